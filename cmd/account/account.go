@@ -27,6 +27,19 @@ var (
 	# Full example
 	vultr-cli account bandwidth
 	`
+	accountInventoryLong = `Lists every resource on the account with its region and monthly cost, along with a summary of
+the count, regions and cost per resource type.
+
+The monthly cost is the list price of the plan for instances, bare metal servers, kubernetes node pools
+and databases, and the billed price for block storage and container registries.  Other resources show
+--- since the API does not expose their price.`
+	accountInventoryExample = `
+	# Full example
+	vultr-cli account inventory
+
+	# Only show the summary per resource type
+	vultr-cli account inventory --summary
+	`
 )
 
 // NewCmdAccount creates a cobra command for Account
@@ -81,9 +94,39 @@ func NewCmdAccount(base *cli.Base) *cobra.Command {
 		},
 	}
 
+	inventory := &cobra.Command{
+		Use:     "inventory",
+		Short:   "List every resource on the account",
+		Aliases: []string{"inv"},
+		Long:    accountInventoryLong,
+		Example: accountInventoryExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			summaryOnly, errSu := cmd.Flags().GetBool("summary")
+			if errSu != nil {
+				return fmt.Errorf("error parsing flag 'summary' for account inventory : %v", errSu)
+			}
+
+			items, err := o.inventory()
+			if err != nil {
+				return fmt.Errorf("error retrieving account inventory : %v", err)
+			}
+
+			data := &AccountInventoryPrinter{Summary: summarize(items)}
+			if !summaryOnly {
+				data.Resources = items
+			}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	inventory.Flags().Bool("summary", false, "(optional) only show the totals per resource type")
+
 	cmd.AddCommand(
 		info,
 		bandwidth,
+		inventory,
 	)
 
 	return cmd
@@ -0,0 +1,393 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// inventoryItem is a single resource of the account.  MonthlyCost is nil
+// when the API does not expose a price for the resource.
+type inventoryItem struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	Label       string   `json:"label"`
+	Region      string   `json:"region"`
+	MonthlyCost *float64 `json:"monthly_cost,omitempty"`
+}
+
+// inventorySummary totals the resources of one type.  MonthlyCost only adds
+// up the resources with a known price.
+type inventorySummary struct {
+	Type        string   `json:"type"`
+	Count       int      `json:"count"`
+	Regions     []string `json:"regions"`
+	MonthlyCost *float64 `json:"monthly_cost,omitempty"`
+}
+
+// planPrices maps plan IDs to their monthly list price
+type planPrices map[string]float64
+
+// cost returns the price of the plan or nil when it is not known
+func (p planPrices) cost(plan string, count int) *float64 {
+	price, ok := p[plan]
+	if !ok {
+		return nil
+	}
+	total := price * float64(count)
+	return &total
+}
+
+// inventoryLister lists the resources of one type
+type inventoryLister func(prices planPrices) ([]inventoryItem, error)
+
+// inventory lists every resource type of the account concurrently
+func (o *options) inventory() ([]inventoryItem, error) {
+	prices, err := o.planPrices()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving plan prices : %v", err)
+	}
+
+	listers := []inventoryLister{
+		o.inventoryInstances,
+		o.inventoryBareMetal,
+		o.inventoryKubernetes,
+		o.inventoryDatabases,
+		o.inventoryLoadBalancers,
+		o.inventoryBlockStorage,
+		o.inventoryObjectStorage,
+		o.inventorySnapshots,
+		o.inventoryReservedIPs,
+		o.inventoryVPCs,
+		o.inventoryContainerRegistries,
+	}
+
+	results := make([][]inventoryItem, len(listers))
+	errs := make([]error, len(listers))
+	var wg sync.WaitGroup
+	for i := range listers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = listers[i](prices)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var items []inventoryItem
+	for i := range results {
+		items = append(items, results[i]...)
+	}
+
+	return items, nil
+}
+
+// planPrices collects the monthly prices of the instance, bare metal and
+// database plans
+func (o *options) planPrices() (planPrices, error) {
+	prices := planPrices{}
+
+	plans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+		plans, meta, _, err := o.Base.Client.Plan.List(o.Base.Context, "all", opts)
+		return plans, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range plans {
+		prices[plans[i].ID] = float64(plans[i].MonthlyCost)
+	}
+
+	metal, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, error) {
+		plans, meta, _, err := o.Base.Client.Plan.ListBareMetal(o.Base.Context, opts)
+		return plans, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range metal {
+		prices[metal[i].ID] = float64(metal[i].MonthlyCost)
+	}
+
+	databases, _, _, err := o.Base.Client.Database.ListPlans(o.Base.Context, &govultr.DBPlanListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range databases {
+		prices[databases[i].ID] = float64(databases[i].MonthlyCost)
+	}
+
+	return prices, nil
+}
+
+func (o *options) inventoryInstances(prices planPrices) ([]inventoryItem, error) {
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances : %v", err)
+	}
+
+	items := make([]inventoryItem, len(instances))
+	for i := range instances {
+		items[i] = inventoryItem{
+			Type:        "instance",
+			ID:          instances[i].ID,
+			Label:       instances[i].Label,
+			Region:      instances[i].Region,
+			MonthlyCost: prices.cost(instances[i].Plan, 1),
+		}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryBareMetal(prices planPrices) ([]inventoryItem, error) {
+	servers, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		servers, meta, _, err := o.Base.Client.BareMetalServer.List(o.Base.Context, opts)
+		return servers, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing bare metal servers : %v", err)
+	}
+
+	items := make([]inventoryItem, len(servers))
+	for i := range servers {
+		items[i] = inventoryItem{
+			Type:        "bare-metal",
+			ID:          servers[i].ID,
+			Label:       servers[i].Label,
+			Region:      servers[i].Region,
+			MonthlyCost: prices.cost(servers[i].Plan, 1),
+		}
+	}
+	return items, nil
+}
+
+// inventoryKubernetes prices clusters by the plans of their node pools.  The
+// cost of a highly available control plane is not included.
+func (o *options) inventoryKubernetes(prices planPrices) ([]inventoryItem, error) {
+	clusters, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Cluster, *govultr.Meta, error) {
+		clusters, meta, _, err := o.Base.Client.Kubernetes.ListClusters(o.Base.Context, opts)
+		return clusters, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing kubernetes clusters : %v", err)
+	}
+
+	items := make([]inventoryItem, len(clusters))
+	for i := range clusters {
+		var total float64
+		known := true
+		for j := range clusters[i].NodePools {
+			cost := prices.cost(clusters[i].NodePools[j].Plan, clusters[i].NodePools[j].NodeQuantity)
+			if cost == nil {
+				known = false
+				break
+			}
+			total += *cost
+		}
+
+		items[i] = inventoryItem{
+			Type:   "kubernetes",
+			ID:     clusters[i].ID,
+			Label:  clusters[i].Label,
+			Region: clusters[i].Region,
+		}
+		if known {
+			items[i].MonthlyCost = &total
+		}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryDatabases(prices planPrices) ([]inventoryItem, error) {
+	databases, _, _, err := o.Base.Client.Database.List(o.Base.Context, &govultr.DBListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing databases : %v", err)
+	}
+
+	items := make([]inventoryItem, len(databases))
+	for i := range databases {
+		items[i] = inventoryItem{
+			Type:        "database",
+			ID:          databases[i].ID,
+			Label:       databases[i].Label,
+			Region:      databases[i].Region,
+			MonthlyCost: prices.cost(databases[i].Plan, 1),
+		}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryLoadBalancers(_ planPrices) ([]inventoryItem, error) {
+	lbs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, error) {
+		lbs, meta, _, err := o.Base.Client.LoadBalancer.List(o.Base.Context, opts)
+		return lbs, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing load balancers : %v", err)
+	}
+
+	items := make([]inventoryItem, len(lbs))
+	for i := range lbs {
+		items[i] = inventoryItem{Type: "load-balancer", ID: lbs[i].ID, Label: lbs[i].Label, Region: lbs[i].Region}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryBlockStorage(_ planPrices) ([]inventoryItem, error) {
+	volumes, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		volumes, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return volumes, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing block storage : %v", err)
+	}
+
+	items := make([]inventoryItem, len(volumes))
+	for i := range volumes {
+		cost := float64(volumes[i].Cost)
+		items[i] = inventoryItem{
+			Type:        "block-storage",
+			ID:          volumes[i].ID,
+			Label:       volumes[i].Label,
+			Region:      volumes[i].Region,
+			MonthlyCost: &cost,
+		}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryObjectStorage(_ planPrices) ([]inventoryItem, error) {
+	stores, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.ObjectStorage, *govultr.Meta, error) {
+		stores, meta, _, err := o.Base.Client.ObjectStorage.List(o.Base.Context, opts)
+		return stores, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing object storage : %v", err)
+	}
+
+	items := make([]inventoryItem, len(stores))
+	for i := range stores {
+		items[i] = inventoryItem{Type: "object-storage", ID: stores[i].ID, Label: stores[i].Label, Region: stores[i].Region}
+	}
+	return items, nil
+}
+
+func (o *options) inventorySnapshots(_ planPrices) ([]inventoryItem, error) {
+	snapshots, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		snapshots, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return snapshots, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots : %v", err)
+	}
+
+	items := make([]inventoryItem, len(snapshots))
+	for i := range snapshots {
+		items[i] = inventoryItem{Type: "snapshot", ID: snapshots[i].ID, Label: snapshots[i].Description}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryReservedIPs(_ planPrices) ([]inventoryItem, error) {
+	ips, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.ReservedIP, *govultr.Meta, error) {
+		ips, meta, _, err := o.Base.Client.ReservedIP.List(o.Base.Context, opts)
+		return ips, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing reserved ips : %v", err)
+	}
+
+	items := make([]inventoryItem, len(ips))
+	for i := range ips {
+		items[i] = inventoryItem{Type: "reserved-ip", ID: ips[i].ID, Label: ips[i].Label, Region: ips[i].Region}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryVPCs(_ planPrices) ([]inventoryItem, error) {
+	vpcs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, error) {
+		vpcs, meta, _, err := o.Base.Client.VPC.List(o.Base.Context, opts)
+		return vpcs, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpcs : %v", err)
+	}
+
+	items := make([]inventoryItem, len(vpcs))
+	for i := range vpcs {
+		items[i] = inventoryItem{Type: "vpc", ID: vpcs[i].ID, Label: vpcs[i].Description, Region: vpcs[i].Region}
+	}
+	return items, nil
+}
+
+func (o *options) inventoryContainerRegistries(_ planPrices) ([]inventoryItem, error) {
+	registries, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.ContainerRegistry, *govultr.Meta, error) {
+		registries, meta, _, err := o.Base.Client.ContainerRegistry.List(o.Base.Context, opts)
+		return registries, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing container registries : %v", err)
+	}
+
+	items := make([]inventoryItem, len(registries))
+	for i := range registries {
+		cost := float64(registries[i].Metadata.Subscription.Billing.MonthlyPrice)
+		items[i] = inventoryItem{
+			Type:        "container-registry",
+			ID:          registries[i].ID,
+			Label:       registries[i].Name,
+			Region:      registries[i].Metadata.Region.Name,
+			MonthlyCost: &cost,
+		}
+	}
+	return items, nil
+}
+
+// summarize totals the inventory by resource type
+func summarize(items []inventoryItem) []inventorySummary {
+	byType := map[string]*inventorySummary{}
+	regions := map[string]map[string]bool{}
+	var order []string
+
+	for i := range items {
+		s, ok := byType[items[i].Type]
+		if !ok {
+			s = &inventorySummary{Type: items[i].Type}
+			byType[items[i].Type] = s
+			regions[items[i].Type] = map[string]bool{}
+			order = append(order, items[i].Type)
+		}
+
+		s.Count++
+		if items[i].Region != "" && !regions[items[i].Type][items[i].Region] {
+			regions[items[i].Type][items[i].Region] = true
+			s.Regions = append(s.Regions, items[i].Region)
+		}
+
+		if items[i].MonthlyCost != nil {
+			if s.MonthlyCost == nil {
+				s.MonthlyCost = new(float64)
+			}
+			*s.MonthlyCost += *items[i].MonthlyCost
+		}
+	}
+
+	summaries := make([]inventorySummary, len(order))
+	for i := range order {
+		summaries[i] = *byType[order[i]]
+		sort.Strings(summaries[i].Regions)
+	}
+
+	return summaries
+}
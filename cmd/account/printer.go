@@ -156,3 +156,84 @@ func (a *AccountBandwidthPrinter) Data() [][]string {
 func (a *AccountBandwidthPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// AccountInventoryPrinter ...
+type AccountInventoryPrinter struct {
+	Summary   []inventorySummary `json:"summary"`
+	Resources []inventoryItem    `json:"resources,omitempty"`
+}
+
+// JSON ...
+func (a *AccountInventoryPrinter) JSON() []byte {
+	return printer.MarshalObject(a, "json")
+}
+
+// YAML ...
+func (a *AccountInventoryPrinter) YAML() []byte {
+	return printer.MarshalObject(a, "yaml")
+}
+
+// Columns ...
+func (a *AccountInventoryPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (a *AccountInventoryPrinter) Data() [][]string {
+	data := [][]string{
+		{"SUMMARY"},
+		{"TYPE", "COUNT", "REGIONS", "MONTHLY COST"},
+	}
+
+	var count int
+	var total float64
+	for i := range a.Summary {
+		data = append(data, []string{
+			a.Summary[i].Type,
+			strconv.Itoa(a.Summary[i].Count),
+			printer.ArrayOfStringsToString(a.Summary[i].Regions),
+			inventoryCost(a.Summary[i].MonthlyCost),
+		})
+		count += a.Summary[i].Count
+		if a.Summary[i].MonthlyCost != nil {
+			total += *a.Summary[i].MonthlyCost
+		}
+	}
+	data = append(data, []string{"TOTAL", strconv.Itoa(count), "", inventoryCost(&total)})
+
+	if a.Resources == nil {
+		return data
+	}
+
+	data = append(data,
+		[]string{" "},
+		[]string{"RESOURCES"},
+		[]string{"TYPE", "ID", "LABEL", "REGION", "MONTHLY COST"},
+	)
+	for i := range a.Resources {
+		data = append(data, []string{
+			a.Resources[i].Type,
+			a.Resources[i].ID,
+			a.Resources[i].Label,
+			a.Resources[i].Region,
+			inventoryCost(a.Resources[i].MonthlyCost),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (a *AccountInventoryPrinter) Paging() [][]string {
+	return nil
+}
+
+// inventoryCost formats a monthly cost which may be unknown
+func inventoryCost(cost *float64) string {
+	if cost == nil {
+		return "---"
+	}
+	return fmt.Sprintf("$%s", strconv.FormatFloat(*cost, 'f', utils.FloatPrecision, 64))
+}
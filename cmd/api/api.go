@@ -0,0 +1,209 @@
+// Package api provides the functionality for sending raw requests to the
+// Vultr API
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// apiVersionPrefix is added to paths which don't start with it
+const apiVersionPrefix = "/v2/"
+
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+var (
+	apiLong = `Send a request to any endpoint of the Vultr API with the configured API key and print the response.
+
+This reaches endpoints which don't have a command yet.  The path is relative to the API, /v2/ is added
+when it is left out.  The body given with --data is sent as JSON, either inline or read from a file
+with @path, or from stdin with @-.  Files may be YAML or JSON.
+
+The response is printed as JSON, or YAML with --output yaml, and --query filters it like any other
+command.  Requests which change resources honor --dry-run and are recorded in the audit log.`
+	apiExample = `
+	# List instances
+	vultr-cli api GET /v2/instances
+
+	# Query parameters
+	vultr-cli api GET instances --param per_page=5 --param tag=web
+
+	# Create a resource with an inline body
+	vultr-cli api POST /v2/ssh-keys --data '{"name": "laptop", "ssh_key": "ssh-ed25519 AAAA..."}'
+
+	# Read the body from a file or stdin
+	vultr-cli api PATCH /v2/instances/cb676a46-66fd-4dfb-b839-443f2e6c0b60 --data @instance.yaml
+	echo '{"label": "web"}' | vultr-cli api PATCH /v2/instances/cb676a46-66fd-4dfb-b839-443f2e6c0b60 --data @-
+	`
+)
+
+// NewCmdAPI provides the CLI command for raw API requests
+func NewCmdAPI(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "api <method> <path>",
+		Short:   "Send a raw request to the Vultr API",
+		Long:    apiLong,
+		Example: apiExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("please provide a method and a path, such as GET /v2/instances")
+			}
+			return nil
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, errDa := cmd.Flags().GetString("data")
+			if errDa != nil {
+				return fmt.Errorf("error parsing flag 'data' for api : %v", errDa)
+			}
+
+			params, errPa := cmd.Flags().GetStringArray("param")
+			if errPa != nil {
+				return fmt.Errorf("error parsing flag 'param' for api : %v", errPa)
+			}
+
+			method := strings.ToUpper(args[0])
+			if !slices.Contains(methods, method) {
+				return fmt.Errorf("invalid method %q, must be one of %s", args[0], strings.Join(methods, ", "))
+			}
+
+			uri, err := requestURI(args[1], params)
+			if err != nil {
+				return err
+			}
+
+			body, err := requestBody(data)
+			if err != nil {
+				return err
+			}
+
+			response, err := o.send(method, uri, body)
+			if err != nil {
+				return fmt.Errorf("error sending %s %s : %v", method, uri, err)
+			}
+
+			if response == nil {
+				o.Base.Printer.Display(printer.Info(fmt.Sprintf("%s %s succeeded", method, uri)), nil)
+				return nil
+			}
+
+			o.Base.Printer.Display(&APIPrinter{Response: response}, nil)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(
+		"data",
+		"d",
+		"",
+		"(optional) JSON body of the request, or @path to read a YAML or JSON file, or @- to read stdin",
+	)
+	cmd.Flags().StringArrayP("param", "p", nil, "(optional) key=value query parameter, may be repeated")
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// send sends the request and returns the decoded response body, which is
+// nil when the response is empty
+func (o *options) send(method, uri string, body interface{}) (interface{}, error) {
+	req, err := o.Base.Client.NewRequest(o.Base.Context, method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.Base.Client.DoWithContext(o.Base.Context, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strings.TrimSpace(string(raw))) == 0 {
+		return nil, nil
+	}
+
+	var response interface{}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		// not JSON, print it as it came
+		return string(raw), nil //nolint:nilerr
+	}
+
+	return response, nil
+}
+
+// requestURI returns the path with the API version prefix and the query
+// parameters
+func requestURI(path string, params []string) (string, error) {
+	if strings.Contains(path, "://") {
+		return "", fmt.Errorf("please provide the path of the endpoint, such as /v2/instances, not %s", path)
+	}
+
+	if !strings.HasPrefix(path, apiVersionPrefix) {
+		path = apiVersionPrefix + strings.TrimPrefix(strings.TrimPrefix(path, "/"), "v2/")
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s : %v", path, err)
+	}
+
+	query := u.Query()
+	for _, p := range params {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || key == "" {
+			return "", fmt.Errorf("invalid parameter %q, must be key=value", p)
+		}
+		query.Add(key, value)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// requestBody returns the body given with --data, inline JSON or a YAML or
+// JSON file when it starts with @
+func requestBody(data string) (interface{}, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	if path, ok := strings.CutPrefix(data, "@"); ok {
+		var body interface{}
+		if err := utils.ReadSpecFile(path, &body); err != nil {
+			return nil, fmt.Errorf("error reading request body : %v", err)
+		}
+		return body, nil
+	}
+
+	if !json.Valid([]byte(data)) {
+		return nil, errors.New("the request body given with --data is not valid JSON")
+	}
+
+	return json.RawMessage(data), nil
+}
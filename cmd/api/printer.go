@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// APIPrinter ...
+type APIPrinter struct {
+	Response interface{}
+}
+
+// JSON ...
+func (a *APIPrinter) JSON() []byte {
+	return printer.MarshalObject(a.Response, "json")
+}
+
+// YAML ...
+func (a *APIPrinter) YAML() []byte {
+	return printer.MarshalObject(a.Response, "yaml")
+}
+
+// Columns ...
+func (a *APIPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data prints the response as indented JSON, since its shape depends on the
+// endpoint
+func (a *APIPrinter) Data() [][]string {
+	if s, ok := a.Response.(string); ok {
+		return [][]string{0: {s}}
+	}
+	return [][]string{0: {string(a.JSON())}}
+}
+
+// Paging ...
+func (a *APIPrinter) Paging() [][]string {
+	return nil
+}
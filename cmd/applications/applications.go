@@ -2,8 +2,9 @@
 package applications
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -30,6 +31,18 @@ var (
 	# Shortened with alias commands
 	vultr-cli a l
 	`
+
+	findLong = `Search the one-click and marketplace applications by name, printing the IDs to
+use with --app or --image on instance create. Every search term has to match, either
+as part of the name or as letters appearing in the same order. The best matches are
+listed first.`
+	findExample = `
+	# Full example
+	vultr-cli applications find docker
+
+	# Shortened with alias commands
+	vultr-cli a f word press
+	`
 )
 
 // NewCmdApplications creates cobra command for applications
@@ -56,7 +69,9 @@ func NewCmdApplications(base *cli.Base) *cobra.Command {
 		Long:    listLong,
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			apps, meta, err := o.list()
+			o.Base.Options = utils.GetPaging(cmd)
+
+			apps, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving application list : %v", err)
 			}
@@ -78,8 +93,35 @@ func NewCmdApplications(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
+
+	// Find
+	find := &cobra.Command{
+		Use:     "find <search terms>",
+		Short:   "Search applications by name",
+		Aliases: []string{"f", "search"},
+		Long:    findLong,
+		Example: findExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a search term")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apps, err := o.find(args)
+			if err != nil {
+				return fmt.Errorf("error retrieving application list : %v", err)
+			}
+
+			data := &ApplicationsPrinter{Applications: apps}
+			o.Base.Printer.Display(data, nil)
 
-	cmd.AddCommand(list)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(list, find)
 	return cmd
 }
 
@@ -88,7 +130,45 @@ type options struct {
 	Printer *printer.Output
 }
 
+// find returns the applications matching every term, best match first.  The
+// deploy name is searched as well so vendors can be used as terms.
+func (o *options) find(terms []string) ([]govultr.Application, error) {
+	all, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Application, *govultr.Meta, error) {
+		all, meta, _, err := o.Base.Client.Application.List(o.Base.Context, opts)
+		return all, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		app   govultr.Application
+		score int
+	}
+
+	var matches []match
+	for i := range all {
+		if score, ok := utils.FuzzyScore(terms, all[i].Name+" "+all[i].DeployName); ok {
+			matches = append(matches, match{app: all[i], score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].app.Name < matches[j].app.Name
+	})
+
+	var found []govultr.Application
+	for i := range matches {
+		found = append(found, matches[i].app)
+	}
+
+	return found, nil
+}
+
 func (o *options) list() ([]govultr.Application, *govultr.Meta, error) {
-	list, meta, _, err := o.Base.Client.Application.List(context.Background(), o.Base.Options)
+	list, meta, _, err := o.Base.Client.Application.List(o.Base.Context, o.Base.Options)
 	return list, meta, err
 }
@@ -0,0 +1,206 @@
+// Package audit provides the functionality for querying the local log of
+// changes made through the CLI
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// maxLineSize is the longest entry read from the log, responses of large
+// resources can exceed the default buffer of the scanner
+const maxLineSize = 16 * 1024 * 1024
+
+var (
+	auditLong = `Query the audit log of the changes made through the CLI.
+
+The audit log is off by default.  Once turned on with 'vultr-cli config set audit true', every
+request which creates, updates or deletes a resource is appended to ~/.vultr-cli/audit.jsonl
+along with the command and arguments which made it, the time and the response of the API.
+Passwords, secrets and API keys are redacted from the arguments and responses.
+`
+	auditExample = `
+	# Full example
+	vultr-cli audit list
+	`
+	listLong    = `List the entries of the audit log, oldest first`
+	listExample = `
+	# Full example
+	vultr-cli audit list
+
+	# Changes made by instance commands in the last day
+	vultr-cli audit list --command instance --since 24h
+
+	# The last 10 changes with the responses of the API
+	vultr-cli audit list --limit 10 -o json
+	`
+)
+
+// NewCmdAudit provides the CLI command for the audit log
+func NewCmdAudit(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "audit",
+		Short:   "Commands to query the audit log",
+		Long:    auditLong,
+		Example: auditExample,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.SetOptions(o.Base, cmd, args)
+		},
+	}
+
+	// List
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List the changes in the audit log",
+		Aliases: []string{"l"},
+		Long:    listLong,
+		Example: listExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command, errCo := cmd.Flags().GetString("command")
+			if errCo != nil {
+				return fmt.Errorf("error parsing flag 'command' for audit list : %v", errCo)
+			}
+
+			since, errSi := cmd.Flags().GetString("since")
+			if errSi != nil {
+				return fmt.Errorf("error parsing flag 'since' for audit list : %v", errSi)
+			}
+
+			limit, errLi := cmd.Flags().GetInt("limit")
+			if errLi != nil {
+				return fmt.Errorf("error parsing flag 'limit' for audit list : %v", errLi)
+			}
+
+			f := filter{command: strings.TrimSpace(command), limit: limit}
+			if since != "" {
+				start, err := parseSince(since)
+				if err != nil {
+					return err
+				}
+				f.since = start
+			}
+
+			entries, err := readLog(f)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&AuditPrinter{Entries: entries}, nil)
+
+			return nil
+		},
+	}
+
+	list.Flags().String(
+		"command",
+		"",
+		"(optional) only list changes made by commands starting with this, e.g. 'dns record'",
+	)
+	list.Flags().String("since", "", "(optional) only list changes since an age such as 24h or 7d, or an RFC 3339 time")
+	list.Flags().IntP("limit", "n", 0, "(optional) only list the most recent number of changes")
+
+	cmd.AddCommand(
+		list,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// filter selects the entries of the log to list
+type filter struct {
+	command string
+	since   time.Time
+	limit   int
+}
+
+// match reports whether the entry passes the filter
+func (f *filter) match(e *cli.AuditEntry) bool {
+	if !f.since.IsZero() && e.Time.Before(f.since) {
+		return false
+	}
+
+	if f.command != "" && e.Command != f.command && !strings.HasPrefix(e.Command, f.command+" ") {
+		return false
+	}
+
+	return true
+}
+
+// parseSince reads an age such as 24h or 7d, or an RFC 3339 time
+func parseSince(since string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+
+	age, err := utils.ParseAge(since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"invalid value %q for since, must be an age such as 24h or 7d or an RFC 3339 time",
+			since,
+		)
+	}
+
+	return time.Now().Add(-age), nil
+}
+
+// readLog returns the entries of the audit log which pass the filter.  A
+// missing log has no entries
+func readLog(f filter) ([]cli.AuditEntry, error) {
+	path, err := cli.AuditLogPath()
+	if err != nil {
+		return nil, fmt.Errorf("error finding the audit log : %v", err)
+	}
+
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read the audit log %s : %v", path, err)
+	}
+	defer file.Close()
+
+	var entries []cli.AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var e cli.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("error parsing line %d of the audit log %s : %v", n, path, err)
+		}
+
+		if f.match(&e) {
+			entries = append(entries, e)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read the audit log %s : %v", path, err)
+	}
+
+	if f.limit > 0 && len(entries) > f.limit {
+		entries = entries[len(entries)-f.limit:]
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// AuditPrinter ...
+type AuditPrinter struct {
+	Entries []cli.AuditEntry `json:"entries"`
+}
+
+// JSON ...
+func (a *AuditPrinter) JSON() []byte {
+	return printer.MarshalObject(a, "json")
+}
+
+// YAML ...
+func (a *AuditPrinter) YAML() []byte {
+	return printer.MarshalObject(a, "yaml")
+}
+
+// Columns ...
+func (a *AuditPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TIME",
+		"COMMAND",
+		"METHOD",
+		"PATH",
+		"STATUS",
+		"ARGS",
+	}}
+}
+
+// Data ...
+func (a *AuditPrinter) Data() [][]string {
+	if len(a.Entries) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range a.Entries {
+		status := strconv.Itoa(a.Entries[i].Status)
+		if a.Entries[i].Error != "" {
+			status = a.Entries[i].Error
+		}
+
+		data = append(data, []string{
+			a.Entries[i].Time.Local().Format(time.DateTime),
+			a.Entries[i].Command,
+			a.Entries[i].Method,
+			a.Entries[i].Path,
+			status,
+			strings.Join(a.Entries[i].Args, " "),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (a *AuditPrinter) Paging() [][]string {
+	return nil
+}
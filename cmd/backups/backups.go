@@ -4,6 +4,9 @@ package backups
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -14,10 +17,16 @@ import (
 var (
 	backupsLong    = ``
 	backupsExample = ``
-	listLong       = ``
-	listExample    = ``
-	getLong        = ``
-	getExample     = ``
+	listLong       = `List the backups of the account or, with --instance, of a single instance`
+	listExample    = `
+	# Full example
+	vultr-cli backups list
+
+	# Backups of an instance
+	vultr-cli backups list --instance 2126b7d9-5e2a-491e-8840-838aa6b5f294
+	`
+	getLong    = ``
+	getExample = ``
 )
 
 // NewCmdBackups provides the backup command for the CLI
@@ -47,8 +56,14 @@ func NewCmdBackups(base *cli.Base) *cobra.Command {
 		Long:    listLong,
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			instance, errIn := cmd.Flags().GetString("instance")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'instance' for backups list : %v", errIn)
+			}
+
 			o.Base.Options = utils.GetPaging(cmd)
-			backups, meta, err := o.list()
+			o.InstanceID = instance
+			backups, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving backups list : %v", err)
 			}
@@ -69,6 +84,8 @@ func NewCmdBackups(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
+	list.Flags().StringP("instance", "i", "", "(optional) only list the backups of this instance ID")
 
 	// Get
 	get := &cobra.Command{
@@ -100,14 +117,49 @@ func NewCmdBackups(base *cli.Base) *cobra.Command {
 }
 
 type options struct {
-	Base *cli.Base
+	Base       *cli.Base
+	InstanceID string
 }
 
 func (b *options) list() ([]govultr.Backup, *govultr.Meta, error) {
+	if b.InstanceID != "" {
+		return b.listByInstance()
+	}
+
 	backups, meta, _, err := b.Base.Client.Backup.List(b.Base.Context, b.Base.Options)
 	return backups, meta, err
 }
 
+// listByInstance lists the backups of an instance.  The API filters on the
+// instance_id query parameter which govultr does not send so the request is
+// built here.
+func (b *options) listByInstance() ([]govultr.Backup, *govultr.Meta, error) {
+	req, err := b.Base.Client.NewRequest(b.Base.Context, http.MethodGet, "/v2/backups", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := url.Values{}
+	query.Set("instance_id", b.InstanceID)
+	if b.Base.Options.PerPage != 0 {
+		query.Set("per_page", strconv.Itoa(b.Base.Options.PerPage))
+	}
+	if b.Base.Options.Cursor != "" {
+		query.Set("cursor", b.Base.Options.Cursor)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	backups := struct {
+		Backups []govultr.Backup `json:"backups"`
+		Meta    *govultr.Meta    `json:"meta"`
+	}{}
+	if _, err := b.Base.Client.DoWithContext(b.Base.Context, req, &backups); err != nil {
+		return nil, nil, err
+	}
+
+	return backups.Backups, backups.Meta, nil
+}
+
 func (b *options) get() (*govultr.Backup, error) {
 	backup, _, err := b.Base.Client.Backup.Get(b.Base.Context, b.Base.Args[0])
 	return backup, err
@@ -3,16 +3,21 @@
 package baremetal
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/applications"
 	"github.com/vultr/vultr-cli/v3/cmd/ip"
+	"github.com/vultr/vultr-cli/v3/cmd/marketplace"
 	"github.com/vultr/vultr-cli/v3/cmd/operatingsystems"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/userdata"
@@ -42,17 +47,40 @@ var (
 	and you will still be billed for the machine.
 	`
 
-	haltExample = ``
+	haltExample = `
+	# Full example
+	vultr-cli bare-metal halt 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Halt every bare metal server tagged rack1, 5 at a time
+	vultr-cli bare-metal halt --tag rack1 --parallel 5
+	`
 
-	startLong     = ``
-	startExample  = ``
+	startLong    = ``
+	startExample = `
+	# Full example
+	vultr-cli bare-metal start 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Start every bare metal server tagged rack1
+	vultr-cli bare-metal start --tag rack1
+	`
 	rebootLong    = `This is a hard reboot, which means that the server is powered off, then back on.`
-	rebootExample = ``
+	rebootExample = `
+	# Full example
+	vultr-cli bare-metal reboot 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Reboot every bare metal server tagged rack1, 5 at a time
+	vultr-cli bare-metal reboot --tag rack1 --parallel 5
+	`
 
 	reinstallLong = `Reinstall the operating system on a bare metal server.
 All data will be permanently lost, but the IP address will remain the same.
-There is no going back from this call.`
-	reinstallExample = ``
+There is no going back from this call.
+
+Use --wait to block until the server is active again.`
+	reinstallExample = `
+	# Full example
+	vultr-cli bare-metal reinstall 2126b7d9-5e2a-491e-8840-838aa6b5f294 --wait
+	`
 
 	tagsLong    = `Update the tags on a bare metal server`
 	tagsExample = `
@@ -63,6 +91,22 @@ There is no going back from this call.`
 	vultr-cli bm tags <bareMetalID> -t="tag-1,tag-2"
 	`
 
+	vpcListLong    = `List the VPC networks attached to the specified bare metal server`
+	vpcListExample = `
+	# Full example
+	vultr-cli bare-metal vpc list <bareMetalID>
+	`
+	vpcAttachLong    = `Attaches an existing VPC network to the specified bare metal server`
+	vpcAttachExample = `
+	# Full example
+	vultr-cli bare-metal vpc attach <bareMetalID> 2126b7d9-5e2a-491e-8840-838aa6b5f294
+	`
+	vpcDetachLong    = `Detaches an existing VPC network from the specified bare metal server`
+	vpcDetachExample = `
+	# Full example
+	vultr-cli bare-metal vpc detach <bareMetalID> 2126b7d9-5e2a-491e-8840-838aa6b5f294
+	`
+
 	vpc2AttachLong    = `Attaches an existing VPC 2.0 network to the specified bare metal server`
 	vpc2AttachExample = `
 	# Full example
@@ -102,8 +146,17 @@ There is no going back from this call.`
 	userDataSetLong    = ``
 	userDataSetExample = ``
 
-	vncLong    = ``
-	vncExample = ``
+	vncLong = `Get the URL of the web console of a bare metal server.  The console works
+without network access to the server which makes it useful for recovery.
+
+Use --open to open the console in the default browser.`
+	vncExample = `
+	# Full example
+	vultr-cli bare-metal vnc 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Open the console in the browser
+	vultr-cli bare-metal console 2126b7d9-5e2a-491e-8840-838aa6b5f294 --open
+	`
 
 	bandwidthLong    = ``
 	bandwidthExample = ``
@@ -150,7 +203,7 @@ func NewCmdBareMetal(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			list, meta, err := o.list()
+			list, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving bare metal list : %v", err)
 			}
@@ -171,6 +224,7 @@ func NewCmdBareMetal(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -210,6 +264,35 @@ func NewCmdBareMetal(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flags for bare metal create : %v", errParse)
 			}
 
+			if req.ImageID != "" {
+				if err := marketplace.CheckVariables(o.Base, req.ImageID, req.AppVariables); err != nil {
+					return err
+				}
+			} else if len(req.AppVariables) > 0 {
+				return errors.New("marketplace variables can only be used with --image")
+			}
+
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing flag 'yes' for bare metal create : %v", errYs)
+			}
+
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			action := fmt.Sprintf("Create a %s bare metal server in %s", req.Plan, req.Region)
+			ok, err := utils.ConfirmPrice(yes, action, func() (float64, error) {
+				return utils.BareMetalPlanPrice(o.Base, req.Plan)
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("bare metal create was cancelled")
+			}
+
 			o.CreateReq = req
 
 			bm, err := o.create()
@@ -217,6 +300,12 @@ func NewCmdBareMetal(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error with bare metal create : %v", err)
 			}
 
+			if wait {
+				if bm, err = o.waitActive(bm.ID, "", timeout); err != nil {
+					return err
+				}
+			}
+
 			data := &BareMetalPrinter{BareMetal: *bm}
 			o.Base.Printer.Display(data, err)
 
@@ -305,6 +394,13 @@ Defaults to 'yes'.`,
 		`(optional) The raid configuration to use when provisioning this server. 
 Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 	)
+	create.Flags().StringToString(
+		"marketplace-variable",
+		nil,
+		"(optional) key=value pairs for the user-supplied variables of the marketplace app given with --image",
+	)
+	utils.AddYesFlag(create)
+	utils.AddWaitFlags(create, "the bare metal server is active")
 	if err := create.MarkFlagRequired("region"); err != nil {
 		fmt.Printf("error marking bare metal create 'region' flag required: %v", err)
 		os.Exit(1)
@@ -318,6 +414,8 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 	installFlags := []string{"app", "snapshot", "os", "image"}
 	create.MarkFlagsMutuallyExclusive(installFlags...)
 	create.MarkFlagsOneRequired(installFlags...)
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
+	utils.SetConfigDefault(create, "os", utils.DefaultOSKey, "app", "snapshot", "image")
 
 	// Delete
 	del := &cobra.Command{
@@ -350,12 +448,29 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		Long:    haltLong,
 		Example: haltExample,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide a bare metal ID")
+			if len(args) < 1 && !cmd.Flags().Changed("tag") {
+				return errors.New("please provide a bare metal ID or a tag")
+			}
+			if len(args) > 0 && cmd.Flags().Changed("tag") {
+				return errors.New("please provide either a bare metal ID or a tag")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, errTg := cmd.Flags().GetString("tag")
+			if errTg != nil {
+				return fmt.Errorf("error parsing tag flag for bare metal halt : %v", errTg)
+			}
+
+			parallel, errPa := cmd.Flags().GetInt("parallel")
+			if errPa != nil {
+				return fmt.Errorf("error parsing parallel flag for bare metal halt : %v", errPa)
+			}
+
+			if tag != "" {
+				return o.powerByTag(tag, parallel, o.Base.Client.BareMetalServer.Halt)
+			}
+
 			if err := o.halt(); err != nil {
 				return fmt.Errorf("error halting bare metal : %v", err)
 			}
@@ -372,12 +487,29 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		Long:    startLong,
 		Example: startExample,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide a bare metal ID")
+			if len(args) < 1 && !cmd.Flags().Changed("tag") {
+				return errors.New("please provide a bare metal ID or a tag")
+			}
+			if len(args) > 0 && cmd.Flags().Changed("tag") {
+				return errors.New("please provide either a bare metal ID or a tag")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, errTg := cmd.Flags().GetString("tag")
+			if errTg != nil {
+				return fmt.Errorf("error parsing tag flag for bare metal start : %v", errTg)
+			}
+
+			parallel, errPa := cmd.Flags().GetInt("parallel")
+			if errPa != nil {
+				return fmt.Errorf("error parsing parallel flag for bare metal start : %v", errPa)
+			}
+
+			if tag != "" {
+				return o.powerByTag(tag, parallel, o.Base.Client.BareMetalServer.Start)
+			}
+
 			if err := o.start(); err != nil {
 				return fmt.Errorf("error starting bare metal : %v", err)
 			}
@@ -396,12 +528,29 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		Long:    rebootLong,
 		Example: rebootExample,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide a bare metal ID")
+			if len(args) < 1 && !cmd.Flags().Changed("tag") {
+				return errors.New("please provide a bare metal ID or a tag")
+			}
+			if len(args) > 0 && cmd.Flags().Changed("tag") {
+				return errors.New("please provide either a bare metal ID or a tag")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, errTg := cmd.Flags().GetString("tag")
+			if errTg != nil {
+				return fmt.Errorf("error parsing tag flag for bare metal reboot : %v", errTg)
+			}
+
+			parallel, errPa := cmd.Flags().GetInt("parallel")
+			if errPa != nil {
+				return fmt.Errorf("error parsing parallel flag for bare metal reboot : %v", errPa)
+			}
+
+			if tag != "" {
+				return o.powerByTag(tag, parallel, o.Base.Client.BareMetalServer.Reboot)
+			}
+
 			if err := o.reboot(); err != nil {
 				return fmt.Errorf("error rebooting bare metal : %v", err)
 			}
@@ -412,6 +561,11 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 	}
 
+	for _, c := range []*cobra.Command{halt, start, reboot} {
+		c.Flags().StringP("tag", "t", "", "(optional) run the action on every bare metal server with this tag")
+		c.Flags().Int("parallel", bulkParallel, "(optional) number of bare metal servers acted on at once with --tag")
+	}
+
 	// Reinstall
 	reinstall := &cobra.Command{
 		Use:     "reinstall <bareMetalID>",
@@ -425,16 +579,34 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := o.reinstall(); err != nil {
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			bm, err := o.reinstall()
+			if err != nil {
 				return fmt.Errorf("error reinstalling bare metal : %v", err)
 			}
 
-			o.Base.Printer.Display(printer.Info("bare metal server has initiated reinstallation"), nil)
+			if !wait {
+				o.Base.Printer.Display(printer.Info("bare metal server has initiated reinstallation"), nil)
+				return nil
+			}
+
+			if bm, err = o.waitActive(bm.ID, bm.Status, timeout); err != nil {
+				return err
+			}
+
+			data := &BareMetalPrinter{BareMetal: *bm}
+			o.Base.Printer.Display(data, nil)
 
 			return nil
 		},
 	}
 
+	utils.AddWaitFlags(reinstall, "the bare metal server is active again")
+
 	// Application
 	application := &cobra.Command{
 		Use:     "app",
@@ -742,6 +914,7 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 	vnc := &cobra.Command{
 		Use:     "vnc <Bare Metal ID>",
 		Short:   "Get a bare metal server's VNC url",
+		Aliases: []string{"console"},
 		Long:    vncLong,
 		Example: vncExample,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -751,11 +924,22 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			open, errOp := cmd.Flags().GetBool("open")
+			if errOp != nil {
+				return fmt.Errorf("error parsing open flag for bare metal vnc : %v", errOp)
+			}
+
 			vnc, err := o.getVNCURL()
 			if err != nil {
 				return fmt.Errorf("error retrieving bare metal VNC URL : %v", err)
 			}
 
+			if open {
+				if err := utils.OpenURL(o.Base.Context, vnc.URL); err != nil {
+					return fmt.Errorf("error opening bare metal VNC URL : %v", err)
+				}
+			}
+
 			data := &BareMetalVNCPrinter{VNC: *vnc}
 			o.Base.Printer.Display(data, nil)
 
@@ -763,6 +947,8 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 	}
 
+	vnc.Flags().Bool("open", false, "(optional) open the console in the default browser")
+
 	// Bandwidth
 	bandwidth := &cobra.Command{
 		Use:     "bandwidth <Bare Metal ID>",
@@ -838,7 +1024,7 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			ipv4, meta, err := o.getIPv4Addresses()
+			ipv4, meta, err := utils.ListAll(cmd, o.Base.Options, o.getIPv4Addresses)
 			if err != nil {
 				return fmt.Errorf("error retrieving bare metal IPv4 information : %v", err)
 			}
@@ -849,6 +1035,8 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 	}
 
+	utils.AddListAllFlag(ipv4)
+
 	// IPv6 Addresses
 	ipv6 := &cobra.Command{
 		Use:     "ipv6 <Bare Metal ID>",
@@ -863,7 +1051,7 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			ipv6, meta, err := o.getIPv6Addresses()
+			ipv6, meta, err := utils.ListAll(cmd, o.Base.Options, o.getIPv6Addresses)
 			if err != nil {
 				return fmt.Errorf("error retrieving bare metal IPv6 information : %v", err)
 			}
@@ -874,6 +1062,88 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 	}
 
+	utils.AddListAllFlag(ipv6)
+
+	// VPC
+	vpc := &cobra.Command{
+		Use:   "vpc",
+		Short: "Commands to manage VPCs on bare metal servers",
+	}
+
+	// VPC List
+	vpcList := &cobra.Command{
+		Use:     "list <Bare Metal ID>",
+		Short:   "List all VPC networks attached to a server",
+		Aliases: []string{"l"},
+		Long:    vpcListLong,
+		Example: vpcListExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a bare metal ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vpcs, err := o.vpcNetworksList()
+			if err != nil {
+				return fmt.Errorf("error retrieving bare metal vpc information : %v", err)
+			}
+
+			data := &BareMetalVPCsPrinter{VPCs: vpcs}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	// VPC Attach
+	vpcAttach := &cobra.Command{
+		Use:     "attach <Bare Metal ID> <VPC ID>",
+		Short:   "Attach a VPC network to a server",
+		Long:    vpcAttachLong,
+		Example: vpcAttachExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a bare metal ID and a VPC ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.vpcNetworksAttach(); err != nil {
+				return fmt.Errorf("error attaching bare metal to VPC : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info("bare metal server has been attached to VPC network"), nil)
+
+			return nil
+		},
+	}
+
+	// VPC Detach
+	vpcDetach := &cobra.Command{
+		Use:     "detach <Bare Metal ID> <VPC ID>",
+		Short:   "Detach a VPC network from a server",
+		Long:    vpcDetachLong,
+		Example: vpcDetachExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a bare metal ID and a VPC ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.vpcNetworksDetach(); err != nil {
+				return fmt.Errorf("error detaching bare metal VPC : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info("bare metal server has been detached from VPC network"), nil)
+
+			return nil
+		},
+	}
+
+	vpc.AddCommand(vpcList, vpcAttach, vpcDetach)
+
 	// VPC2
 	vpc2 := &cobra.Command{
 		Use:        "vpc2",
@@ -1015,12 +1285,20 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		tags,
 		ipv4,
 		ipv6,
+		vpc,
 		vpc2,
 	)
 
+	complete := utils.CompleteBareMetals(o.Base)
+	utils.RegisterCompletion(cmd, "<Bare Metal ID>", complete)
+	utils.RegisterCompletion(cmd, "<bareMetalID>", complete)
+
 	return cmd
 }
 
+// bulkParallel is how many servers a tag action runs on at once by default
+const bulkParallel = 5
+
 type options struct {
 	Base      *cli.Base
 	CreateReq *govultr.BareMetalCreate
@@ -1065,9 +1343,88 @@ func (b *options) reboot() error {
 	return b.Base.Client.BareMetalServer.Reboot(b.Base.Context, b.Base.Args[0])
 }
 
-func (b *options) reinstall() error {
-	_, _, err := b.Base.Client.BareMetalServer.Reinstall(b.Base.Context, b.Base.Args[0])
-	return err
+// powerByTag runs the power action on every bare metal server with the tag,
+// at most parallel at a time, and displays the result for each server
+func (b *options) powerByTag(tag string, parallel int, action func(context.Context, string) error) error {
+	if parallel < 1 {
+		return errors.New("parallel must be at least 1")
+	}
+
+	tagged, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		opts.Tag = tag
+		tagged, meta, _, err := b.Base.Client.BareMetalServer.List(b.Base.Context, opts)
+		return tagged, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving bare metal list : %v", err)
+	}
+
+	var servers []govultr.BareMetalServer
+	for i := range tagged {
+		if slices.Contains(tagged[i].Tags, tag) {
+			servers = append(servers, tagged[i])
+		}
+	}
+
+	if len(servers) == 0 {
+		return fmt.Errorf("no bare metal servers have the tag %s", tag)
+	}
+
+	results := make([]actionResult, len(servers))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range servers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = actionResult{ID: servers[i].ID, Label: servers[i].Label, Status: "ok"}
+			if err := action(b.Base.Context, servers[i].ID); err != nil {
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	data := &BareMetalActionsPrinter{Results: results}
+	b.Base.Printer.Display(data, nil)
+
+	if failed := data.failed(); failed > 0 {
+		return fmt.Errorf("%d of %d bare metal servers failed", failed, len(results))
+	}
+
+	return nil
+}
+
+func (b *options) reinstall() (*govultr.BareMetalServer, error) {
+	bm, _, err := b.Base.Client.BareMetalServer.Reinstall(b.Base.Context, b.Base.Args[0])
+	return bm, err
+}
+
+// waitActive polls the bare metal server until it is active.  The status
+// returned by the action is passed in so a server which has not left the
+// active state yet is not mistaken for a finished one.
+func (b *options) waitActive(id, status string, timeout time.Duration) (*govultr.BareMetalServer, error) {
+	left := status != "active"
+
+	var bm *govultr.BareMetalServer
+	err := b.Base.Wait("bare metal "+id, timeout, func() (string, bool, error) {
+		var err error
+		if bm, _, err = b.Base.Client.BareMetalServer.Get(b.Base.Context, id); err != nil {
+			return "", false, err
+		}
+
+		if bm.Status != "active" {
+			left = true
+		}
+
+		return bm.Status, left && bm.Status == "active", nil
+	})
+
+	return bm, err
 }
 
 func (b *options) getUpgrades() (*govultr.Upgrades, error) {
@@ -1100,6 +1457,19 @@ func (b *options) getIPv6Addresses() ([]govultr.IPv6, *govultr.Meta, error) {
 	return ips, meta, err
 }
 
+func (b *options) vpcNetworksList() ([]govultr.VPCInfo, error) {
+	vpcs, _, err := b.Base.Client.BareMetalServer.ListVPCInfo(b.Base.Context, b.Base.Args[0])
+	return vpcs, err
+}
+
+func (b *options) vpcNetworksAttach() error {
+	return b.Base.Client.BareMetalServer.AttachVPC(b.Base.Context, b.Base.Args[0], b.Base.Args[1])
+}
+
+func (b *options) vpcNetworksDetach() error {
+	return b.Base.Client.BareMetalServer.DetachVPC(b.Base.Context, b.Base.Args[0], b.Base.Args[1])
+}
+
 func (b *options) vpc2NetworksList() ([]govultr.VPC2Info, error) {
 	vpc2s, _, err := b.Base.Client.BareMetalServer.ListVPC2Info(b.Base.Context, b.Base.Args[0]) //nolint:staticcheck
 	return vpc2s, err
@@ -1201,6 +1571,11 @@ func parseCreateFlags(cmd *cobra.Command) (*govultr.BareMetalCreate, error) { //
 		return nil, fmt.Errorf("error parsing image flag for bare metal create : %v", err)
 	}
 
+	appVars, err := cmd.Flags().GetStringToString("marketplace-variable")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing marketplace-variable flag for bare metal create : %v", err)
+	}
+
 	options := &govultr.BareMetalCreate{
 		StartupScriptID: script,
 		Plan:            plan,
@@ -1216,6 +1591,7 @@ func parseCreateFlags(cmd *cobra.Command) (*govultr.BareMetalCreate, error) { //
 		Region:          region,
 		PersistentPxe:   govultr.BoolToBoolPtr(pxe),
 		MdiskMode:       mdiskMode,
+		AppVariables:    appVars,
 	}
 	if userdata != "" {
 		options.UserData = base64.StdEncoding.EncodeToString([]byte(userdata))
@@ -210,6 +210,56 @@ func (b *BareMetalBandwidthPrinter) Paging() [][]string {
 
 // ======================================
 
+// BareMetalVPCsPrinter ...
+type BareMetalVPCsPrinter struct {
+	VPCs []govultr.VPCInfo `json:"vpcs"`
+}
+
+// JSON ...
+func (b *BareMetalVPCsPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BareMetalVPCsPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BareMetalVPCsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"MAC ADDRESS",
+		"IP ADDRESS",
+	}}
+}
+
+// Data ...
+func (b *BareMetalVPCsPrinter) Data() [][]string {
+	var data [][]string
+
+	if len(b.VPCs) == 0 {
+		return [][]string{0: {"---", "---", "---"}}
+	}
+
+	for i := range b.VPCs {
+		data = append(data, []string{
+			b.VPCs[i].ID,
+			b.VPCs[i].MacAddress,
+			b.VPCs[i].IPAddress,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BareMetalVPCsPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
 // BareMetalVPC2sPrinter ...
 type BareMetalVPC2sPrinter struct {
 	VPC2s []govultr.VPC2Info `json:"vpcs"`
@@ -257,3 +307,69 @@ func (b *BareMetalVPC2sPrinter) Data() [][]string {
 func (b *BareMetalVPC2sPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// actionResult is the outcome of an action on one bare metal server
+type actionResult struct {
+	ID     string `json:"id"`
+	Label  string `json:"label"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BareMetalActionsPrinter ...
+type BareMetalActionsPrinter struct {
+	Results []actionResult `json:"results"`
+}
+
+// JSON ...
+func (b *BareMetalActionsPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BareMetalActionsPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BareMetalActionsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"LABEL",
+		"STATUS",
+		"ERROR",
+	}}
+}
+
+// Data ...
+func (b *BareMetalActionsPrinter) Data() [][]string {
+	var data [][]string
+	for i := range b.Results {
+		data = append(data, []string{
+			b.Results[i].ID,
+			b.Results[i].Label,
+			b.Results[i].Status,
+			b.Results[i].Error,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BareMetalActionsPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of servers the action failed on
+func (b *BareMetalActionsPrinter) failed() int {
+	n := 0
+	for i := range b.Results {
+		if b.Results[i].Status == "failed" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,471 @@
+// Package batch provides the functionality for running many CLI commands
+// from a file
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	defaultParallel = 4
+
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusSkipped = "skipped"
+	// statusDone is a command completed by an earlier run of a resumed batch
+	statusDone = "done"
+)
+
+// batchOwnFlags are the global flags which aren't passed on to the commands
+var batchOwnFlags = []string{
+	"api-endpoint", "proxy", "cacert", "insecure-skip-verify",
+	"output", "query", "columns", "sort-by", "no-trunc", "no-color", "short", "wide", "watch",
+}
+
+var (
+	batchLong = `Run the vultr-cli commands in a file, one per line, several at a time.
+
+Each line holds the arguments of a command, optionally starting with vultr-cli, and may quote
+arguments with single or double quotes.  Blank lines and lines starting with # are ignored.
+Commands run with the API key, profile and global flags of the batch, such as --dry-run and
+--config, and with JSON output, and the output or error of each is collected into the results.
+The requests of every command go through the batch, which sends at most --rate of them a second
+between them.  Rate limited requests are still retried by each command as usual.
+
+Without --continue-on-error no further commands are started once one fails and the commands
+which did not run are reported as skipped.  Commands can't read from stdin, so those which ask
+to confirm a price need --yes.
+
+The progress of the batch is kept in a state file until every command succeeded.  When the
+batch fails or is interrupted, --resume with the operation ID it prints runs the commands which
+did not complete, without the file.
+`
+	batchExample = `
+	# Full example
+	vultr-cli batch -f commands.txt --parallel 8 --continue-on-error
+
+	# Collect the results as JSON
+	vultr-cli batch -f commands.txt -o json > results.json
+
+	# Run the commands which did not complete in an earlier run
+	vultr-cli batch --resume 3f9a1c2b7d4e
+
+	# Example file
+	# stop the web servers
+	instance stop web-1
+	instance stop web-2
+	vultr-cli dns record create example.com -n www -t A -d 192.0.2.10
+	`
+)
+
+// NewCmdBatch provides the CLI command for running a batch of commands
+func NewCmdBatch(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "batch",
+		Short:   "Run many commands from a file concurrently",
+		Long:    batchLong,
+		Example: batchExample,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for batch : %v", errFi)
+			}
+
+			parallel, errPa := cmd.Flags().GetInt("parallel")
+			if errPa != nil {
+				return fmt.Errorf("error parsing flag 'parallel' for batch : %v", errPa)
+			}
+
+			continueOnError, errCo := cmd.Flags().GetBool("continue-on-error")
+			if errCo != nil {
+				return fmt.Errorf("error parsing flag 'continue-on-error' for batch : %v", errCo)
+			}
+
+			resume, errRe := cmd.Flags().GetString("resume")
+			if errRe != nil {
+				return fmt.Errorf("error parsing flag 'resume' for batch : %v", errRe)
+			}
+
+			rate, errRa := cmd.Flags().GetFloat64("rate")
+			if errRa != nil {
+				return fmt.Errorf("error parsing flag 'rate' for batch : %v", errRa)
+			}
+
+			if parallel < 1 {
+				return errors.New("parallel must be at least 1")
+			}
+
+			if rate <= 0 {
+				return errors.New("rate must be positive")
+			}
+
+			if (file == "") == (resume == "") {
+				return errors.New("one of --file or --resume is required")
+			}
+
+			commands, op, err := load(file, resume)
+			if err != nil {
+				return err
+			}
+
+			o.root = cmd.Root()
+			o.parallel = parallel
+			o.rate = rate
+			o.continueOnError = continueOnError
+
+			results, err := o.run(commands, op)
+			if err != nil {
+				return err
+			}
+
+			finish(op, results)
+
+			data := &BatchPrinter{Results: results}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.count(statusFailed); failed > 0 {
+				return fmt.Errorf("%d of %d commands failed", failed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "path to a file of commands, one per line, or - to read from stdin")
+	cmd.Flags().IntP("parallel", "p", defaultParallel, "(optional) number of commands to run at once")
+	cmd.Flags().Float64("rate", defaultRate, "(optional) most requests a second the commands make together")
+	cmd.Flags().Bool("continue-on-error", false, "(optional) keep starting commands after one fails")
+	cmd.Flags().String("resume", "", "(optional) operation ID of a failed or interrupted batch to run the rest of")
+
+	return cmd
+}
+
+type options struct {
+	Base            *cli.Base
+	root            *cobra.Command
+	parallel        int
+	rate            float64
+	continueOnError bool
+}
+
+// command is a line of the batch file
+type command struct {
+	line int
+	text string
+	args []string
+}
+
+// result is the outcome of a command of the batch
+type result struct {
+	Line     int         `json:"line"`
+	Command  string      `json:"command"`
+	Status   string      `json:"status"`
+	ExitCode int         `json:"exit_code"`
+	Duration string      `json:"duration,omitempty"`
+	Output   interface{} `json:"output,omitempty"`
+	Error    interface{} `json:"error,omitempty"`
+}
+
+// batchInput is what a resumed batch runs again
+type batchInput struct {
+	Lines []batchLine `json:"lines"`
+}
+
+// batchLine is a command of the batch file and its line number
+type batchLine struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// load reads the commands of the batch file and starts the operation which
+// tracks their progress, or loads the operation being resumed
+func load(file, resume string) ([]command, *cli.Operation, error) {
+	if resume != "" {
+		return loadOperation(resume)
+	}
+
+	commands, err := readCommands(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(commands) == 0 {
+		return nil, nil, fmt.Errorf("no commands found in %s", file)
+	}
+
+	input := batchInput{Lines: make([]batchLine, len(commands))}
+	for i := range commands {
+		input.Lines[i] = batchLine{Line: commands[i].line, Text: commands[i].text}
+	}
+
+	op, err := cli.NewOperation("batch", input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commands, op, nil
+}
+
+// loadOperation returns the commands of the batch being resumed
+func loadOperation(id string) ([]command, *cli.Operation, error) {
+	op, err := cli.LoadOperation("batch", id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var input batchInput
+	if err := op.Decode(&input); err != nil {
+		return nil, nil, err
+	}
+
+	commands := make([]command, len(input.Lines))
+	for i, l := range input.Lines {
+		args, err := parseCommand(l.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d of operation %s : %v", l.Line, id, err)
+		}
+		commands[i] = command{line: l.Line, text: l.Text, args: args}
+	}
+
+	return commands, op, nil
+}
+
+// finish removes the state of the batch once every command completed, or
+// tells how to resume it
+func finish(op *cli.Operation, results []result) {
+	for i := range results {
+		if results[i].Status != statusOK && results[i].Status != statusDone {
+			fmt.Fprintf(os.Stderr, "run the commands which did not complete with: vultr-cli batch --resume %s\n", op.ID)
+			return
+		}
+	}
+
+	if err := op.Remove(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+}
+
+// run runs the commands with at most parallel of them at once, skipping
+// those the operation completed in an earlier run
+func (o *options) run(commands []command, op *cli.Operation) ([]result, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error finding the vultr-cli executable : %v", err)
+	}
+
+	// the context is canceled by an interrupt
+	ctx := o.Base.Context
+
+	global := globalArgs(o.root)
+
+	p, err := startProxy(o.Base, o.rate)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	env := append(os.Environ(), "VULTR_API_KEY="+o.Base.APIKey(), "VULTR_API_ENDPOINT="+p.URL())
+	if profile := viper.GetString("profile"); profile != "" {
+		env = append(env, "VULTR_PROFILE="+profile)
+	}
+
+	results := make([]result, len(commands))
+	slots := make(chan struct{}, o.parallel)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+
+	for i := range commands {
+		results[i] = result{Line: commands[i].line, Command: commands[i].text, Status: statusSkipped}
+
+		step := strconv.Itoa(commands[i].line)
+		if op.IsDone(step) {
+			results[i].Status = statusDone
+			continue
+		}
+
+		slots <- struct{}{}
+		if ctx.Err() != nil || (failed.Load() && !o.continueOnError) {
+			<-slots
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *result, c command) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			runCommand(ctx, exe, env, global, c, r)
+			if r.Status == statusFailed {
+				failed.Store(true)
+				return
+			}
+
+			if err := op.MarkDone(step); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+			}
+		}(&results[i], commands[i])
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// globalArgs returns the global flags given to the batch which its commands
+// run with as well, such as --dry-run and --config.  The flags of the API
+// connection are left out since the proxy of the batch makes the requests,
+// and so are those of the output, which the batch collects as JSON
+func globalArgs(root *cobra.Command) []string {
+	var args []string
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed || slices.Contains(batchOwnFlags, f.Name) {
+			return
+		}
+
+		value := f.Value.String()
+		if list, ok := f.Value.(pflag.SliceValue); ok {
+			value = strings.Join(list.GetSlice(), ",")
+		}
+		args = append(args, "--"+f.Name+"="+value)
+	})
+	return args
+}
+
+// runCommand runs a single command of the batch as a separate vultr-cli
+// process, since commands share flags and can't run side by side in one
+// process.  Its requests go through the proxy of the batch
+func runCommand(ctx context.Context, exe string, env, global []string, c command, r *result) {
+	var stdout, stderr bytes.Buffer
+	args := append(append([]string{"--output", "json"}, global...), c.args...)
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	r.Duration = time.Since(start).Round(time.Millisecond).String()
+	r.Output = decodeOutput(stdout.Bytes())
+	r.Status = statusOK
+
+	if err != nil {
+		r.Status = statusFailed
+		r.ExitCode = 1
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			r.ExitCode = exitErr.ExitCode()
+		}
+
+		r.Error = decodeOutput(stderr.Bytes())
+		if r.Error == nil {
+			r.Error = err.Error()
+		}
+	}
+}
+
+// decodeOutput returns the JSON document in the output, or the output as
+// text when it isn't JSON
+func decodeOutput(out []byte) interface{} {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(out, &v); err == nil {
+		return v
+	}
+
+	return string(out)
+}
+
+// readCommands parses the commands of the batch file
+func readCommands(path string) ([]command, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %s : %v", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var commands []command
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		args, err := parseCommand(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d of %s : %v", n, path, err)
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		commands = append(commands, command{line: n, text: text, args: args})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read file %s : %v", path, err)
+	}
+
+	return commands, nil
+}
+
+// parseCommand returns the arguments of a line of the batch file, without a
+// leading vultr-cli
+func parseCommand(text string) ([]string, error) {
+	args, err := utils.SplitArgs(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) > 0 && args[0] == "vultr-cli" {
+		args = args[1:]
+	}
+
+	if len(args) > 0 && args[0] == "batch" {
+		return nil, errors.New("batch can't run itself")
+	}
+
+	return args, nil
+}
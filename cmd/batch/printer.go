@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// BatchPrinter ...
+type BatchPrinter struct {
+	Results []result `json:"results"`
+}
+
+// JSON ...
+func (b *BatchPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BatchPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BatchPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"LINE",
+		"STATUS",
+		"EXIT CODE",
+		"DURATION",
+		"COMMAND",
+	}}
+}
+
+// Data ...
+func (b *BatchPrinter) Data() [][]string {
+	if len(b.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range b.Results {
+		data = append(data, []string{
+			strconv.Itoa(b.Results[i].Line),
+			b.Results[i].Status,
+			strconv.Itoa(b.Results[i].ExitCode),
+			b.Results[i].Duration,
+			b.Results[i].Command,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BatchPrinter) Paging() [][]string {
+	return nil
+}
+
+// count returns the number of results with the status
+func (b *BatchPrinter) count(status string) int {
+	n := 0
+	for i := range b.Results {
+		if b.Results[i].Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,113 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	defaultRate = 20
+
+	proxyReadHeaderTimeout = 10 * time.Second
+)
+
+// proxy forwards the requests of the commands of a batch to the API, so the
+// commands share one rate limit even though each runs as its own process.
+// It only listens on the loopback interface and adds nothing to the
+// requests, the commands authenticate themselves
+type proxy struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// startProxy forwards requests to the API endpoint of the base, sending at
+// most rate of them a second
+func startProxy(base *cli.Base, rate float64) (*proxy, error) {
+	target, err := url.Parse(base.Endpoint())
+	if err != nil {
+		return nil, fmt.Errorf("invalid API endpoint : %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error starting the API proxy of the batch : %v", err)
+	}
+
+	p := &proxy{
+		listener: listener,
+		server: &http.Server{
+			Handler: &httputil.ReverseProxy{
+				Rewrite: func(r *httputil.ProxyRequest) {
+					r.SetURL(target)
+				},
+				Transport: &limitTransport{
+					next:     base.Transport(),
+					interval: time.Duration(float64(time.Second) / rate),
+				},
+			},
+			ReadHeaderTimeout: proxyReadHeaderTimeout,
+		},
+	}
+
+	go func() {
+		_ = p.server.Serve(listener)
+	}()
+
+	return p, nil
+}
+
+// URL returns the endpoint the commands send their requests to
+func (p *proxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Close stops the proxy
+func (p *proxy) Close() error {
+	return p.server.Close()
+}
+
+// limitTransport spaces out the requests so they are sent at most once
+// every interval
+type limitTransport struct {
+	next     http.RoundTripper
+	interval time.Duration
+	mu       sync.Mutex
+	nextAt   time.Time
+}
+
+// RoundTrip ...
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// wait blocks until the request may be sent
+func (t *limitTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	at := time.Now()
+	if t.nextAt.After(at) {
+		at = t.nextAt
+	}
+	t.nextAt = at.Add(t.interval)
+	t.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBatchDryRun(t *testing.T) {
+	var mu sync.Mutex
+	var changes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			mu.Lock()
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "commands.txt")
+	commands := strings.Join([]string{
+		"dns record delete example.com 3f9a1c2b-7d4e-4c1a-9e2b-1f0a2b3c4d5e",
+		"dns record create example.com -n www -t A -d 192.0.2.10",
+		"vultr-cli instance delete 6a31648d-ebfa-4d43-9a00-9c9f0e5048f5",
+	}, "\n")
+	if err := os.WriteFile(file, []byte(commands), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(os.Args[0], "--dry-run", "--api-endpoint", server.URL, "batch", "-f", file, "-o", "json")
+	cmd.Env = append(os.Environ(),
+		"VULTR_CLI_TEST_MAIN=1",
+		"VULTR_API_KEY=test",
+		"HOME="+dir,
+		"XDG_CONFIG_HOME="+dir,
+		"XDG_CACHE_HOME="+dir,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("batch failed : %v\n%s", err, out)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) > 0 {
+		t.Errorf("batch under --dry-run sent changes to the API : %q\n%s", changes, out)
+	}
+	if n := strings.Count(string(out), "DRY RUN"); n != 3 {
+		t.Errorf("batch printed %d dry runs, want 3\n%s", n, out)
+	}
+}
@@ -4,10 +4,13 @@ package billing
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
 )
@@ -72,9 +75,58 @@ var (
 	# Shortened with alias commands
 	vultr-cli billing i i 123456
 	`
+
+	invoiceDownloadLong = `Download the items of an invoice as CSV.  PDF invoices are not available through the API
+and have to be downloaded from the customer portal.`
+	invoiceDownloadExample = `
+	# Full example
+	vultr-cli billing invoice download 123456
+
+	# Write the CSV to stdout
+	vultr-cli billing invoice download 123456 --format="csv" --file="-"
+	`
+
+	breakdownLong = `Totals the invoice items of a month grouped by product, description or tag.  The current
+month is built from the pending charges.
+
+Invoice items do not reference the resource they bill for, so grouping by tag matches the labels of
+instances and bare metal servers against the item descriptions.  Items of resources carrying several
+tags count towards each tag and items which match no resource are grouped as (untagged).`
+	breakdownExample = `
+	# Full example
+	vultr-cli billing breakdown --month="2024-05" --group-by="product"
+
+	# Shortened with alias commands
+	vultr-cli billing b -m="2024-05" -g="tag"
+	`
+
+	forecastLong = `Projects the charges at the end of the current month from the pending charges so far and the
+average daily spend of the month.  Charges which started within the last --recent-days days are listed
+separately since the daily average does not fully reflect them yet.`
+	forecastExample = `
+	# Full example
+	vultr-cli billing forecast
+
+	# Flag charges which started in the last 3 days
+	vultr-cli billing forecast --recent-days=3
+	`
+
+	watchLong = `Polls the pending charges and runs the --exec hook when they go over the threshold.  The hook
+runs once each time the threshold is crossed and gets the charges in the VULTR_PENDING_CHARGES and
+VULTR_BILLING_THRESHOLD environment variables.
+
+With --once the charges are checked a single time and the command exits with a non-zero status when the
+threshold is exceeded, which suits running from cron.`
+	watchExample = `
+	# Full example
+	vultr-cli billing watch --threshold=200 --interval=1h --exec="./notify.sh"
+
+	# Check once from cron
+	vultr-cli billing watch --threshold=200 --once || ./notify.sh
+	`
 )
 
-func NewCmdBilling(base *cli.Base) *cobra.Command {
+func NewCmdBilling(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
 
 	cmd := &cobra.Command{
@@ -109,7 +161,7 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 		Example: invoiceListExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			invs, meta, err := o.listInvoices()
+			invs, meta, err := utils.ListAll(cmd, o.Base.Options, o.listInvoices)
 			if err != nil {
 				return fmt.Errorf("error retrieving billing invoice list : %v", err)
 			}
@@ -130,6 +182,7 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(invoicesList)
 
 	// Invoice Get
 	invoiceGet := &cobra.Command{
@@ -179,7 +232,7 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 
 			o.InvoiceItemID = id
 
-			items, meta, err := o.listInvoiceItems()
+			items, meta, err := utils.ListAll(cmd, o.Base.Options, o.listInvoiceItems)
 			if err != nil {
 				return fmt.Errorf("error retrieving billing invoice item list : %v", err)
 			}
@@ -200,11 +253,81 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(invoiceItemsList)
+
+	// Invoice Download
+	invoiceDownload := &cobra.Command{
+		Use:     "download <INVOICE_ID>",
+		Short:   "Download an invoice",
+		Aliases: []string{"d"},
+		Long:    invoiceDownloadLong,
+		Example: invoiceDownloadExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide an invoice ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, errFo := cmd.Flags().GetString("format")
+			if errFo != nil {
+				return fmt.Errorf("error parsing flag 'format' for invoice download : %v", errFo)
+			}
+
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for invoice download : %v", errFi)
+			}
+
+			switch format {
+			case "csv":
+			case "pdf":
+				return errors.New("pdf invoices are not available through the API, use the customer portal")
+			default:
+				return fmt.Errorf("unsupported invoice format %q", format)
+			}
+
+			id, errConv := strconv.Atoi(args[0])
+			if errConv != nil {
+				return fmt.Errorf("error converting invoice id : %v", errConv)
+			}
+
+			items, err := o.listAllInvoiceItems(id)
+			if err != nil {
+				return fmt.Errorf("error retrieving billing invoice items : %v", err)
+			}
+
+			if file == "-" {
+				return writeInvoiceCSV(os.Stdout, items)
+			}
+
+			if file == "" {
+				file = fmt.Sprintf("invoice-%d.%s", id, format)
+			}
+
+			if err := writeInvoiceFile(file, items); err != nil {
+				return fmt.Errorf("error writing invoice : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("invoice has been written to %s", file)), nil)
+
+			return nil
+		},
+	}
+
+	invoiceDownload.Flags().String("format", "csv", "(optional) format of the invoice. Only csv is available")
+	invoiceDownload.Flags().StringP(
+		"file",
+		"f",
+		"",
+		"(optional) file to write the invoice to, - for stdout. Defaults to invoice-<ID>.<format>",
+	)
 
 	invoice.AddCommand(
 		invoicesList,
 		invoiceGet,
 		invoiceItemsList,
+		invoiceDownload,
 	)
 
 	// History
@@ -225,7 +348,7 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 		Example: historyListExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			hs, meta, err := o.listHistory()
+			hs, meta, err := utils.ListAll(cmd, o.Base.Options, o.listHistory)
 			if err != nil {
 				return fmt.Errorf("error retrieving billing history list : %v", err)
 			}
@@ -246,14 +369,161 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(historyList)
 
 	history.AddCommand(
 		historyList,
 	)
 
+	// Breakdown
+	breakdown := &cobra.Command{
+		Use:     "breakdown",
+		Aliases: []string{"b"},
+		Short:   "Total the charges of a month by group",
+		Long:    breakdownLong,
+		Example: breakdownExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			month, errMo := cmd.Flags().GetString("month")
+			if errMo != nil {
+				return fmt.Errorf("error parsing flag 'month' for billing breakdown : %v", errMo)
+			}
+
+			groupBy, errGr := cmd.Flags().GetString("group-by")
+			if errGr != nil {
+				return fmt.Errorf("error parsing flag 'group-by' for billing breakdown : %v", errGr)
+			}
+
+			if groupBy != groupByProduct && groupBy != groupByDescription && groupBy != groupByTag {
+				return fmt.Errorf("group-by must be one of %s, %s or %s", groupByProduct, groupByDescription, groupByTag)
+			}
+
+			start := time.Now().UTC()
+			if month != "" {
+				var err error
+				if start, err = time.Parse(monthLayout, month); err != nil {
+					return fmt.Errorf("month must be in the YYYY-MM format : %v", err)
+				}
+			}
+
+			groups, err := o.breakdown(start, groupBy)
+			if err != nil {
+				return fmt.Errorf("error building billing breakdown : %v", err)
+			}
+
+			data := &BillingBreakdownPrinter{Month: start.Format(monthLayout), GroupBy: groupBy, Groups: groups}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	breakdown.Flags().StringP("month", "m", "", "(optional) month in the YYYY-MM format. Defaults to the current month")
+	breakdown.Flags().StringP(
+		"group-by",
+		"g",
+		groupByProduct,
+		"(optional) group the charges by product, description or tag",
+	)
+
+	// Forecast
+	forecast := &cobra.Command{
+		Use:     "forecast",
+		Aliases: []string{"f"},
+		Short:   "Project the charges at the end of the month",
+		Long:    forecastLong,
+		Example: forecastExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			recentDays, errRe := cmd.Flags().GetInt("recent-days")
+			if errRe != nil {
+				return fmt.Errorf("error parsing flag 'recent-days' for billing forecast : %v", errRe)
+			}
+
+			if recentDays < 0 {
+				return errors.New("recent-days cannot be negative")
+			}
+
+			f, err := o.forecast(time.Now().UTC(), recentDays)
+			if err != nil {
+				return fmt.Errorf("error building billing forecast : %v", err)
+			}
+
+			o.Base.Printer.Display(&BillingForecastPrinter{Forecast: f}, nil)
+
+			return nil
+		},
+	}
+
+	forecast.Flags().Int("recent-days", defaultRecentDays, "(optional) list charges which started in this many days")
+
+	// Watch
+	watch := &cobra.Command{
+		Use:     "watch",
+		Aliases: []string{"w"},
+		Short:   "Alert when the pending charges exceed a threshold",
+		Long:    watchLong,
+		Example: watchExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, errTh := cmd.Flags().GetFloat64("threshold")
+			if errTh != nil {
+				return fmt.Errorf("error parsing flag 'threshold' for billing watch : %v", errTh)
+			}
+
+			interval, errIn := cmd.Flags().GetDuration("interval")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'interval' for billing watch : %v", errIn)
+			}
+
+			hook, errEx := cmd.Flags().GetString("exec")
+			if errEx != nil {
+				return fmt.Errorf("error parsing flag 'exec' for billing watch : %v", errEx)
+			}
+
+			once, errOn := cmd.Flags().GetBool("once")
+			if errOn != nil {
+				return fmt.Errorf("error parsing flag 'once' for billing watch : %v", errOn)
+			}
+
+			if interval <= 0 {
+				return errors.New("interval must be positive")
+			}
+
+			if !once {
+				return o.watch(threshold, interval, hook)
+			}
+
+			check, err := o.checkBudget(threshold)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&BillingBudgetPrinter{Check: check}, nil)
+
+			if check.Exceeded {
+				if err := o.runHook(hook, check); err != nil {
+					return fmt.Errorf("error running %s : %v", hook, err)
+				}
+				return errBudgetExceeded
+			}
+
+			return nil
+		},
+	}
+
+	watch.Flags().Float64("threshold", 0, "pending charges above which the hook runs")
+	if err := watch.MarkFlagRequired("threshold"); err != nil {
+		fmt.Printf("error marking billing watch 'threshold' flag required: %v", err)
+		os.Exit(1)
+	}
+	watch.Flags().Duration("interval", defaultWatchInterval, "(optional) delay between checks of the pending charges")
+	watch.Flags().String("exec", "", "(optional) command to run when the threshold is exceeded")
+	watch.Flags().Bool("once", false, "(optional) check once and exit non-zero when the threshold is exceeded")
+
 	cmd.AddCommand(
 		history,
 		invoice,
+		breakdown,
+		forecast,
+		watch,
 	)
 
 	return cmd
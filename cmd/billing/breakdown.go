@@ -0,0 +1,242 @@
+package billing
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	monthLayout = "2006-01"
+	hoursPerDay = 24
+
+	groupByProduct     = "product"
+	groupByDescription = "description"
+	groupByTag         = "tag"
+
+	untagged = "(untagged)"
+)
+
+// costGroup is the total of the invoice items sharing a group
+type costGroup struct {
+	Name  string  `json:"name"`
+	Items int     `json:"items"`
+	Total float64 `json:"total"`
+}
+
+// breakdown returns the invoice items of the month grouped by the field.  The
+// current month is built from the pending charges, earlier months from the
+// invoices issued during or right after the month.
+func (b *options) breakdown(month time.Time, groupBy string) ([]costGroup, error) {
+	items, err := b.monthItems(month)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags map[string][]string
+	if groupBy == groupByTag {
+		if tags, err = b.labelTags(); err != nil {
+			return nil, fmt.Errorf("error listing resource tags : %v", err)
+		}
+	}
+
+	totals := map[string]*costGroup{}
+	add := func(name string, item *govultr.InvoiceItem) {
+		if totals[name] == nil {
+			totals[name] = &costGroup{Name: name}
+		}
+		totals[name].Items++
+		totals[name].Total += float64(item.Total)
+	}
+
+	for i := range items {
+		switch groupBy {
+		case groupByProduct:
+			add(items[i].Product, &items[i])
+		case groupByDescription:
+			add(items[i].Description, &items[i])
+		case groupByTag:
+			itemTags := tagsFor(tags, items[i].Description)
+			if len(itemTags) == 0 {
+				itemTags = []string{untagged}
+			}
+			for j := range itemTags {
+				add(itemTags[j], &items[i])
+			}
+		}
+	}
+
+	groups := make([]costGroup, 0, len(totals))
+	for _, g := range totals {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Total > groups[j].Total })
+
+	return groups, nil
+}
+
+// monthItems returns the invoice items which started in the month
+func (b *options) monthItems(month time.Time) ([]govultr.InvoiceItem, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	if now := time.Now().UTC(); !now.Before(start) && now.Before(end) {
+		items, _, err := b.Base.Client.Billing.ListPendingCharges(b.Base.Context, &govultr.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving pending charges : %v", err)
+		}
+		return items, nil
+	}
+
+	invoices, err := b.listAllInvoices()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving invoices : %v", err)
+	}
+
+	var items []govultr.InvoiceItem
+	for i := range invoices {
+		date, err := parseBillingDate(invoices[i].Date)
+		if err != nil || date.Before(start) || !date.Before(end.AddDate(0, 1, 0)) {
+			continue
+		}
+
+		invoiceItems, err := b.listAllInvoiceItems(invoices[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving items of invoice %d : %v", invoices[i].ID, err)
+		}
+
+		for j := range invoiceItems {
+			itemStart, err := parseBillingDate(invoiceItems[j].StartDate)
+			if err == nil && !itemStart.Before(start) && itemStart.Before(end) {
+				items = append(items, invoiceItems[j])
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func (b *options) listAllInvoices() ([]govultr.Invoice, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Invoice, *govultr.Meta, error) {
+		invoices, meta, _, err := b.Base.Client.Billing.ListInvoices(b.Base.Context, opts)
+		return invoices, meta, err
+	})
+}
+
+func (b *options) listAllInvoiceItems(id int) ([]govultr.InvoiceItem, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.InvoiceItem, *govultr.Meta, error) {
+		items, meta, _, err := b.Base.Client.Billing.ListInvoiceItems(b.Base.Context, id, opts)
+		return items, meta, err
+	})
+}
+
+// labelTags maps the labels of the instances and bare metal servers to their
+// tags.  Invoice items do not reference resources so they are matched to a
+// resource by its label appearing in the item description.
+func (b *options) labelTags() (map[string][]string, error) {
+	tags := map[string][]string{}
+
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := b.Base.Client.Instance.List(b.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range instances {
+		if instances[i].Label != "" {
+			tags[instances[i].Label] = instances[i].Tags
+		}
+	}
+
+	servers, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		servers, meta, _, err := b.Base.Client.BareMetalServer.List(b.Base.Context, opts)
+		return servers, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if servers[i].Label != "" {
+			tags[servers[i].Label] = servers[i].Tags
+		}
+	}
+
+	return tags, nil
+}
+
+// tagsFor returns the tags of the resource with the longest label found in
+// the description
+func tagsFor(tags map[string][]string, description string) []string {
+	match := ""
+	for label := range tags {
+		if len(label) > len(match) && strings.Contains(description, label) {
+			match = label
+		}
+	}
+
+	if match == "" {
+		return nil
+	}
+	return tags[match]
+}
+
+// parseBillingDate parses the dates used on invoices and invoice items
+func parseBillingDate(date string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, date)
+}
+
+// writeInvoiceCSV writes the invoice items as CSV with a header row
+func writeInvoiceCSV(w io.Writer, items []govultr.InvoiceItem) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{
+		"description", "product", "start_date", "end_date", "units", "unit_type", "unit_price", "total",
+	}); err != nil {
+		return err
+	}
+
+	for i := range items {
+		if err := out.Write([]string{
+			items[i].Description,
+			items[i].Product,
+			items[i].StartDate,
+			items[i].EndDate,
+			strconv.Itoa(items[i].Units),
+			items[i].UnitType,
+			strconv.FormatFloat(float64(items[i].UnitPrice), 'f', -1, 32),
+			strconv.FormatFloat(float64(items[i].Total), 'f', utils.FloatPrecision, 32),
+		}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// writeInvoiceFile writes the invoice items as CSV to the file
+func writeInvoiceFile(file string, items []govultr.InvoiceItem) error {
+	f, err := os.Create(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+
+	errWr := writeInvoiceCSV(f, items)
+	if errCls := f.Close(); errWr == nil {
+		errWr = errCls
+	}
+
+	return errWr
+}
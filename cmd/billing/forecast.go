@@ -0,0 +1,60 @@
+package billing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// defaultRecentDays is how far back forecast flags newly started charges
+const defaultRecentDays = 7
+
+// costForecast is the projected end of month charges
+type costForecast struct {
+	Month         string                `json:"month"`
+	Pending       float64               `json:"pending_charges"`
+	DaysElapsed   float64               `json:"days_elapsed"`
+	DaysInMonth   int                   `json:"days_in_month"`
+	DailyRunRate  float64               `json:"daily_run_rate"`
+	Projected     float64               `json:"projected_charges"`
+	RecentDays    int                   `json:"recent_days"`
+	RecentCharges []govultr.InvoiceItem `json:"recent_charges"`
+}
+
+// forecast projects the charges at the end of the month from the pending
+// charges so far and the average daily spend of the month.  Charges which
+// started within the recent days are listed since the average does not
+// reflect them yet.
+func (b *options) forecast(now time.Time, recentDays int) (*costForecast, error) {
+	items, _, err := b.Base.Client.Billing.ListPendingCharges(b.Base.Context, &govultr.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pending charges : %v", err)
+	}
+
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	recentSince := now.AddDate(0, 0, -recentDays)
+
+	f := &costForecast{
+		Month:       start.Format(monthLayout),
+		DaysElapsed: now.Sub(start).Hours() / hoursPerDay,
+		DaysInMonth: int(end.Sub(start).Hours() / hoursPerDay),
+		RecentDays:  recentDays,
+	}
+
+	for i := range items {
+		f.Pending += float64(items[i].Total)
+
+		if itemStart, err := parseBillingDate(items[i].StartDate); err == nil && itemStart.After(recentSince) {
+			f.RecentCharges = append(f.RecentCharges, items[i])
+		}
+	}
+
+	// avoid projecting from the first minutes of the month
+	elapsed := max(f.DaysElapsed, 1)
+	f.DailyRunRate = f.Pending / elapsed
+	f.Projected = f.Pending + f.DailyRunRate*max(float64(f.DaysInMonth)-f.DaysElapsed, 0)
+
+	return f, nil
+}
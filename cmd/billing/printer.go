@@ -3,7 +3,9 @@
 package billing
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -219,3 +221,163 @@ func (b *BillingInvoiceItemsPrinter) Data() [][]string {
 func (b *BillingInvoiceItemsPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(b.Meta).Compose()
 }
+
+// ======================================
+
+// BillingBreakdownPrinter ...
+type BillingBreakdownPrinter struct {
+	Month   string      `json:"month"`
+	GroupBy string      `json:"group_by"`
+	Groups  []costGroup `json:"groups"`
+}
+
+// JSON ...
+func (b *BillingBreakdownPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BillingBreakdownPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BillingBreakdownPrinter) Columns() [][]string {
+	return [][]string{0: {
+		strings.ToUpper(b.GroupBy),
+		"ITEMS",
+		"TOTAL",
+	}}
+}
+
+// Data ...
+func (b *BillingBreakdownPrinter) Data() [][]string {
+	if len(b.Groups) == 0 {
+		return [][]string{0: {"---", "---", "---"}}
+	}
+
+	var data [][]string
+	var items int
+	var total float64
+	for i := range b.Groups {
+		data = append(data, []string{
+			b.Groups[i].Name,
+			strconv.Itoa(b.Groups[i].Items),
+			strconv.FormatFloat(b.Groups[i].Total, 'f', utils.FloatPrecision, 64),
+		})
+		items += b.Groups[i].Items
+		total += b.Groups[i].Total
+	}
+
+	if b.GroupBy != groupByTag {
+		data = append(data, []string{
+			fmt.Sprintf("TOTAL %s", b.Month),
+			strconv.Itoa(items),
+			strconv.FormatFloat(total, 'f', utils.FloatPrecision, 64),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BillingBreakdownPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// BillingForecastPrinter ...
+type BillingForecastPrinter struct {
+	Forecast *costForecast `json:"forecast"`
+}
+
+// JSON ...
+func (b *BillingForecastPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BillingForecastPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BillingForecastPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (b *BillingForecastPrinter) Data() [][]string {
+	f := b.Forecast
+	data := [][]string{
+		{"MONTH", f.Month},
+		{"PENDING CHARGES", strconv.FormatFloat(f.Pending, 'f', utils.FloatPrecision, 64)},
+		{"DAYS ELAPSED", fmt.Sprintf("%.1f of %d", f.DaysElapsed, f.DaysInMonth)},
+		{"DAILY RUN RATE", strconv.FormatFloat(f.DailyRunRate, 'f', utils.FloatPrecision, 64)},
+		{"PROJECTED CHARGES", strconv.FormatFloat(f.Projected, 'f', utils.FloatPrecision, 64)},
+		{" "},
+		{fmt.Sprintf("STARTED IN THE LAST %d DAYS", f.RecentDays)},
+		{"DESCRIPTION", "PRODUCT", "START DATE", "TOTAL"},
+	}
+
+	if len(f.RecentCharges) == 0 {
+		return append(data, []string{"---", "---", "---", "---"})
+	}
+
+	for i := range f.RecentCharges {
+		data = append(data, []string{
+			f.RecentCharges[i].Description,
+			f.RecentCharges[i].Product,
+			f.RecentCharges[i].StartDate,
+			strconv.FormatFloat(float64(f.RecentCharges[i].Total), 'f', utils.FloatPrecision, 32),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BillingForecastPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// BillingBudgetPrinter ...
+type BillingBudgetPrinter struct {
+	Check *budgetCheck `json:"budget"`
+}
+
+// JSON ...
+func (b *BillingBudgetPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BillingBudgetPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BillingBudgetPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"PENDING CHARGES",
+		"THRESHOLD",
+		"EXCEEDED",
+	}}
+}
+
+// Data ...
+func (b *BillingBudgetPrinter) Data() [][]string {
+	return [][]string{0: {
+		strconv.FormatFloat(b.Check.Pending, 'f', utils.FloatPrecision, 64),
+		strconv.FormatFloat(b.Check.Threshold, 'f', utils.FloatPrecision, 64),
+		strconv.FormatBool(b.Check.Exceeded),
+	}}
+}
+
+// Paging ...
+func (b *BillingBudgetPrinter) Paging() [][]string {
+	return nil
+}
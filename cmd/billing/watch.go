@@ -0,0 +1,105 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// defaultWatchInterval is the delay between polls of the pending charges
+const defaultWatchInterval = time.Hour
+
+// budgetCheck is the pending charges compared to the threshold
+type budgetCheck struct {
+	Pending   float64 `json:"pending_charges"`
+	Threshold float64 `json:"threshold"`
+	Exceeded  bool    `json:"exceeded"`
+}
+
+// checkBudget compares the pending charges to the threshold
+func (b *options) checkBudget(threshold float64) (*budgetCheck, error) {
+	items, _, err := b.Base.Client.Billing.ListPendingCharges(b.Base.Context, &govultr.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pending charges : %v", err)
+	}
+
+	check := &budgetCheck{Threshold: threshold}
+	for i := range items {
+		check.Pending += float64(items[i].Total)
+	}
+	check.Exceeded = check.Pending > threshold
+
+	return check, nil
+}
+
+// watch polls the pending charges every interval and runs the hook when they
+// go over the threshold.  The hook runs once per crossing and is armed again
+// once the charges drop back below the threshold, such as at the start of a
+// new month.
+func (b *options) watch(threshold float64, interval time.Duration, hook string) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fired := false
+	for {
+		check, err := b.checkBudget(threshold)
+		if err != nil {
+			// keep watching through temporary API errors
+			fmt.Fprintf(os.Stderr, "%s %v\n", time.Now().Format(time.RFC3339), err)
+		} else {
+			fmt.Fprintf(
+				os.Stderr,
+				"%s pending charges %.2f of %.2f\n",
+				time.Now().Format(time.RFC3339),
+				check.Pending,
+				check.Threshold,
+			)
+
+			if check.Exceeded && !fired {
+				fired = true
+				if err := b.runHook(hook, check); err != nil {
+					fmt.Fprintf(os.Stderr, "error running %s : %v\n", hook, err)
+				}
+			}
+			fired = fired && check.Exceeded
+		}
+
+		select {
+		case <-b.Base.Context.Done():
+			return b.Base.Context.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runHook runs the hook command with the charges in its environment.  When
+// no hook is given the crossing is only reported.
+func (b *options) runHook(hook string, check *budgetCheck) error {
+	fmt.Fprintf(os.Stderr, "pending charges %.2f exceeded the threshold of %.2f\n", check.Pending, check.Threshold)
+
+	args := strings.Fields(hook)
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd := exec.CommandContext(b.Base.Context, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"VULTR_PENDING_CHARGES="+strconv.FormatFloat(check.Pending, 'f', utils.FloatPrecision, 64),
+		"VULTR_BILLING_THRESHOLD="+strconv.FormatFloat(check.Threshold, 'f', utils.FloatPrecision, 64),
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// errBudgetExceeded is returned by watch --once so the exit status can be
+// used from cron
+var errBudgetExceeded = errors.New("pending charges exceeded the threshold")
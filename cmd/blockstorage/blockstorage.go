@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -15,15 +16,40 @@ import (
 )
 
 var (
-	attachLong    = `Attaches a block storage resource to an specified instance`
+	attachLong = `Attaches a block storage resource to an specified instance
+
+Use --wait to block until the block storage reports it is attached and
+--print-device to show the device path the block storage is expected to have
+on the instance, along with the commands to format and mount it.  The format
+step only runs when the device has no filesystem yet so the commands are safe
+to run again after later attachments.`
 	attachExample = `
 	#Full example
 	vultr-cli block-storage attach 67181686-5455-4ebb-81eb-7299f3506e2c --instance=a7898453-dd9e-4b47-bdab-9dd7a3448f1f
 
+	#Attach without a restart, wait for it and show how to mount it
+	vultr-cli block-storage attach 67181686-5455-4ebb-81eb-7299f3506e2c --instance=a7898453-dd9e-4b47-bdab-9dd7a3448f1f \
+		--live --wait --print-device
+
 	#Shortened with aliased commands
 	vultr-cli bs a 67181686-5455-4ebb-81eb-7299f3506e2c -i=a7898453-dd9e-4b47-bdab-9dd7a3448f1f
 	`
 
+	cloneLong = `Copy a block storage resource into a new block storage, usually in another
+region.  The block storage must be detached.
+
+The API cannot snapshot block storage so a temporary instance is created in the
+region of each volume, the volumes are attached and the contents are streamed
+between the instances over SSH.  The temporary instances are deleted when the
+clone finishes or fails and are billed for the time they run.`
+	cloneExample = `
+	#Full example
+	vultr-cli block-storage clone 67181686-5455-4ebb-81eb-7299f3506e2c --region=ams
+
+	#With a label and a different temporary instance plan
+	vultr-cli block-storage clone 67181686-5455-4ebb-81eb-7299f3506e2c --region=ams --label=data-ams --plan=vc2-2c-4gb
+	`
+
 	createLong    = `Create a new block storage resource in a specified region`
 	createExample = `
 	#Full example
@@ -66,11 +92,20 @@ var (
 	vultr-cli bs label 67181686-5455-4ebb-81eb-7299f3506e2c -l="Example Label"
 	`
 
-	listLong    = `Retrieves a list of active block storage resources`
+	listLong = `Retrieves a list of active block storage resources
+
+The --attached, --detached and --instance filters are applied to every page of
+block storage so the filtered list is not paged.`
 	listExample = `
 	#Full example
 	vultr-cli block-storage list
 
+	#Find detached block storage which is still billed
+	vultr-cli block-storage list --detached
+
+	#Block storage attached to an instance
+	vultr-cli block-storage list --instance=a7898453-dd9e-4b47-bdab-9dd7a3448f1f
+
 	#Shortened with aliased commands
 	vultr-cli bs l
 	`
@@ -84,18 +119,25 @@ var (
 	vultr-cli bs g 67181686-5455-4ebb-81eb-7299f3506e2c
 	`
 
-	resizeLong    = `Resizes a specified block storage resource`
+	resizeLong = `Resizes a specified block storage resource
+
+Block storage can only grow so a size smaller than the current one is refused.
+The change in monthly cost is shown and must be confirmed unless --yes is
+passed.  Use --wait to block until the API reports the new size.`
 	resizeExample = `
 	#Full example
 	vultr-cli block-storage resize 67181686-5455-4ebb-81eb-7299f3506e2c --size=20
 
+	#Skip the confirmation and wait for the resize to finish
+	vultr-cli block-storage resize 67181686-5455-4ebb-81eb-7299f3506e2c --size=20 --yes --wait
+
 	#Shortened with aliased commands
 	vultr-cli bs r 67181686-5455-4ebb-81eb-7299f3506e2c -s=20
 	`
 )
 
 // NewCmdBlockStorage provides the command for block storage to the CLI
-func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
+func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	o := &options{Base: base}
 
 	cmd := &cobra.Command{
@@ -120,8 +162,35 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		Long:    listLong,
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			attached, errAt := cmd.Flags().GetBool("attached")
+			if errAt != nil {
+				return fmt.Errorf("error parsing 'attached' flag for block storage list : %v", errAt)
+			}
+
+			detached, errDe := cmd.Flags().GetBool("detached")
+			if errDe != nil {
+				return fmt.Errorf("error parsing 'detached' flag for block storage list : %v", errDe)
+			}
+
+			instance, errIn := cmd.Flags().GetString("instance")
+			if errIn != nil {
+				return fmt.Errorf("error parsing 'instance' flag for block storage list : %v", errIn)
+			}
+
+			if attached || detached || instance != "" {
+				bss, err := o.listFiltered(attached, detached, instance)
+				if err != nil {
+					return fmt.Errorf("error retrieving block storage list : %v", err)
+				}
+
+				data := &BlockStoragesPrinter{BlockStorages: bss}
+				o.Base.Printer.Display(data, nil)
+
+				return nil
+			}
+
 			o.Base.Options = utils.GetPaging(cmd)
-			bss, meta, err := o.list()
+			bss, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving block storage list : %v", err)
 			}
@@ -143,6 +212,12 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
+	list.Flags().Bool("attached", false, "(optional) only list block storage attached to an instance")
+	list.Flags().Bool("detached", false, "(optional) only list block storage not attached to an instance")
+	list.Flags().StringP("instance", "i", "", "(optional) only list block storage attached to this instance ID")
+	list.MarkFlagsMutuallyExclusive("attached", "detached")
+	list.MarkFlagsMutuallyExclusive("detached", "instance")
 
 	// Get
 	get := &cobra.Command{
@@ -205,6 +280,20 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				BlockType: blockType,
 			}
 
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing 'yes' flag for block storage create : %v", errYs)
+			}
+
+			action := fmt.Sprintf("Create a %d GB block storage in %s", size, reg)
+			ok, err := utils.ConfirmPrice(yes, action, o.createPrice)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("block storage create was cancelled")
+			}
+
 			bs, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating block storage : %v", err)
@@ -222,6 +311,7 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		fmt.Printf("error marking block storage create 'region' flag required: %v\n", err)
 		os.Exit(1)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
 
 	create.Flags().IntP("size", "s", 0, "size of the block storage you want to create")
 	if err := create.MarkFlagRequired("size"); err != nil {
@@ -238,6 +328,7 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		`(optional) Block type you want to give the block storage.
 		Possible values: 'high_perf', 'storage_opt'. Currently defaults to 'high_perf'.`,
 	)
+	utils.AddYesFlag(create)
 
 	// Delete
 	del := &cobra.Command{
@@ -292,11 +383,43 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				Live:       govultr.BoolToBoolPtr(live),
 			}
 
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			printDevice, errPd := cmd.Flags().GetBool("print-device")
+			if errPd != nil {
+				return fmt.Errorf("error parsing 'print-device' flag for block storage attach : %v", errPd)
+			}
+
+			mountPoint, errMp := cmd.Flags().GetString("mount-point")
+			if errMp != nil {
+				return fmt.Errorf("error parsing 'mount-point' flag for block storage attach : %v", errMp)
+			}
+
 			if err := o.attach(); err != nil {
 				return fmt.Errorf("error attaching block storage : %v", err)
 			}
 
-			o.Base.Printer.Display(printer.Info("block storage has been attached"), nil)
+			if wait {
+				if err := o.waitAttached(instance, timeout); err != nil {
+					return err
+				}
+			}
+
+			if !printDevice {
+				o.Base.Printer.Display(printer.Info("block storage has been attached"), nil)
+				return nil
+			}
+
+			bs, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving block storage : %v", err)
+			}
+
+			data := newBlockStorageDevicePrinter(bs, instance, mountPoint)
+			o.Base.Printer.Display(data, nil)
 
 			return nil
 		},
@@ -309,6 +432,17 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	}
 
 	attach.Flags().Bool("live", false, "attach block storage without restarting the instance")
+	utils.AddWaitFlags(attach, "the block storage is attached")
+	attach.Flags().Bool(
+		"print-device",
+		false,
+		"(optional) print the expected device path and the commands to format and mount it",
+	)
+	attach.Flags().String(
+		"mount-point",
+		defaultMountPoint,
+		"(optional) mount point used in the commands printed by --print-device",
+	)
 
 	// Detach
 	detach := &cobra.Command{
@@ -401,6 +535,34 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing 'size' flag for block storage resize : %v", errSz)
 			}
 
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing 'yes' flag for block storage resize : %v", errYs)
+			}
+
+			bs, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving block storage : %v", err)
+			}
+
+			if size < bs.SizeGB {
+				return fmt.Errorf("block storage cannot be shrunk from %d GB to %d GB", bs.SizeGB, size)
+			}
+
+			if size == bs.SizeGB {
+				o.Base.Printer.Display(printer.Info(fmt.Sprintf("block storage is already %d GB", size)), nil)
+				return nil
+			}
+
+			if !yes && !utils.Confirm(resizeCostMessage(bs, size)) {
+				return errors.New("block storage resize was cancelled")
+			}
+
 			o.UpdateReq = &govultr.BlockStorageUpdate{
 				SizeGB: size,
 			}
@@ -409,6 +571,12 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error resizing block storage : %v", err)
 			}
 
+			if wait {
+				if err := o.waitSize(size, timeout); err != nil {
+					return err
+				}
+			}
+
 			o.Base.Printer.Display(printer.Info("block storage has been resized"), nil)
 
 			return nil
@@ -421,6 +589,81 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	utils.AddWaitFlags(resize, "the new size is reported")
+	resize.Flags().BoolP("yes", "y", false, "(optional) resize without asking to confirm the cost change")
+
+	// Clone
+	clone := &cobra.Command{
+		Use:     "clone <Block Storage ID>",
+		Short:   "Copy a block storage into a new block storage",
+		Long:    cloneLong,
+		Example: cloneExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a block storage ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reg, errRg := cmd.Flags().GetString("region")
+			if errRg != nil {
+				return fmt.Errorf("error parsing 'region' flag for block storage clone : %v", errRg)
+			}
+
+			label, errLa := cmd.Flags().GetString("label")
+			if errLa != nil {
+				return fmt.Errorf("error parsing 'label' flag for block storage clone : %v", errLa)
+			}
+
+			plan, errPl := cmd.Flags().GetString("plan")
+			if errPl != nil {
+				return fmt.Errorf("error parsing 'plan' flag for block storage clone : %v", errPl)
+			}
+
+			osID, errOs := cmd.Flags().GetInt("os")
+			if errOs != nil {
+				return fmt.Errorf("error parsing 'os' flag for block storage clone : %v", errOs)
+			}
+
+			timeout, errTo := utils.GetWaitTimeout(cmd)
+			if errTo != nil {
+				return errTo
+			}
+
+			bs, err := o.clone(&cloneReq{
+				Region:  reg,
+				Label:   label,
+				Plan:    plan,
+				OsID:    osID,
+				Timeout: timeout,
+			})
+			if err != nil {
+				return fmt.Errorf("error cloning block storage : %v", err)
+			}
+
+			data := &BlockStoragePrinter{BlockStorage: bs}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	clone.Flags().StringP("region", "r", "", "ID of the region in which to create the new block storage")
+	if err := clone.MarkFlagRequired("region"); err != nil {
+		fmt.Printf("error marking block storage clone 'region' flag required: %v\n", err)
+		os.Exit(1)
+	}
+
+	clone.Flags().StringP(
+		"label",
+		"l",
+		"",
+		"(optional) label of the new block storage. Defaults to the source label with (clone) appended",
+	)
+	clone.Flags().String("plan", clonePlan, "(optional) plan of the temporary instances")
+	clone.Flags().Int("os", cloneOS, "(optional) operating system ID of the temporary instances")
+	utils.AddWaitTimeoutFlag(clone, cloneTimeout, "(optional) how long to wait for the copy to finish")
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -430,11 +673,19 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		attach,
 		detach,
 		resize,
+		clone,
 	)
 
+	utils.RegisterCompletion(cmd, "<Block Storage ID>", utils.CompleteBlockStorages(o.Base))
+	utils.RegisterResolver(cmd, "<Block Storage ID>", utils.ResolveBlockStorages(o.Base))
+
 	return cmd
 }
 
+// defaultMountPoint is where the --print-device commands mount the block
+// storage unless told otherwise
+const defaultMountPoint = "/mnt/blockstorage"
+
 type options struct {
 	Base      *cli.Base
 	CreateReq *govultr.BlockStorageCreate
@@ -448,6 +699,30 @@ func (o *options) list() ([]govultr.BlockStorage, *govultr.Meta, error) {
 	return bs, meta, err
 }
 
+// listFiltered retrieves every block storage matching the attachment filters
+func (o *options) listFiltered(attached, detached bool, instanceID string) ([]govultr.BlockStorage, error) {
+	var bss []govultr.BlockStorage
+	volumes, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		volumes, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return volumes, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range volumes {
+		switch {
+		case attached && volumes[i].AttachedToInstance == "",
+			detached && volumes[i].AttachedToInstance != "",
+			instanceID != "" && volumes[i].AttachedToInstance != instanceID:
+			continue
+		}
+		bss = append(bss, volumes[i])
+	}
+
+	return bss, nil
+}
+
 func (o *options) get() (*govultr.BlockStorage, error) {
 	bs, _, err := o.Base.Client.BlockStorage.Get(o.Base.Context, o.Base.Args[0])
 	return bs, err
@@ -473,3 +748,73 @@ func (o *options) attach() error {
 func (o *options) detach() error {
 	return o.Base.Client.BlockStorage.Detach(o.Base.Context, o.Base.Args[0], o.DetachReq)
 }
+
+// waitAttached polls the block storage until it is active on the instance
+func (o *options) waitAttached(instanceID string, timeout time.Duration) error {
+	return o.Base.Wait("block storage "+o.Base.Args[0], timeout, func() (string, bool, error) {
+		bs, err := o.get()
+		if err != nil {
+			return "", false, err
+		}
+
+		status := bs.Status
+		if bs.AttachedToInstance != "" {
+			status = fmt.Sprintf("%s, attached to %s", bs.Status, bs.AttachedToInstance)
+		}
+		return status, bs.AttachedToInstance == instanceID && bs.Status == "active", nil
+	})
+}
+
+// waitSize polls the block storage until it reports the size
+func (o *options) waitSize(size int, timeout time.Duration) error {
+	return o.Base.Wait("block storage "+o.Base.Args[0], timeout, func() (string, bool, error) {
+		bs, err := o.get()
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("%s, %d GB", bs.Status, bs.SizeGB), bs.SizeGB == size && bs.Status == "active", nil
+	})
+}
+
+// createPrice estimates the monthly price of the new block storage at the
+// cost per GB of an existing one of the same type, since the API doesn't
+// publish block storage prices
+func (o *options) createPrice() (float64, error) {
+	blockType := o.CreateReq.BlockType
+	if blockType == "" {
+		blockType = "high_perf"
+	}
+
+	blocks, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range blocks {
+		if blocks[i].BlockType == blockType && blocks[i].SizeGB > 0 {
+			return float64(blocks[i].Cost) / float64(blocks[i].SizeGB) * float64(o.CreateReq.SizeGB), nil
+		}
+	}
+
+	return 0, utils.ErrNoPrice
+}
+
+// resizeCostMessage describes the monthly cost change of a resize, priced
+// at the current cost per GB of the block storage
+func resizeCostMessage(bs *govultr.BlockStorage, size int) string {
+	perGB := float64(bs.Cost) / float64(bs.SizeGB)
+	cost := perGB * float64(size)
+
+	return fmt.Sprintf(
+		"Resize %s from %d GB to %d GB, changing the monthly cost from $%.2f to $%.2f (+$%.2f)?",
+		bs.ID,
+		bs.SizeGB,
+		size,
+		bs.Cost,
+		cost,
+		cost-float64(bs.Cost),
+	)
+}
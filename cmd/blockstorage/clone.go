@@ -0,0 +1,257 @@
+package blockstorage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	// clonePlan and cloneOS are the smallest instance and a stock image which
+	// are enough to stream a volume over SSH
+	clonePlan = "vc2-1c-1gb"
+	cloneOS   = 2136
+	cloneTag  = "vultr-cli-clone"
+
+	cloneTimeout = 4 * time.Hour
+)
+
+// cloneReq holds the settings of a block storage clone
+type cloneReq struct {
+	Region  string
+	Label   string
+	Plan    string
+	OsID    int
+	Timeout time.Duration
+}
+
+// clone copies the contents of the block storage in the args into a new
+// block storage in another region.  The API has no block storage snapshots
+// so a temporary instance is created next to each volume and the source
+// instance streams the raw device to the target instance over SSH.  The
+// target powers itself off once the copy is synced which is how completion
+// is detected.  The temporary instances are always removed and the new block
+// storage is removed again when the copy fails.
+func (o *options) clone(req *cloneReq) (*govultr.BlockStorage, error) { //nolint:gocyclo
+	src, err := o.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if src.AttachedToInstance != "" {
+		return nil, fmt.Errorf(
+			"block storage is attached to instance %s, detach it first so the copy is consistent",
+			src.AttachedToInstance,
+		)
+	}
+
+	if req.Label == "" {
+		req.Label = strings.TrimSpace(src.Label + " (clone)")
+	}
+
+	privateKey, publicKey, err := cloneKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("error generating ssh key : %v", err)
+	}
+
+	dst, _, err := o.Base.Client.BlockStorage.Create(o.Base.Context, &govultr.BlockStorageCreate{
+		Region:    req.Region,
+		SizeGB:    src.SizeGB,
+		Label:     req.Label,
+		BlockType: src.BlockType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating block storage : %v", err)
+	}
+	cloneProgress("created block storage %s in %s", dst.ID, dst.Region)
+
+	// the temporary instances, and the volume unless the clone finished, are
+	// deleted even when interrupted
+	cleanupCtx, cleanupDone := o.Base.Cleanup()
+	var instances []string
+	ok := false
+	defer func() {
+		defer cleanupDone()
+		for i := range instances {
+			if err := o.Base.Client.Instance.Delete(cleanupCtx, instances[i]); err != nil {
+				cloneProgress("error deleting temporary instance %s : %v", instances[i], err)
+				continue
+			}
+			cloneProgress("deleted temporary instance %s", instances[i])
+		}
+
+		if !ok {
+			if err := o.Base.Client.BlockStorage.Delete(cleanupCtx, dst.ID); err != nil {
+				cloneProgress("error deleting block storage %s : %v", dst.ID, err)
+				return
+			}
+			cloneProgress("deleted block storage %s", dst.ID)
+		}
+	}()
+
+	if dst, err = o.waitBlockActive(dst.ID); err != nil {
+		return nil, fmt.Errorf("error waiting for block storage : %v", err)
+	}
+
+	target, err := o.cloneInstance(req, req.Region, "target", cloneTargetScript(publicKey))
+	if target != nil {
+		instances = append(instances, target.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := o.cloneInstance(req, src.Region, "source", cloneSourceScript(privateKey, target.MainIP, src, dst))
+	if source != nil {
+		instances = append(instances, source.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bs := range []struct{ block, instance string }{{dst.ID, target.ID}, {src.ID, source.ID}} {
+		attachReq := &govultr.BlockStorageAttach{InstanceID: bs.instance, Live: govultr.BoolToBoolPtr(true)}
+		if err := o.Base.Client.BlockStorage.Attach(o.Base.Context, bs.block, attachReq); err != nil {
+			return nil, fmt.Errorf("error attaching block storage %s : %v", bs.block, err)
+		}
+		cloneProgress("attached block storage %s to %s", bs.block, bs.instance)
+	}
+
+	cloneProgress("copying %d GB, this can take a while", src.SizeGB)
+	if err := cli.Poll(o.Base.Context, cli.WaitInterval, req.Timeout, func() (bool, error) {
+		ins, _, err := o.Base.Client.Instance.Get(o.Base.Context, target.ID)
+		if err != nil {
+			return false, err
+		}
+		return ins.PowerStatus == "stopped", nil
+	}); err != nil {
+		return nil, fmt.Errorf("error waiting for the copy to finish : %v", err)
+	}
+	cloneProgress("copy finished")
+
+	for _, id := range []string{src.ID, dst.ID} {
+		detachReq := &govultr.BlockStorageDetach{Live: govultr.BoolToBoolPtr(true)}
+		if err := o.Base.Client.BlockStorage.Detach(o.Base.Context, id, detachReq); err != nil {
+			return nil, fmt.Errorf("error detaching block storage %s : %v", id, err)
+		}
+	}
+
+	ok = true
+
+	bs, _, err := o.Base.Client.BlockStorage.Get(o.Base.Context, dst.ID)
+	return bs, err
+}
+
+// cloneInstance creates a temporary instance running the script and waits
+// until it has an address
+func (o *options) cloneInstance(req *cloneReq, region, role, script string) (*govultr.Instance, error) {
+	ins, _, err := o.Base.Client.Instance.Create(o.Base.Context, &govultr.InstanceCreateReq{
+		Region:   region,
+		Plan:     req.Plan,
+		OsID:     req.OsID,
+		Label:    fmt.Sprintf("block-storage-clone-%s", role),
+		Tags:     []string{cloneTag},
+		UserData: base64.StdEncoding.EncodeToString([]byte(script)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary %s instance : %v", role, err)
+	}
+	cloneProgress("created temporary %s instance %s in %s", role, ins.ID, region)
+
+	if err := cli.Poll(o.Base.Context, cli.WaitInterval, cli.WaitTimeout, func() (bool, error) {
+		ins, _, err = o.Base.Client.Instance.Get(o.Base.Context, ins.ID)
+		if err != nil {
+			return false, err
+		}
+		return ins.Status == "active" && ins.MainIP != "" && ins.MainIP != "0.0.0.0", nil
+	}); err != nil {
+		return ins, fmt.Errorf("error waiting for temporary %s instance : %v", role, err)
+	}
+
+	return ins, nil
+}
+
+// waitBlockActive polls the block storage until it is active
+func (o *options) waitBlockActive(id string) (*govultr.BlockStorage, error) {
+	var bs *govultr.BlockStorage
+	err := cli.Poll(o.Base.Context, cli.WaitInterval, cli.WaitTimeout, func() (bool, error) {
+		var err error
+		if bs, _, err = o.Base.Client.BlockStorage.Get(o.Base.Context, id); err != nil {
+			return false, err
+		}
+		return bs.Status == "active", nil
+	})
+	return bs, err
+}
+
+// cloneTargetScript authorizes the generated key on the target instance
+func cloneTargetScript(publicKey string) string {
+	return fmt.Sprintf(`#!/bin/sh
+mkdir -p /root/.ssh
+chmod 700 /root/.ssh
+echo '%s' >> /root/.ssh/authorized_keys
+`, publicKey)
+}
+
+// cloneSourceScript streams the source device into the target device once
+// both are attached, powering the target off after the data is synced
+func cloneSourceScript(privateKey, targetIP string, src, dst *govultr.BlockStorage) string {
+	return fmt.Sprintf(`#!/bin/sh
+umask 077
+cat > /root/clone_key <<'KEY'
+%sKEY
+SSH="ssh -i /root/clone_key -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null root@%s"
+SRC=/dev/disk/by-id/virtio-%s
+DST=/dev/disk/by-id/virtio-%s
+until [ -b "$SRC" ]; do sleep 5; done
+until $SSH "test -b $DST"; do sleep 10; done
+dd if="$SRC" bs=4M status=none | gzip -1 | $SSH "gunzip | dd of=$DST bs=4M status=none && sync && poweroff"
+`, privateKey, targetIP, src.MountID, dst.MountID)
+}
+
+// cloneKeyPair generates a throwaway ECDSA key returning the PEM private key
+// and the public key in authorized_keys format
+func cloneKeyPair() (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	pub, err := key.PublicKey.ECDH()
+	if err != nil {
+		return "", "", err
+	}
+
+	var blob []byte
+	for _, field := range [][]byte{[]byte("ecdsa-sha2-nistp256"), []byte("nistp256"), pub.Bytes()} {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(field))) //nolint:gosec
+		blob = append(blob, field...)
+	}
+
+	private := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	public := "ecdsa-sha2-nistp256 " + base64.StdEncoding.EncodeToString(blob) + " " + cloneTag
+
+	return private, public, nil
+}
+
+// cloneProgress reports a step of the clone on stderr so it does not mix
+// with the printed block storage
+func cloneProgress(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+}
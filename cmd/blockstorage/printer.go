@@ -123,3 +123,81 @@ func (b *BlockStoragePrinter) Data() [][]string {
 func (b *BlockStoragePrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// BlockStorageDevicePrinter ...
+type BlockStorageDevicePrinter struct {
+	ID         string   `json:"id"`
+	InstanceID string   `json:"instance_id"`
+	MountID    string   `json:"mount_id"`
+	Device     string   `json:"device"`
+	MountPoint string   `json:"mount_point"`
+	Commands   []string `json:"commands"`
+}
+
+// newBlockStorageDevicePrinter builds the device path of an attached block
+// storage and the commands to format and mount it.  The disk serial of the
+// device is the mount ID so the by-id path is stable across reboots.  The
+// commands can be run again without mounting twice or repeating the fstab
+// entry.
+func newBlockStorageDevicePrinter(bs *govultr.BlockStorage, instanceID, mountPoint string) *BlockStorageDevicePrinter {
+	device := fmt.Sprintf("/dev/disk/by-id/virtio-%s", bs.MountID)
+
+	return &BlockStorageDevicePrinter{
+		ID:         bs.ID,
+		InstanceID: instanceID,
+		MountID:    bs.MountID,
+		Device:     device,
+		MountPoint: mountPoint,
+		Commands: []string{
+			fmt.Sprintf("sudo blkid %s || sudo mkfs.ext4 %s", device, device),
+			fmt.Sprintf("sudo mkdir -p %s", mountPoint),
+			fmt.Sprintf("mountpoint -q %s || sudo mount %s %s", mountPoint, device, mountPoint),
+			fmt.Sprintf(
+				"grep -qs '^%s ' /etc/fstab || echo '%s %s ext4 defaults,nofail,noatime 0 0' | sudo tee -a /etc/fstab",
+				device,
+				device,
+				mountPoint,
+			),
+		},
+	}
+}
+
+// JSON ...
+func (b *BlockStorageDevicePrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BlockStorageDevicePrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BlockStorageDevicePrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (b *BlockStorageDevicePrinter) Data() [][]string {
+	data := [][]string{
+		{"ID", b.ID},
+		{"INSTANCE ID", b.InstanceID},
+		{"MOUNT ID", b.MountID},
+		{"DEVICE", b.Device},
+		{" "},
+		{"SETUP"},
+	}
+
+	for i := range b.Commands {
+		data = append(data, []string{b.Commands[i]})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BlockStorageDevicePrinter) Paging() [][]string {
+	return nil
+}
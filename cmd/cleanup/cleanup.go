@@ -0,0 +1,325 @@
+// Package cleanup provides the functionality for finding and deleting
+// resources which are no longer in use
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	typeBlockStorage = "block-storage"
+	typeReservedIP   = "reserved-ip"
+	typeSnapshot     = "snapshot"
+	typeFirewall     = "firewall"
+
+	statusPlanned = "planned"
+	statusDeleted = "deleted"
+	statusFailed  = "failed"
+
+	defaultSnapshotAge = "90d"
+)
+
+// cleanupTypes are the kinds of resources which are looked for, in the order
+// they are listed
+var cleanupTypes = []string{typeBlockStorage, typeReservedIP, typeSnapshot, typeFirewall}
+
+var (
+	cleanupLong = `Find resources which are billed or kept around without being used and delete them.
+
+These resources are looked for:
+
+  block-storage  block storage which is not attached to an instance
+  reserved-ip    reserved IPs which are not attached to an instance
+  snapshot       snapshots older than --snapshot-older-than, 90 days by default
+  firewall       firewall groups which no instance uses
+
+Nothing is deleted unless --confirm is given, so run with --dry-run first to review the list.
+The monthly cost is shown where the API reports it.
+`
+	cleanupExample = `
+	# List the resources which would be deleted
+	vultr-cli cleanup --dry-run
+
+	# Delete unattached block storage and reserved IPs
+	vultr-cli cleanup --types block-storage,reserved-ip --confirm
+
+	# Only count snapshots older than a year
+	vultr-cli cleanup --types snapshot --snapshot-older-than 52w --dry-run
+	`
+)
+
+// NewCmdCleanup provides the CLI command for cleaning up unused resources
+func NewCmdCleanup(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "cleanup",
+		Short:   "Find and delete unused resources",
+		Long:    cleanupLong,
+		Example: cleanupExample,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types, errTy := cmd.Flags().GetStringSlice("types")
+			if errTy != nil {
+				return fmt.Errorf("error parsing flag 'types' for cleanup : %v", errTy)
+			}
+
+			olderThan, errOl := cmd.Flags().GetString("snapshot-older-than")
+			if errOl != nil {
+				return fmt.Errorf("error parsing flag 'snapshot-older-than' for cleanup : %v", errOl)
+			}
+
+			confirm, errCo := cmd.Flags().GetBool("confirm")
+			if errCo != nil {
+				return fmt.Errorf("error parsing flag 'confirm' for cleanup : %v", errCo)
+			}
+
+			for i := range types {
+				types[i] = strings.ToLower(strings.TrimSpace(types[i]))
+				if !slices.Contains(cleanupTypes, types[i]) {
+					return fmt.Errorf("invalid type %q, must be one of %s", types[i], strings.Join(cleanupTypes, ", "))
+				}
+			}
+
+			age, err := utils.ParseAge(olderThan)
+			if err != nil {
+				return err
+			}
+
+			items, err := o.find(types, age)
+			if err != nil {
+				return err
+			}
+
+			if confirm {
+				o.delete(items)
+			}
+
+			data := &CleanupPrinter{Items: items}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.count(statusFailed); failed > 0 {
+				return fmt.Errorf("%d of %d resources failed to delete", failed, len(items))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP(
+		"types",
+		"t",
+		cleanupTypes,
+		fmt.Sprintf("(optional) comma separated resource types to look for, any of %s", strings.Join(cleanupTypes, ", ")),
+	)
+	cmd.Flags().String(
+		"snapshot-older-than",
+		defaultSnapshotAge,
+		"(optional) only count snapshots older than this age, such as 30d, 2w or 12h",
+	)
+	cmd.Flags().Bool("confirm", false, "(optional) delete the resources found")
+	cmd.Flags().Bool("dry-run", false, "(optional) list the resources which would be deleted without deleting them")
+	cmd.MarkFlagsMutuallyExclusive("confirm", "dry-run")
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// item is an unused resource
+type item struct {
+	Type        string  `json:"type"`
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Reason      string  `json:"reason"`
+	MonthlyCost float32 `json:"monthly_cost,omitempty"`
+	Status      string  `json:"status"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// find looks for the unused resources of the types
+func (o *options) find(types []string, snapshotAge time.Duration) ([]item, error) {
+	var items []item
+	for _, t := range cleanupTypes {
+		if !slices.Contains(types, t) {
+			continue
+		}
+
+		var found []item
+		var err error
+		switch t {
+		case typeBlockStorage:
+			found, err = o.findBlockStorage()
+		case typeReservedIP:
+			found, err = o.findReservedIPs()
+		case typeSnapshot:
+			found, err = o.findSnapshots(snapshotAge)
+		case typeFirewall:
+			found, err = o.findFirewallGroups()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+
+	return items, nil
+}
+
+func (o *options) findBlockStorage() ([]item, error) {
+	blocks, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block storage : %v", err)
+	}
+
+	var items []item
+	for i := range blocks {
+		if blocks[i].AttachedToInstance != "" {
+			continue
+		}
+
+		items = append(items, item{
+			Type:        typeBlockStorage,
+			ID:          blocks[i].ID,
+			Description: strings.TrimSpace(fmt.Sprintf("%s %d GB in %s", blocks[i].Label, blocks[i].SizeGB, blocks[i].Region)),
+			Reason:      "not attached",
+			MonthlyCost: blocks[i].Cost,
+			Status:      statusPlanned,
+		})
+	}
+
+	return items, nil
+}
+
+func (o *options) findReservedIPs() ([]item, error) {
+	ips, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.ReservedIP, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.ReservedIP.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving reserved IPs : %v", err)
+	}
+
+	var items []item
+	for i := range ips {
+		if ips[i].InstanceID != "" {
+			continue
+		}
+
+		description := fmt.Sprintf("%s %s/%d in %s", ips[i].Label, ips[i].Subnet, ips[i].SubnetSize, ips[i].Region)
+		items = append(items, item{
+			Type:        typeReservedIP,
+			ID:          ips[i].ID,
+			Description: strings.TrimSpace(description),
+			Reason:      "not attached",
+			Status:      statusPlanned,
+		})
+	}
+
+	return items, nil
+}
+
+func (o *options) findSnapshots(age time.Duration) ([]item, error) {
+	snapshots, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving snapshots : %v", err)
+	}
+
+	cutoff := time.Now().Add(-age)
+
+	var items []item
+	for i := range snapshots {
+		created, err := time.Parse(time.RFC3339, snapshots[i].DateCreated)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the creation date of snapshot %s : %v", snapshots[i].ID, err)
+		}
+
+		if created.After(cutoff) {
+			continue
+		}
+
+		items = append(items, item{
+			Type:        typeSnapshot,
+			ID:          snapshots[i].ID,
+			Description: snapshots[i].Description,
+			Reason:      fmt.Sprintf("created %s", created.Format(time.DateOnly)),
+			Status:      statusPlanned,
+		})
+	}
+
+	return items, nil
+}
+
+func (o *options) findFirewallGroups() ([]item, error) {
+	groups, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.FirewallGroup.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving firewall groups : %v", err)
+	}
+
+	var items []item
+	for i := range groups {
+		if groups[i].InstanceCount > 0 {
+			continue
+		}
+
+		items = append(items, item{
+			Type:        typeFirewall,
+			ID:          groups[i].ID,
+			Description: groups[i].Description,
+			Reason:      fmt.Sprintf("no instances, %d rules", groups[i].RuleCount),
+			Status:      statusPlanned,
+		})
+	}
+
+	return items, nil
+}
+
+// delete deletes the resources, recording the outcome of each
+func (o *options) delete(items []item) {
+	for i := range items {
+		var err error
+		switch items[i].Type {
+		case typeBlockStorage:
+			err = o.Base.Client.BlockStorage.Delete(o.Base.Context, items[i].ID)
+		case typeReservedIP:
+			err = o.Base.Client.ReservedIP.Delete(o.Base.Context, items[i].ID)
+		case typeSnapshot:
+			err = o.Base.Client.Snapshot.Delete(o.Base.Context, items[i].ID)
+		case typeFirewall:
+			err = o.Base.Client.FirewallGroup.Delete(o.Base.Context, items[i].ID)
+		}
+
+		items[i].Status = statusDeleted
+		if err != nil {
+			items[i].Status = statusFailed
+			items[i].Error = err.Error()
+		}
+	}
+}
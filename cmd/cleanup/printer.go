@@ -0,0 +1,81 @@
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// CleanupPrinter ...
+type CleanupPrinter struct {
+	Items []item `json:"resources"`
+}
+
+// JSON ...
+func (c *CleanupPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *CleanupPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *CleanupPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"ID",
+		"DESCRIPTION",
+		"REASON",
+		"MONTHLY COST",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (c *CleanupPrinter) Data() [][]string {
+	if len(c.Items) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range c.Items {
+		cost := ""
+		if c.Items[i].MonthlyCost > 0 {
+			cost = fmt.Sprintf("$%.2f", c.Items[i].MonthlyCost)
+		}
+
+		status := c.Items[i].Status
+		if c.Items[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, c.Items[i].Error)
+		}
+
+		data = append(data, []string{
+			c.Items[i].Type,
+			c.Items[i].ID,
+			c.Items[i].Description,
+			c.Items[i].Reason,
+			cost,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (c *CleanupPrinter) Paging() [][]string {
+	return nil
+}
+
+// count returns the number of resources with the status
+func (c *CleanupPrinter) count(status string) int {
+	n := 0
+	for i := range c.Items {
+		if c.Items[i].Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,428 @@
+// Package config provides the commands to manage the CLI config file
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+	"github.com/vultr/vultr-cli/v3/pkg/keyring"
+)
+
+var (
+	configLong = `Manage the settings in the vultr-cli config file.
+
+Settings are addressed by key.  The top level keys api-key and output apply without a profile
+while profiles are set with profiles.<name>.api-key, profiles.<name>.output and
+profiles.<name>.region.`
+	configExample = `
+	# Full example
+	vultr-cli config
+	`
+
+	initLong = `Interactively create the config file settings, or those of a profile when a name is given.
+The API key is validated against the account before anything is written.`
+	initExample = `
+	# Full example
+	vultr-cli config init
+
+	# Create the settings of the prod profile
+	vultr-cli config init prod
+	`
+
+	getLong    = `Print the value of a setting in the config file`
+	getExample = `
+	# Full example
+	vultr-cli config get output
+
+	# Shortened with alias commands
+	vultr-cli config g profiles.dev.region
+	`
+
+	setLong    = `Set a setting in the config file`
+	setExample = `
+	# Full example
+	vultr-cli config set output json
+
+	# Set the default region of the dev profile
+	vultr-cli config set profiles.dev.region ams
+	`
+
+	setAPIKeyLong = `Prompt for an API key, check it against the account and save it for the selected profile,
+or without a profile when none is selected.  Reading the key from a prompt keeps it out of the shell history.
+
+With --keyring the key is stored in the credential store of the operating system (Keychain on macOS,
+Credential Manager on Windows, the Secret Service through libsecret's secret-tool elsewhere) and only
+keyring: true is written to the config file.`
+	setAPIKeyExample = `
+	# Full example
+	vultr-cli config set-api-key --keyring
+
+	# Store the key of the prod profile, reading it from a file
+	vultr-cli config set-api-key --keyring --profile prod < prod.key
+	`
+
+	unsetLong    = `Remove a setting from the config file`
+	unsetExample = `
+	# Full example
+	vultr-cli config unset profiles.dev.region
+	`
+
+	listLong    = `List the settings in the config file.  API keys are masked unless --show-secrets is given.`
+	listExample = `
+	# Full example
+	vultr-cli config list
+
+	# Shortened with alias commands
+	vultr-cli config l
+	`
+)
+
+// NewCmdConfig provides the CLI command for managing the config file
+func NewCmdConfig(base *cli.Base) *cobra.Command { //nolint:gocyclo
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Manage the config file settings",
+		Long:    configLong,
+		Example: configExample,
+		// the selected profile is not loaded so a missing profile can be
+		// created with config init
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			o.Base.Args = args
+			o.Base.Printer.Output = viper.GetString("output")
+			o.Path = viper.ConfigFileUsed()
+		},
+	}
+
+	// Init
+	initCmd := &cobra.Command{
+		Use:     "init [profile name]",
+		Short:   "Create the config file settings interactively",
+		Long:    initLong,
+		Example: initExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var profile string
+			if len(args) > 0 {
+				profile = args[0]
+			}
+
+			if err := o.init(profile); err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("config has been written to %s", o.Path)), nil)
+
+			return nil
+		},
+	}
+
+	// Get
+	get := &cobra.Command{
+		Use:     "get <key>",
+		Short:   "Print a setting",
+		Aliases: []string{"g"},
+		Long:    getLong,
+		Example: getExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a setting key")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := readConfig(o.Path)
+			if err != nil {
+				return err
+			}
+
+			value, ok := c.get(args[0])
+			if !ok {
+				return fmt.Errorf("setting %q is not set", args[0])
+			}
+
+			o.Base.Printer.Display(&SettingsPrinter{Settings: []setting{{Key: args[0], Value: value}}}, nil)
+
+			return nil
+		},
+	}
+
+	// Set
+	set := &cobra.Command{
+		Use:     "set <key> <value>",
+		Short:   "Change a setting",
+		Aliases: []string{"s"},
+		Long:    setLong,
+		Example: setExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a setting key and value")
+			}
+			return validateKey(args[0])
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := readConfig(o.Path)
+			if err != nil {
+				return err
+			}
+
+			value, err := parseValue(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			c.set(args[0], value)
+			if err := c.write(); err != nil {
+				return fmt.Errorf("error writing config file : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("%s has been set", args[0])), nil)
+
+			return nil
+		},
+	}
+
+	// Set API Key
+	setAPIKey := &cobra.Command{
+		Use:     "set-api-key",
+		Short:   "Save an API key",
+		Long:    setAPIKeyLong,
+		Example: setAPIKeyExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useKeyring, errKr := cmd.Flags().GetBool("keyring")
+			if errKr != nil {
+				return fmt.Errorf("error parsing flag 'keyring' for config set-api-key : %v", errKr)
+			}
+
+			profile := viper.GetString("profile")
+			if err := o.setAPIKey(profile, useKeyring); err != nil {
+				return err
+			}
+
+			where := o.Path
+			if useKeyring {
+				where = "the keyring"
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("API key has been saved to %s", where)), nil)
+
+			return nil
+		},
+	}
+
+	setAPIKey.Flags().Bool("keyring", false, "(optional) store the API key in the OS credential store")
+
+	// Unset
+	unset := &cobra.Command{
+		Use:     "unset <key>",
+		Short:   "Remove a setting",
+		Aliases: []string{"u"},
+		Long:    unsetLong,
+		Example: unsetExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a setting key")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := readConfig(o.Path)
+			if err != nil {
+				return err
+			}
+
+			if !c.unset(args[0]) {
+				return fmt.Errorf("setting %q is not set", args[0])
+			}
+
+			if err := c.write(); err != nil {
+				return fmt.Errorf("error writing config file : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("%s has been removed", args[0])), nil)
+
+			return nil
+		},
+	}
+
+	// List
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List the settings",
+		Aliases: []string{"l"},
+		Long:    listLong,
+		Example: listExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			showSecrets, errSh := cmd.Flags().GetBool("show-secrets")
+			if errSh != nil {
+				return fmt.Errorf("error parsing flag 'show-secrets' for config list : %v", errSh)
+			}
+
+			c, err := readConfig(o.Path)
+			if err != nil {
+				return err
+			}
+
+			settings := c.list()
+			if !showSecrets {
+				for i := range settings {
+					settings[i].Value = maskSecret(settings[i].Key, settings[i].Value)
+				}
+			}
+
+			o.Base.Printer.Display(&SettingsPrinter{Settings: settings}, nil)
+
+			return nil
+		},
+	}
+
+	list.Flags().Bool("show-secrets", false, "(optional) show the API keys in full")
+
+	cmd.AddCommand(
+		initCmd,
+		get,
+		set,
+		setAPIKey,
+		unset,
+		list,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+	Path string
+}
+
+// init prompts for the settings, checking the API key against the account
+// before writing them to the top level or the named profile
+func (o *options) init(profile string) error {
+	c, err := readConfig(o.Path)
+	if err != nil {
+		return err
+	}
+
+	prefix, account := scope(profile)
+
+	// keep using the keyring when the key is already stored there
+	kr, _ := c.get(prefix + "keyring")
+	useKeyring := kr == "true"
+
+	current, _ := c.get(prefix + "api-key")
+	if useKeyring {
+		current, _ = keyring.Get(o.Base.Context, account)
+	}
+
+	apiKey := utils.Prompt("Vultr API key", maskSecret("api-key", current))
+	if apiKey == maskSecret("api-key", current) {
+		apiKey = current
+	}
+
+	if apiKey == "" {
+		return errors.New("an API key is required")
+	}
+
+	if err := o.validateAPIKey(apiKey); err != nil {
+		return err
+	}
+
+	current, _ = c.get(prefix + "output")
+	if current == "" {
+		current = "text"
+	}
+	output := utils.Prompt("Default output format (text, json, yaml, csv)", current)
+	if !slices.Contains([]string{"text", "json", "yaml", "csv"}, output) {
+		return fmt.Errorf("invalid output format %q", output)
+	}
+
+	if useKeyring {
+		if err := keyring.Set(o.Base.Context, account, apiKey); err != nil {
+			return fmt.Errorf("error storing API key in keyring : %v", err)
+		}
+	} else {
+		c.set(prefix+"api-key", apiKey)
+	}
+	c.set(prefix+"output", output)
+
+	if profile != "" {
+		current, _ = c.get(prefix + "region")
+		if region := utils.Prompt("Default region ID, empty for none", current); region != "" {
+			c.set(prefix+"region", region)
+		}
+	}
+
+	if err := c.write(); err != nil {
+		return fmt.Errorf("error writing config file : %v", err)
+	}
+
+	return nil
+}
+
+// setAPIKey prompts for an API key and saves it to the config file or the
+// keyring, for the profile when one is given
+func (o *options) setAPIKey(profile string, useKeyring bool) error {
+	c, err := readConfig(o.Path)
+	if err != nil {
+		return err
+	}
+
+	prefix, account := scope(profile)
+
+	apiKey := utils.Prompt("Vultr API key", "")
+	if apiKey == "" {
+		return errors.New("an API key is required")
+	}
+
+	if err := o.validateAPIKey(apiKey); err != nil {
+		return err
+	}
+
+	if useKeyring {
+		if err := keyring.Set(o.Base.Context, account, apiKey); err != nil {
+			return fmt.Errorf("error storing API key in keyring : %v", err)
+		}
+		c.unset(prefix + "api-key")
+		c.set(prefix+"keyring", true)
+	} else {
+		c.unset(prefix + "keyring")
+		c.set(prefix+"api-key", apiKey)
+	}
+
+	if err := c.write(); err != nil {
+		return fmt.Errorf("error writing config file : %v", err)
+	}
+
+	return nil
+}
+
+// validateAPIKey switches the client to the API key and makes sure the
+// account can be retrieved with it
+func (o *options) validateAPIKey(apiKey string) error {
+	o.Base.UseAPIKey(apiKey)
+	account, _, err := o.Base.Client.Account.Get(o.Base.Context)
+	if err != nil {
+		return fmt.Errorf("error validating API key : %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "API key is valid for %s\n", account.Email)
+
+	return nil
+}
+
+// scope returns the key prefix of the settings and the keyring account for
+// the profile, or those used without a profile when it is empty
+func scope(profile string) (string, string) {
+	if profile == "" {
+		return "", keyring.DefaultAccount
+	}
+	return fmt.Sprintf("%s.%s.", profilesKey, profile), profile
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	profilesKey = "profiles"
+	configPerm  = 0o600
+)
+
+var (
+	// topLevelKeys are the settings which apply without a profile
+	topLevelKeys = []string{
+		"api-key", "keyring", "output", "retries", "retry-max-wait", "cache-ttl", "audit",
+		"default_region", "default_plan", "default_os",
+	}
+	// profileKeys are the settings of a profile
+	profileKeys = []string{"api-key", "keyring", "output", "region"}
+)
+
+// validateKey makes sure the dotted key is a known setting, either at the
+// top level or as profiles.<name>.<setting>
+func validateKey(key string) error {
+	parts := strings.Split(key, ".")
+	switch {
+	case len(parts) == 1 && slices.Contains(topLevelKeys, parts[0]):
+		return nil
+	case len(parts) == 3 && parts[0] == profilesKey && parts[1] != "" && slices.Contains(profileKeys, parts[2]):
+		return nil
+	}
+
+	return fmt.Errorf(
+		"unknown setting %q, possible settings are %s or profiles.<name>.%s",
+		key,
+		strings.Join(topLevelKeys, ", "),
+		strings.Join(profileKeys, "|"),
+	)
+}
+
+// configFile is the parsed YAML config file
+type configFile struct {
+	path     string
+	settings map[string]interface{}
+}
+
+// readConfig parses the config file, a missing or empty file has no settings
+func readConfig(path string) (*configFile, error) {
+	c := &configFile{path: path, settings: map[string]interface{}{}}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &c.settings); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s : %v", path, err)
+	}
+
+	if c.settings == nil {
+		c.settings = map[string]interface{}{}
+	}
+
+	return c, nil
+}
+
+// write saves the config file readable only by the user since it holds API
+// keys
+func (c *configFile) write() error {
+	data, err := yaml.Marshal(c.settings)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.path, data, configPerm); err != nil {
+		return err
+	}
+
+	return os.Chmod(c.path, configPerm)
+}
+
+// get returns the value of the dotted key
+func (c *configFile) get(key string) (string, bool) {
+	parts := strings.Split(key, ".")
+
+	m := c.settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		m = next
+	}
+
+	v, ok := m[parts[len(parts)-1]]
+	if !ok || v == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%v", v), true
+}
+
+// set stores the value of the dotted key, creating the parent maps
+func (c *configFile) set(key string, value interface{}) {
+	parts := strings.Split(key, ".")
+
+	m := c.settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[part] = next
+		}
+		m = next
+	}
+
+	m[parts[len(parts)-1]] = value
+}
+
+// unset removes the dotted key, dropping parents which end up empty
+func (c *configFile) unset(key string) bool {
+	return unsetPath(c.settings, strings.Split(key, "."))
+}
+
+func unsetPath(m map[string]interface{}, parts []string) bool {
+	if len(parts) == 1 {
+		if _, ok := m[parts[0]]; !ok {
+			return false
+		}
+		delete(m, parts[0])
+		return true
+	}
+
+	next, ok := m[parts[0]].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	removed := unsetPath(next, parts[1:])
+	if len(next) == 0 {
+		delete(m, parts[0])
+	}
+	return removed
+}
+
+// parseValue converts the value given on the command line to the type of
+// the setting
+func parseValue(key, value string) (interface{}, error) {
+	switch {
+	case key == "keyring" || strings.HasSuffix(key, ".keyring") || key == "audit":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s, must be true or false", value, key)
+		}
+		return b, nil
+	case key == "retries" || key == "default_os":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s, must be a positive number", value, key)
+		}
+		return n, nil
+	case key == "retry-max-wait" || key == "cache-ttl":
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s, must be a duration such as 30s", value, key)
+		}
+	}
+
+	return value, nil
+}
+
+// setting is a single flattened key and value of the config file
+type setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// list flattens the settings into dotted keys in sorted order
+func (c *configFile) list() []setting {
+	var settings []setting
+	flatten(c.settings, "", &settings)
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings
+}
+
+func flatten(m map[string]interface{}, prefix string, settings *[]setting) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if next, ok := v.(map[string]interface{}); ok {
+			flatten(next, key, settings)
+			continue
+		}
+		*settings = append(*settings, setting{Key: key, Value: fmt.Sprintf("%v", v)})
+	}
+}
+
+// maskSecret hides all but the last characters of an API key
+func maskSecret(key, value string) string {
+	const visible = 4
+	if !strings.HasSuffix(key, "api-key") {
+		return value
+	}
+	if len(value) <= visible {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", len(value)-visible) + value[len(value)-visible:]
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// SettingsPrinter ...
+type SettingsPrinter struct {
+	Settings []setting `json:"settings"`
+}
+
+// JSON ...
+func (s *SettingsPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SettingsPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SettingsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"KEY",
+		"VALUE",
+	}}
+}
+
+// Data ...
+func (s *SettingsPrinter) Data() [][]string {
+	if len(s.Settings) == 0 {
+		return [][]string{0: {"---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Settings {
+		data = append(data, []string{
+			s.Settings[i].Key,
+			s.Settings[i].Value,
+		})
+	}
+	return data
+}
+
+// Paging ...
+func (s *SettingsPrinter) Paging() [][]string {
+	return nil
+}
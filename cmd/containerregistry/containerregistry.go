@@ -5,7 +5,11 @@ package containerregistry
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -75,6 +79,29 @@ var (
 	# Full example
 	vultr-cli container-registry credentials docker d24cfdcc-0534-4700-bf88-8ee48f20064e 
 	`
+	dockerLoginLong = `Generates docker credentials for a container registry and prints the docker login command which
+uses them.  With --run the local docker client is logged in directly, passing the password on stdin.`
+	dockerLoginExample = `
+	# Full example
+	vultr-cli container-registry docker-login d24cfdcc-0534-4700-bf88-8ee48f20064e
+
+	# Log in with push access using the local docker client
+	vultr-cli container-registry docker-login d24cfdcc-0534-4700-bf88-8ee48f20064e --read-write --run
+	`
+	credentialsGenerateLong = `Generates credentials for pulling from a container registry.  The credentials are read-only
+unless --read-only=false is given and never expire unless --expires is set, such as 30d, 2w or 12h.
+
+With --kubernetes-secret a kubernetes.io/dockerconfigjson secret is printed which can be applied directly
+and referenced from imagePullSecrets.`
+	//nolint:gosec
+	credentialsGenerateExample = `
+	# Full example
+	vultr-cli container-registry credentials generate d24cfdcc-0534-4700-bf88-8ee48f20064e --expires="30d"
+
+	# Create an image pull secret in a cluster
+	vultr-cli container-registry credentials generate d24cfdcc-0534-4700-bf88-8ee48f20064e --expires="30d" \
+		--kubernetes-secret --secret-name="vcr-pull" --namespace="apps" | kubectl apply -f -
+	`
 	repoLong    = `Access commands for individual repositories on a container registry`
 	repoExample = `
 	# Full example
@@ -146,7 +173,7 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			regs, meta, err := o.list()
+			regs, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving container registry list : %v", err)
 			}
@@ -168,6 +195,7 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -253,6 +281,7 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		fmt.Printf("error marking container registry create 'region' flag required: %v", err)
 		os.Exit(1)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
 
 	create.Flags().BoolP(
 		"public",
@@ -404,7 +433,7 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			repos, meta, err := o.repositoryList()
+			repos, meta, err := utils.ListAll(cmd, o.Base.Options, o.repositoryList)
 			if err != nil {
 				return fmt.Errorf("error retrieving repositories for container registry : %v", err)
 			}
@@ -416,6 +445,8 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		},
 	}
 
+	utils.AddListAllFlag(repoList)
+
 	// Repository Get
 	repoGet := &cobra.Command{
 		Use:     "get <Registry ID>",
@@ -626,9 +657,172 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		"(optional) Whether or not these credentials have write access.  Should be true or false.  Default is false",
 	)
 
+	// Credentials Generate
+	credentialsGenerate := &cobra.Command{
+		Use:     "generate <Registry ID>",
+		Short:   "Generate pull credentials for a container registry",
+		Aliases: []string{"g"},
+		Long:    credentialsGenerateLong,
+		Example: credentialsGenerateExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a container registry ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expires, errEx := cmd.Flags().GetString("expires")
+			if errEx != nil {
+				return fmt.Errorf("error parsing 'expires' flag for container registry credentials : %v", errEx)
+			}
+
+			readOnly, errRe := cmd.Flags().GetBool("read-only")
+			if errRe != nil {
+				return fmt.Errorf("error parsing 'read-only' flag for container registry credentials : %v", errRe)
+			}
+
+			secret, errSe := cmd.Flags().GetBool("kubernetes-secret")
+			if errSe != nil {
+				return fmt.Errorf("error parsing 'kubernetes-secret' flag for container registry credentials : %v", errSe)
+			}
+
+			name, errNa := cmd.Flags().GetString("secret-name")
+			if errNa != nil {
+				return fmt.Errorf("error parsing 'secret-name' flag for container registry credentials : %v", errNa)
+			}
+
+			namespace, errNs := cmd.Flags().GetString("namespace")
+			if errNs != nil {
+				return fmt.Errorf("error parsing 'namespace' flag for container registry credentials : %v", errNs)
+			}
+
+			var expiry time.Duration
+			if expires != "" {
+				var err error
+				if expiry, err = utils.ParseAge(expires); err != nil {
+					return err
+				}
+			}
+
+			o.CredentialsDockerReq = &govultr.DockerCredentialsOpt{
+				ExpirySeconds: govultr.IntToIntPtr(int(expiry.Seconds())),
+				WriteAccess:   govultr.BoolToBoolPtr(!readOnly),
+			}
+
+			cred, err := o.credentialsDocker()
+			if err != nil {
+				return fmt.Errorf("error generating container registry credentials : %v", err)
+			}
+
+			if secret {
+				data := &ContainerRegistrySecretPrinter{Secret: newDockerConfigSecret(name, namespace, cred)}
+				o.Base.Printer.Display(data, nil)
+				return nil
+			}
+
+			login, err := parseDockerCredentials(cred)
+			if err != nil {
+				return err
+			}
+
+			data := &ContainerRegistryCredentialsPrinter{Login: login, ReadOnly: readOnly, Expires: "never"}
+			if expiry > 0 {
+				data.Expires = time.Now().Add(expiry).UTC().Format(time.RFC3339)
+			}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	credentialsGenerate.Flags().String("expires", "", "(optional) how long until the credentials expire, such as 30d")
+	credentialsGenerate.Flags().Bool("read-only", true, "(optional) limit the credentials to pulling images")
+	credentialsGenerate.Flags().Bool(
+		"kubernetes-secret",
+		false,
+		"(optional) print a kubernetes image pull secret instead of the credentials",
+	)
+	credentialsGenerate.Flags().String("secret-name", defaultSecretName, "(optional) name of the kubernetes secret")
+	credentialsGenerate.Flags().String("namespace", "", "(optional) namespace of the kubernetes secret")
+
 	credentials.AddCommand(
 		credentialsDocker,
+		credentialsGenerate,
+	)
+
+	// Docker Login
+	dockerLogin := &cobra.Command{
+		Use:     "docker-login <Registry ID>",
+		Short:   "Log docker in to a container registry",
+		Aliases: []string{"login"},
+		Long:    dockerLoginLong,
+		Example: dockerLoginExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a container registry ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expiry, errEx := cmd.Flags().GetInt("expiry-seconds")
+			if errEx != nil {
+				return fmt.Errorf("error parsing 'expiry-seconds' flag for container registry docker-login : %v", errEx)
+			}
+
+			access, errAc := cmd.Flags().GetBool("read-write")
+			if errAc != nil {
+				return fmt.Errorf("error parsing 'read-write' flag for container registry docker-login : %v", errAc)
+			}
+
+			run, errRu := cmd.Flags().GetBool("run")
+			if errRu != nil {
+				return fmt.Errorf("error parsing 'run' flag for container registry docker-login : %v", errRu)
+			}
+
+			o.CredentialsDockerReq = &govultr.DockerCredentialsOpt{
+				ExpirySeconds: govultr.IntToIntPtr(expiry),
+				WriteAccess:   govultr.BoolToBoolPtr(access),
+			}
+
+			cred, err := o.credentialsDocker()
+			if err != nil {
+				return fmt.Errorf("error generating container registry docker credentials : %v", err)
+			}
+
+			login, err := parseDockerCredentials(cred)
+			if err != nil {
+				return err
+			}
+
+			if run {
+				if err := o.dockerLoginRun(login); err != nil {
+					return fmt.Errorf("error running docker login : %v", err)
+				}
+
+				o.Base.Printer.Display(printer.Info(fmt.Sprintf("docker has been logged in to %s", login.Host)), nil)
+				return nil
+			}
+
+			data := &ContainerRegistryDockerLoginPrinter{Login: login}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	dockerLogin.Flags().IntP(
+		"expiry-seconds",
+		"e",
+		0,
+		"(optional) The seconds until these credentials expire.  Default is 0, never",
 	)
+	dockerLogin.Flags().BoolP(
+		"read-write",
+		"w",
+		false,
+		"(optional) Whether or not these credentials have write access.  Default is false",
+	)
+	dockerLogin.Flags().Bool("run", false, "(optional) run docker login instead of printing the command")
 
 	cmd.AddCommand(
 		list,
@@ -640,6 +834,7 @@ func NewCmdContainerRegistry(base *cli.Base) *cobra.Command { //nolint:funlen,go
 		regions,
 		repository,
 		credentials,
+		dockerLogin,
 	)
 
 	return cmd
@@ -713,11 +908,29 @@ func (o *options) repositoryDelete() error {
 	return o.Base.Client.ContainerRegistry.DeleteRepository(o.Base.Context, o.Base.Args[0], o.RepoName)
 }
 
+// credentialsDocker generates docker credentials for the registry.  govultr
+// sends the address of the expiry rather than its value so the request is
+// built here.
 func (o *options) credentialsDocker() (*govultr.ContainerRegistryDockerCredentials, error) {
-	cred, _, err := o.Base.Client.ContainerRegistry.CreateDockerCredentials(
-		o.Base.Context,
-		o.Base.Args[0],
-		o.CredentialsDockerReq,
-	)
-	return cred, err
+	uri := fmt.Sprintf("/v2/registry/%s/docker-credentials", o.Base.Args[0])
+	req, err := o.Base.Client.NewRequest(o.Base.Context, http.MethodOptions, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if o.CredentialsDockerReq.ExpirySeconds != nil {
+		query.Set("expiry_seconds", strconv.Itoa(*o.CredentialsDockerReq.ExpirySeconds))
+	}
+	if o.CredentialsDockerReq.WriteAccess != nil {
+		query.Set("read_write", strconv.FormatBool(*o.CredentialsDockerReq.WriteAccess))
+	}
+	req.URL.RawQuery = query.Encode()
+
+	cred := new(govultr.ContainerRegistryDockerCredentials)
+	if _, err := o.Base.Client.DoWithContext(o.Base.Context, req, cred); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
 }
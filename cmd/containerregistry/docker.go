@@ -0,0 +1,112 @@
+package containerregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// dockerLogin holds the registry host and credentials unpacked from the
+// docker config generated by the API
+type dockerLogin struct {
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// parseDockerCredentials unpacks the first registry of the docker config
+// JSON returned when creating docker credentials
+func parseDockerCredentials(cred *govultr.ContainerRegistryDockerCredentials) (*dockerLogin, error) {
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+
+	if err := json.Unmarshal([]byte(cred.String()), &config); err != nil {
+		return nil, fmt.Errorf("error parsing docker credentials : %v", err)
+	}
+
+	for host, auth := range config.Auths {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding docker credentials : %v", err)
+		}
+
+		user, pass, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return nil, errors.New("docker credentials are not in the user:password format")
+		}
+
+		return &dockerLogin{Host: host, Username: user, Password: pass}, nil
+	}
+
+	return nil, errors.New("docker credentials do not contain a registry")
+}
+
+// command returns the docker login command line for the credentials.  The
+// values are quoted for the shell and the password is passed with printf,
+// since some shells' echo expands backslashes in it
+func (d *dockerLogin) command() string {
+	return fmt.Sprintf(
+		"printf '%%s' %s | docker login %s --username %s --password-stdin",
+		utils.ShellQuote(d.Password),
+		utils.ShellQuote(d.Host),
+		utils.ShellQuote(d.Username),
+	)
+}
+
+// dockerLoginRun logs the local docker client into the registry passing the password
+// on stdin so it never shows up in the process list
+func (o *options) dockerLoginRun(login *dockerLogin) error {
+	cmd := exec.CommandContext(
+		o.Base.Context,
+		"docker", "login", login.Host, "--username", login.Username, "--password-stdin",
+	)
+	cmd.Stdin = strings.NewReader(login.Password)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// defaultSecretName is the name given to generated kubernetes secrets
+const defaultSecretName = "vultr-container-registry"
+
+// dockerConfigSecret is a kubernetes image pull secret
+type dockerConfigSecret struct {
+	APIVersion string            `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string            `json:"kind" yaml:"kind"`
+	Metadata   secretMetadata    `json:"metadata" yaml:"metadata"`
+	Type       string            `json:"type" yaml:"type"`
+	Data       map[string]string `json:"data" yaml:"data"`
+}
+
+type secretMetadata struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// newDockerConfigSecret wraps the docker config of the credentials in a
+// kubernetes.io/dockerconfigjson secret
+func newDockerConfigSecret(
+	name, namespace string,
+	cred *govultr.ContainerRegistryDockerCredentials,
+) *dockerConfigSecret {
+	return &dockerConfigSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   secretMetadata{Name: name, Namespace: namespace},
+		Type:       "kubernetes.io/dockerconfigjson",
+		Data: map[string]string{
+			".dockerconfigjson": base64.StdEncoding.EncodeToString([]byte(cred.String())),
+		},
+	}
+}
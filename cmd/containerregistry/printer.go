@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -378,3 +379,113 @@ func (c *ContainerRegistryCredentialDockerPrinter) Data() [][]string {
 func (c *ContainerRegistryCredentialDockerPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// ContainerRegistryDockerLoginPrinter ...
+type ContainerRegistryDockerLoginPrinter struct {
+	Login *dockerLogin `json:"docker_login"`
+}
+
+// JSON ...
+func (c *ContainerRegistryDockerLoginPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *ContainerRegistryDockerLoginPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *ContainerRegistryDockerLoginPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (c *ContainerRegistryDockerLoginPrinter) Data() [][]string {
+	return [][]string{0: {c.Login.command()}}
+}
+
+// Paging ...
+func (c *ContainerRegistryDockerLoginPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// ContainerRegistryCredentialsPrinter ...
+type ContainerRegistryCredentialsPrinter struct {
+	Login    *dockerLogin `json:"credentials"`
+	ReadOnly bool         `json:"read_only"`
+	Expires  string       `json:"expires"`
+}
+
+// JSON ...
+func (c *ContainerRegistryCredentialsPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *ContainerRegistryCredentialsPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *ContainerRegistryCredentialsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"HOST",
+		"USERNAME",
+		"PASSWORD",
+		"READ ONLY",
+		"EXPIRES",
+	}}
+}
+
+// Data ...
+func (c *ContainerRegistryCredentialsPrinter) Data() [][]string {
+	return [][]string{0: {
+		c.Login.Host,
+		c.Login.Username,
+		c.Login.Password,
+		strconv.FormatBool(c.ReadOnly),
+		c.Expires,
+	}}
+}
+
+// Paging ...
+func (c *ContainerRegistryCredentialsPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// ContainerRegistrySecretPrinter ...
+type ContainerRegistrySecretPrinter struct {
+	Secret *dockerConfigSecret
+}
+
+// JSON ...
+func (c *ContainerRegistrySecretPrinter) JSON() []byte {
+	return printer.MarshalObject(c.Secret, "json")
+}
+
+// YAML ...
+func (c *ContainerRegistrySecretPrinter) YAML() []byte {
+	return printer.MarshalObject(c.Secret, "yaml")
+}
+
+// Columns ...
+func (c *ContainerRegistrySecretPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (c *ContainerRegistrySecretPrinter) Data() [][]string {
+	return [][]string{0: {strings.TrimSpace(string(c.YAML()))}}
+}
+
+// Paging ...
+func (c *ContainerRegistrySecretPrinter) Paging() [][]string {
+	return nil
+}
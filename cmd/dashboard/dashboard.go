@@ -0,0 +1,196 @@
+// Package dashboard provides an interactive terminal view of the account
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// defaultRefresh is the delay between refreshes of the dashboard
+const defaultRefresh = 10 * time.Second
+
+var (
+	dashboardLong = `Show a live view of the instances, load balancers, databases and billing of the account.
+
+The selected instance can be started with s, stopped with h and deleted with d after confirming with y.
+The arrow keys, or j and k, change the selection, r refreshes and q quits.  Only the first page of each
+resource is shown.`
+	dashboardExample = `
+	# Full example
+	vultr-cli dashboard
+
+	# Refresh every 30 seconds
+	vultr-cli dashboard --refresh 30s
+	`
+)
+
+// NewCmdDashboard provides the CLI command for the dashboard
+func NewCmdDashboard(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "dashboard",
+		Short:   "Interactive dashboard of the account",
+		Aliases: []string{"dash"},
+		Long:    dashboardLong,
+		Example: dashboardExample,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			refresh, errRe := cmd.Flags().GetDuration("refresh")
+			if errRe != nil {
+				return fmt.Errorf("error parsing flag 'refresh' for dashboard : %v", errRe)
+			}
+
+			if refresh <= 0 {
+				return errors.New("refresh must be greater than 0")
+			}
+
+			o.interval = refresh
+
+			return o.run()
+		},
+	}
+
+	cmd.Flags().Duration("refresh", defaultRefresh, "(optional) how often the dashboard is refreshed")
+
+	return cmd
+}
+
+type options struct {
+	Base     *cli.Base
+	interval time.Duration
+	state    *state
+	selected int
+	message  string
+	// deleting holds the instance waiting for the delete to be confirmed
+	deleting string
+}
+
+// run shows the dashboard until q is pressed or the CLI is interrupted
+func (o *options) run() error {
+	// the context is canceled by an interrupt
+	ctx := o.Base.Context
+
+	term, err := openTerminal(ctx)
+	if err != nil {
+		return err
+	}
+	defer term.restore()
+
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	o.state = o.fetch()
+	for {
+		fmt.Print(o.render())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			o.refresh()
+		case key, ok := <-keys:
+			if !ok || o.handleKey(key) {
+				return nil
+			}
+		}
+	}
+}
+
+// refresh fetches the state again, keeping the selection in range
+func (o *options) refresh() {
+	o.state = o.fetch()
+	o.selected = min(o.selected, max(len(o.state.Instances)-1, 0))
+}
+
+// handleKey acts on a key press and reports whether the dashboard should
+// quit
+func (o *options) handleKey(key string) bool {
+	if o.deleting != "" {
+		o.confirmDelete(key == "y" || key == "Y")
+		return false
+	}
+
+	o.message = ""
+
+	switch key {
+	case "q", keyEsc:
+		return true
+	case keyUp, "k":
+		o.selected = max(o.selected-1, 0)
+	case keyDown, "j":
+		o.selected = min(o.selected+1, max(len(o.state.Instances)-1, 0))
+	case "r":
+		o.refresh()
+	case "s", "h", "d":
+		o.instanceAction(key)
+	}
+
+	return false
+}
+
+// instanceAction starts or stops the selected instance, or asks to confirm
+// the delete
+func (o *options) instanceAction(key string) {
+	if len(o.state.Instances) == 0 {
+		o.message = "no instance selected"
+		return
+	}
+
+	instance := o.state.Instances[o.selected]
+
+	var err error
+	switch key {
+	case "s":
+		err = o.Base.Client.Instance.Start(o.Base.Context, instance.ID)
+		o.message = fmt.Sprintf("starting %s (%s)", instance.Label, instance.ID)
+	case "h":
+		err = o.Base.Client.Instance.Halt(o.Base.Context, instance.ID)
+		o.message = fmt.Sprintf("stopping %s (%s)", instance.Label, instance.ID)
+	case "d":
+		o.deleting = instance.ID
+		o.message = fmt.Sprintf("delete %s (%s)? y/n", instance.Label, instance.ID)
+		return
+	}
+
+	if err != nil {
+		o.message = fmt.Sprintf("error : %v", err)
+		return
+	}
+
+	o.refresh()
+}
+
+// confirmDelete deletes the instance waiting for confirmation
+func (o *options) confirmDelete(confirmed bool) {
+	id := o.deleting
+	o.deleting = ""
+
+	if !confirmed {
+		o.message = "delete cancelled"
+		return
+	}
+
+	if err := o.Base.Client.Instance.Delete(o.Base.Context, id); err != nil {
+		o.message = fmt.Sprintf("error deleting %s : %v", id, err)
+		return
+	}
+
+	o.message = fmt.Sprintf("deleted %s", id)
+	o.refresh()
+}
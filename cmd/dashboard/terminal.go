@@ -0,0 +1,92 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// keys read from the terminal
+const (
+	keyUp   = "up"
+	keyDown = "down"
+	keyEsc  = "esc"
+)
+
+const (
+	escClear      = "\x1b[H\x1b[2J"
+	escHideCursor = "\x1b[?25l"
+	escShowCursor = "\x1b[?25h"
+)
+
+// terminal switches the controlling terminal to read single key presses
+// without echo, using stty so no terminal library is needed
+type terminal struct {
+	ctx   context.Context
+	saved string
+}
+
+func openTerminal(ctx context.Context) (*terminal, error) {
+	saved, err := stty(ctx, "-g")
+	if err != nil {
+		return nil, errors.New("dashboard requires an interactive terminal")
+	}
+
+	t := &terminal{ctx: ctx, saved: strings.TrimSpace(saved)}
+	if _, err := stty(ctx, "-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("error setting up the terminal : %v", err)
+	}
+
+	fmt.Print(escHideCursor)
+
+	return t, nil
+}
+
+// restore puts the terminal back the way it was found
+func (t *terminal) restore() {
+	fmt.Print(escClear + escShowCursor)
+	// the dashboard context may be cancelled by now
+	_, _ = stty(context.WithoutCancel(t.ctx), t.saved)
+}
+
+func stty(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// readKeys sends every key press to the channel, turning the arrow escape
+// sequences into keyUp and keyDown.  The channel is closed when the input
+// ends.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 8)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+
+		input := string(buf[:n])
+		switch {
+		case input == "\x1b[A" || input == "\x1bOA":
+			keys <- keyUp
+		case input == "\x1b[B" || input == "\x1bOB":
+			keys <- keyDown
+		case input == "\x1b":
+			keys <- keyEsc
+		case strings.HasPrefix(input, "\x1b"):
+			// other escape sequences such as function keys are ignored
+		default:
+			for _, r := range input {
+				keys <- string(r)
+			}
+		}
+	}
+}
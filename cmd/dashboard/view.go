@@ -0,0 +1,125 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// historyRows is the number of billing history items shown
+const historyRows = 5
+
+// state is what the dashboard shows, refreshed from the API
+type state struct {
+	Instances     []govultr.Instance
+	LoadBalancers []govultr.LoadBalancer
+	Databases     []govultr.Database
+	Account       *govultr.Account
+	History       []govultr.History
+	Refreshed     time.Time
+	Errors        []string
+}
+
+// fetch retrieves the first page of each resource.  Failures are shown on
+// the dashboard rather than ending it.
+func (o *options) fetch() *state {
+	s := &state{Refreshed: time.Now()}
+	opts := &govultr.ListOptions{PerPage: utils.PerPageDefault}
+
+	var err error
+	if s.Instances, _, _, err = o.Base.Client.Instance.List(o.Base.Context, opts); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("instances : %v", err))
+	}
+
+	if s.LoadBalancers, _, _, err = o.Base.Client.LoadBalancer.List(o.Base.Context, opts); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("load balancers : %v", err))
+	}
+
+	if s.Databases, _, _, err = o.Base.Client.Database.List(o.Base.Context, nil); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("databases : %v", err))
+	}
+
+	if s.Account, _, err = o.Base.Client.Account.Get(o.Base.Context); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("account : %v", err))
+	}
+
+	historyOpts := &govultr.ListOptions{PerPage: historyRows}
+	if s.History, _, _, err = o.Base.Client.Billing.ListHistory(o.Base.Context, historyOpts); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("billing history : %v", err))
+	}
+
+	return s
+}
+
+// render draws the whole screen
+func (o *options) render() string {
+	var b bytes.Buffer
+	b.WriteString(escClear)
+
+	s := o.state
+	fmt.Fprintf(&b, "VULTR DASHBOARD  refreshed %s, every %s\n", s.Refreshed.Format(time.TimeOnly), o.interval)
+	if s.Account != nil {
+		fmt.Fprintf(
+			&b,
+			"balance %.2f  pending charges %.2f  last payment %.2f on %s\n",
+			s.Account.Balance,
+			s.Account.PendingCharges,
+			s.Account.LastPaymentAmount,
+			s.Account.LastPaymentDate,
+		)
+	}
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "\nINSTANCES")
+	fmt.Fprintln(w, "\tID\tLABEL\tSTATUS\tPOWER\tREGION\tPLAN\tMAIN IP")
+	for i := range s.Instances {
+		cursor := ""
+		if i == o.selected {
+			cursor = ">"
+		}
+		in := s.Instances[i]
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			cursor, in.ID, in.Label, in.Status, in.PowerStatus, in.Region, in.Plan, in.MainIP,
+		)
+	}
+
+	fmt.Fprintln(w, "\nLOAD BALANCERS")
+	fmt.Fprintln(w, "\tID\tLABEL\tSTATUS\tREGION\tNODES\tINSTANCES")
+	for i := range s.LoadBalancers {
+		lb := s.LoadBalancers[i]
+		fmt.Fprintf(w, "\t%s\t%s\t%s\t%s\t%d\t%d\n", lb.ID, lb.Label, lb.Status, lb.Region, lb.Nodes, len(lb.Instances))
+	}
+
+	fmt.Fprintln(w, "\nDATABASES")
+	fmt.Fprintln(w, "\tID\tLABEL\tSTATUS\tENGINE\tREGION\tPLAN")
+	for i := range s.Databases {
+		db := s.Databases[i]
+		fmt.Fprintf(w, "\t%s\t%s\t%s\t%s\t%s\t%s\n", db.ID, db.Label, db.Status, db.DatabaseEngine, db.Region, db.Plan)
+	}
+
+	fmt.Fprintln(w, "\nBILLING")
+	fmt.Fprintln(w, "\tDATE\tTYPE\tDESCRIPTION\tAMOUNT")
+	for i := range s.History {
+		h := s.History[i]
+		fmt.Fprintf(w, "\t%s\t%s\t%s\t%.2f\n", h.Date, h.Type, h.Description, h.Amount)
+	}
+
+	_ = w.Flush()
+
+	b.WriteString("\nup/down select  s start  h stop  d delete  r refresh  q quit\n")
+	for i := range s.Errors {
+		fmt.Fprintf(&b, "error retrieving %s\n", s.Errors[i])
+	}
+	if o.message != "" {
+		b.WriteString(o.message + "\n")
+	}
+
+	return b.String()
+}
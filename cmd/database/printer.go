@@ -1,8 +1,10 @@
 package database
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -625,6 +627,62 @@ func (d *DBsSummaryPrinter) Paging() [][]string {
 
 // ======================================
 
+// ReadReplicasPrinter ...
+type ReadReplicasPrinter struct {
+	Replicas []govultr.Database `json:"read_replicas"`
+}
+
+// JSON ...
+func (r *ReadReplicasPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *ReadReplicasPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *ReadReplicasPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"REGION",
+		"LABEL",
+		"STATUS",
+		"HOST",
+		"PUBLIC HOST",
+	}}
+}
+
+// Data ...
+func (r *ReadReplicasPrinter) Data() [][]string {
+	if len(r.Replicas) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Replicas {
+		data = append(data, []string{
+			r.Replicas[i].ID,
+			r.Replicas[i].Region,
+			r.Replicas[i].Label,
+			r.Replicas[i].Status,
+			r.Replicas[i].Host,
+			r.Replicas[i].PublicHost,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *ReadReplicasPrinter) Paging() [][]string {
+	paging := &printer.Total{Total: len(r.Replicas)}
+	return paging.Compose()
+}
+
+// ======================================
+
 // PlansPrinter ...
 type PlansPrinter struct {
 	Plans []govultr.DatabasePlan `json:"plans"`
@@ -1232,6 +1290,99 @@ func (u *UpdatesPrinter) Paging() [][]string {
 
 // ======================================
 
+// MetricsPrinter ...
+type MetricsPrinter struct {
+	Period string                  `json:"period"`
+	Usage  *govultr.DatabaseUsage  `json:"usage"`
+	Alerts []govultr.DatabaseAlert `json:"alerts"`
+}
+
+// JSON ...
+func (m *MetricsPrinter) JSON() []byte {
+	return printer.MarshalObject(m, "json")
+}
+
+// YAML ...
+func (m *MetricsPrinter) YAML() []byte {
+	return printer.MarshalObject(m, "yaml")
+}
+
+// Columns ...
+func (m *MetricsPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (m *MetricsPrinter) Data() [][]string {
+	usage := &UsagePrinter{Usage: m.Usage}
+	alerts := &AlertsPrinter{Alerts: m.Alerts}
+
+	data := usage.Data()
+	data = append(data,
+		[]string{" "},
+		[]string{"ALERTS", m.Period},
+		[]string{"---------------------------"},
+	)
+
+	return append(data, alerts.Data()...)
+}
+
+// Paging ...
+func (m *MetricsPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// MaintenancePrinter ...
+type MaintenancePrinter struct {
+	MaintenanceDOW  string   `json:"maintenance_dow"`
+	MaintenanceTime string   `json:"maintenance_time"`
+	Updates         []string `json:"available_updates"`
+}
+
+// JSON ...
+func (m *MaintenancePrinter) JSON() []byte {
+	return printer.MarshalObject(m, "json")
+}
+
+// YAML ...
+func (m *MaintenancePrinter) YAML() []byte {
+	return printer.MarshalObject(m, "yaml")
+}
+
+// Columns ...
+func (m *MaintenancePrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (m *MaintenancePrinter) Data() [][]string {
+	data := [][]string{
+		{"MAINTENANCE DOW", m.MaintenanceDOW},
+		{"MAINTENANCE TIME", m.MaintenanceTime},
+		{" "},
+		{"AVAILABLE UPDATES"},
+	}
+
+	if len(m.Updates) == 0 {
+		data = append(data, []string{"---"})
+	}
+
+	for i := range m.Updates {
+		data = append(data, []string{m.Updates[i]})
+	}
+
+	return data
+}
+
+// Paging ...
+func (m *MaintenancePrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
 // AlertsPrinter ...
 type AlertsPrinter struct {
 	Alerts []govultr.DatabaseAlert `json:"alerts"`
@@ -1636,3 +1787,49 @@ func (v *VersionsPrinter) Data() [][]string {
 func (v *VersionsPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// ConnectionStringsPrinter ...
+type ConnectionStringsPrinter struct {
+	Format      string             `json:"format"`
+	Connections []connectionString `json:"connections"`
+}
+
+// JSON ...
+func (c *ConnectionStringsPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *ConnectionStringsPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *ConnectionStringsPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (c *ConnectionStringsPrinter) Data() [][]string {
+	var data [][]string
+	for i := range c.Connections {
+		if len(c.Connections) > 1 {
+			data = append(data, []string{
+				fmt.Sprintf("# %s %s (%s)", c.Connections[i].Role, c.Connections[i].Label, c.Connections[i].ID),
+			})
+		}
+
+		for _, line := range strings.Split(c.Connections[i].Connection, "\n") {
+			data = append(data, []string{line})
+		}
+	}
+
+	return data
+}
+
+// Paging ...
+func (c *ConnectionStringsPrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,262 @@
+package deploy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/reservedip"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	defaultHealthTimeout = 10 * time.Minute
+
+	// healthyChecks is how many health checks in a row must pass before the
+	// new instance gets the reserved IP
+	healthyChecks = 3
+
+	// healthRequestTimeout is how long a single health check may take
+	healthRequestTimeout = 5 * time.Second
+)
+
+// blueGreenConfig is the deployment requested with the flags
+type blueGreenConfig struct {
+	ReservedIP    string
+	Snapshot      string
+	Plan          string
+	Label         string
+	HealthURL     *url.URL
+	HealthTimeout time.Duration
+	Timeout       time.Duration
+	DestroyOld    bool
+	Yes           bool
+}
+
+// blueGreenResult is the outcome of a blue/green deployment
+type blueGreenResult struct {
+	ReservedIP   string `json:"reserved_ip"`
+	IP           string `json:"ip"`
+	OldInstance  string `json:"old_instance"`
+	NewInstance  string `json:"new_instance"`
+	OldDestroyed bool   `json:"old_destroyed"`
+}
+
+// parseHealthURL checks the health URL can be requested from an instance
+func parseHealthURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health URL : %v", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid health URL %q, it must be a full http or https URL", raw)
+	}
+
+	return u, nil
+}
+
+// blueGreen creates the new instance, waits for it to pass the health check
+// and moves the reserved IP to it.  Nothing is changed on the old instance
+// until the new one is healthy
+func (o *options) blueGreen(c *blueGreenConfig) (*blueGreenResult, error) {
+	rip, _, err := o.Base.Client.ReservedIP.Get(o.Base.Context, c.ReservedIP)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving reserved IP : %v", err)
+	}
+
+	var old *govultr.Instance
+	if rip.InstanceID != "" {
+		if old, _, err = o.Base.Client.Instance.Get(o.Base.Context, rip.InstanceID); err != nil {
+			return nil, fmt.Errorf("error retrieving instance %s : %v", rip.InstanceID, err)
+		}
+	}
+
+	req, err := o.newInstanceReq(c, rip, old)
+	if err != nil {
+		return nil, err
+	}
+
+	action := fmt.Sprintf("Create a %s instance in %s", req.Plan, req.Region)
+	ok, err := utils.ConfirmPrice(c.Yes, action, func() (float64, error) {
+		return utils.PlanPrice(o.Base, req.Plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("deploy was cancelled")
+	}
+
+	created, _, err := o.Base.Client.Instance.Create(o.Base.Context, req)
+	if err != nil {
+		return nil, fmt.Errorf("error creating instance : %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "created instance %s\n", created.ID)
+
+	instance, err := o.waitReady(created.ID, c.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%v, the new instance %s was kept", err, created.ID)
+	}
+
+	if err := o.waitHealthy(c, instance); err != nil {
+		return nil, fmt.Errorf("%v, the reserved IP was left alone and the new instance %s was kept", err, instance.ID)
+	}
+
+	if _, err := reservedip.Move(o.Base, rip.ID, instance.ID, true, c.Timeout); err != nil {
+		return nil, fmt.Errorf("error moving reserved IP to the new instance %s : %v", instance.ID, err)
+	}
+
+	result := &blueGreenResult{ReservedIP: rip.ID, IP: rip.Subnet, NewInstance: instance.ID}
+	if old == nil {
+		return result, nil
+	}
+	result.OldInstance = old.ID
+
+	if c.DestroyOld {
+		if err := o.Base.Client.Instance.Delete(o.Base.Context, old.ID); err != nil {
+			return nil, fmt.Errorf("the reserved IP moved to %s but the old instance %s couldn't be destroyed : %v",
+				instance.ID, old.ID, err)
+		}
+		result.OldDestroyed = true
+	}
+
+	return result, nil
+}
+
+// newInstanceReq builds the request for the new instance, which copies the
+// settings of the old instance when the reserved IP is attached to one
+func (o *options) newInstanceReq(c *blueGreenConfig, rip *govultr.ReservedIP, old *govultr.Instance) (
+	*govultr.InstanceCreateReq,
+	error,
+) {
+	req := &govultr.InstanceCreateReq{
+		Region:     rip.Region,
+		Plan:       c.Plan,
+		SnapshotID: c.Snapshot,
+		Label:      c.Label,
+		Tags:       []string{},
+	}
+
+	if old != nil {
+		if req.Plan == "" {
+			req.Plan = old.Plan
+		}
+		if req.Label == "" {
+			req.Label = old.Label
+		}
+		req.Hostname = old.Hostname
+		req.Tags = old.Tags
+		req.FirewallGroupID = old.FirewallGroupID
+
+		if old.V6MainIP != "" {
+			req.EnableIPv6 = govultr.BoolToBoolPtr(true)
+		}
+		if slices.Contains(old.Features, "auto_backups") {
+			req.Backups = "enabled"
+		}
+		if slices.Contains(old.Features, "ddos_protection") {
+			req.DDOSProtection = govultr.BoolToBoolPtr(true)
+		}
+
+		vpcs, _, _, err := o.Base.Client.Instance.ListVPCInfo(o.Base.Context, old.ID, &govultr.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving the VPCs of instance %s : %v", old.ID, err)
+		}
+		for i := range vpcs {
+			req.AttachVPC = append(req.AttachVPC, vpcs[i].ID)
+		}
+	}
+
+	if req.Plan == "" {
+		return nil, errors.New("the reserved IP isn't attached to an instance, please provide a plan")
+	}
+
+	return req, nil
+}
+
+// waitReady polls the new instance until it is active and its server status
+// is ok
+func (o *options) waitReady(id string, timeout time.Duration) (*govultr.Instance, error) {
+	var instance *govultr.Instance
+	err := o.Base.Wait("instance "+id, timeout, func() (string, bool, error) {
+		var err error
+		if instance, _, err = o.Base.Client.Instance.Get(o.Base.Context, id); err != nil {
+			return "", false, err
+		}
+
+		status := fmt.Sprintf("%s, %s, %s", instance.Status, instance.PowerStatus, instance.ServerStatus)
+		return status, instance.Status == "active" && instance.ServerStatus == "ok", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// waitHealthy requests the health URL from the instance until it passes
+// enough times in a row
+func (o *options) waitHealthy(c *blueGreenConfig, instance *govultr.Instance) error {
+	passed := 0
+	return o.Base.Wait("health check of instance "+instance.ID, c.HealthTimeout, func() (string, bool, error) {
+		status := probeURL(o.Base.Context, c.HealthURL, instance.MainIP)
+		if status != "ok" {
+			passed = 0
+			return status, false, nil
+		}
+
+		passed++
+		return fmt.Sprintf("ok %d of %d", passed, healthyChecks), passed >= healthyChecks, nil
+	})
+}
+
+// probeURL requests the URL from the IP rather than the host of the URL and
+// returns "ok" or the reason the request failed.  The host is still sent in
+// the Host header and used to verify the certificate, since the application
+// may only answer to its own name
+func probeURL(ctx context.Context, target *url.URL, ip string) string {
+	ctx, cancel := context.WithTimeout(ctx, healthRequestTimeout)
+	defer cancel()
+
+	u := *target
+	u.Host = ip
+	if port := target.Port(); port != "" {
+		u.Host = net.JoinHostPort(ip, port)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err.Error()
+	}
+	req.Host = target.Host
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{ServerName: target.Hostname(), MinVersion: tls.VersionTLS12},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err.Error()
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.Status
+	}
+
+	return "ok"
+}
@@ -0,0 +1,177 @@
+// Package deploy provides the functionality for deploying new versions of
+// instances with the CLI
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+var (
+	long    = `Commands to deploy new versions of instances`
+	example = `
+	# Full example
+	vultr-cli deploy blue-green --reserved-ip="6a31648d-ebfa-4d43-9a00-9c9f0e5048f5" \
+		--new-snapshot="704ac064-4ff2-49ca-a6e6-88262cca8f8a" --health-url="http://app.example.com/healthz"
+	`
+	blueGreenLong = `Replace the instance behind a reserved IP with a new instance created from a snapshot,
+without downtime.
+
+The new instance is created from --new-snapshot in the region of the reserved IP and copies the
+plan, label, hostname, tags, firewall group, VPCs, IPv6 and backups of the instance the reserved
+IP is attached to.  Once it is running, --health-url is requested from the main IP of the new
+instance until it answers with a success status three times in a row.  The host of the URL is
+still sent as the Host header and used for TLS, so it can be the name the application is served
+under.  The reserved IP is then moved to the new instance, and with --destroy-old the old
+instance is destroyed.
+
+When the new instance doesn't become healthy the reserved IP stays where it is and the new
+instance is kept so it can be inspected.`
+	blueGreenExample = `
+	# Full example
+	vultr-cli deploy blue-green --reserved-ip="6a31648d-ebfa-4d43-9a00-9c9f0e5048f5" \
+		--new-snapshot="704ac064-4ff2-49ca-a6e6-88262cca8f8a" --plan="vc2-2c-4gb" \
+		--health-url="https://app.example.com/healthz" --destroy-old
+
+	# Shortened with alias commands
+	vultr-cli deploy bg --reserved-ip="6a31648d-ebfa-4d43-9a00-9c9f0e5048f5" \
+		--new-snapshot="704ac064-4ff2-49ca-a6e6-88262cca8f8a" --health-url="http://app.example.com:8080/"
+	`
+)
+
+// NewCmdDeploy provides the CLI command for deployments
+func NewCmdDeploy(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "deploy",
+		Short:   "Commands to deploy new versions of instances",
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+	}
+
+	// Blue Green
+	blueGreen := &cobra.Command{
+		Use:     "blue-green",
+		Short:   "Move a reserved IP to a new instance once it is healthy",
+		Aliases: []string{"bg"},
+		Long:    blueGreenLong,
+		Example: blueGreenExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rip, errRi := cmd.Flags().GetString("reserved-ip")
+			if errRi != nil {
+				return fmt.Errorf("error parsing flag 'reserved-ip' for deploy blue-green : %v", errRi)
+			}
+
+			snapshot, errSn := cmd.Flags().GetString("new-snapshot")
+			if errSn != nil {
+				return fmt.Errorf("error parsing flag 'new-snapshot' for deploy blue-green : %v", errSn)
+			}
+
+			plan, errPl := cmd.Flags().GetString("plan")
+			if errPl != nil {
+				return fmt.Errorf("error parsing flag 'plan' for deploy blue-green : %v", errPl)
+			}
+
+			label, errLa := cmd.Flags().GetString("label")
+			if errLa != nil {
+				return fmt.Errorf("error parsing flag 'label' for deploy blue-green : %v", errLa)
+			}
+
+			healthURL, errHu := cmd.Flags().GetString("health-url")
+			if errHu != nil {
+				return fmt.Errorf("error parsing flag 'health-url' for deploy blue-green : %v", errHu)
+			}
+
+			healthTimeout, errHt := cmd.Flags().GetDuration("health-timeout")
+			if errHt != nil {
+				return fmt.Errorf("error parsing flag 'health-timeout' for deploy blue-green : %v", errHt)
+			}
+
+			timeout, errTi := utils.GetWaitTimeout(cmd)
+			if errTi != nil {
+				return errTi
+			}
+
+			destroyOld, errDo := cmd.Flags().GetBool("destroy-old")
+			if errDo != nil {
+				return fmt.Errorf("error parsing flag 'destroy-old' for deploy blue-green : %v", errDo)
+			}
+
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing flag 'yes' for deploy blue-green : %v", errYs)
+			}
+
+			health, err := parseHealthURL(healthURL)
+			if err != nil {
+				return err
+			}
+
+			result, err := o.blueGreen(&blueGreenConfig{
+				ReservedIP:    rip,
+				Snapshot:      snapshot,
+				Plan:          plan,
+				Label:         label,
+				HealthURL:     health,
+				HealthTimeout: healthTimeout,
+				Timeout:       timeout,
+				DestroyOld:    destroyOld,
+				Yes:           yes,
+			})
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&BlueGreenPrinter{Result: result}, nil)
+
+			return nil
+		},
+	}
+
+	blueGreen.Flags().String("reserved-ip", "", "ID of the reserved IP to move to the new instance")
+	blueGreen.Flags().String("new-snapshot", "", "ID of the snapshot to create the new instance from")
+	blueGreen.Flags().String("health-url", "", "URL which must answer with a success status on the new instance")
+	for _, f := range []string{"reserved-ip", "new-snapshot", "health-url"} {
+		if err := blueGreen.MarkFlagRequired(f); err != nil {
+			fmt.Printf("error marking deploy blue-green '%s' flag required: %v", f, err)
+			os.Exit(1)
+		}
+	}
+	blueGreen.Flags().String("plan", "", "(optional) plan of the new instance, instead of that of the old instance")
+	blueGreen.Flags().String("label", "", "(optional) label of the new instance, instead of that of the old instance")
+	blueGreen.Flags().Duration(
+		"health-timeout",
+		defaultHealthTimeout,
+		"(optional) how long to wait for the new instance to become healthy",
+	)
+	utils.AddWaitTimeoutFlag(
+		blueGreen,
+		cli.WaitTimeout,
+		"(optional) how long to wait for the new instance to start and the reserved IP to move",
+	)
+	blueGreen.Flags().Bool("destroy-old", false, "(optional) destroy the old instance once the reserved IP has moved")
+	utils.AddYesFlag(blueGreen)
+
+	cmd.AddCommand(
+		blueGreen,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
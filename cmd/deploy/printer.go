@@ -0,0 +1,54 @@
+package deploy
+
+import (
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// BlueGreenPrinter ...
+type BlueGreenPrinter struct {
+	Result *blueGreenResult `json:"deploy"`
+}
+
+// JSON ...
+func (b *BlueGreenPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BlueGreenPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BlueGreenPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"RESERVED IP",
+		"IP",
+		"OLD INSTANCE",
+		"NEW INSTANCE",
+		"OLD DESTROYED",
+	}}
+}
+
+// Data ...
+func (b *BlueGreenPrinter) Data() [][]string {
+	old := b.Result.OldInstance
+	if old == "" {
+		old = "---"
+	}
+
+	return [][]string{0: {
+		b.Result.ReservedIP,
+		b.Result.IP,
+		old,
+		b.Result.NewInstance,
+		strconv.FormatBool(b.Result.OldDestroyed),
+	}}
+}
+
+// Paging ...
+func (b *BlueGreenPrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,187 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	checkMatch    = "match"
+	checkMismatch = "mismatch"
+	checkError    = "error"
+
+	checkQueryTimeout = 5 * time.Second
+)
+
+// checkNameservers are the Vultr nameservers followed by the public
+// resolvers queried by dns check
+var checkNameservers = []string{"ns1.vultr.com", "ns2.vultr.com", "1.1.1.1", "8.8.8.8", "9.9.9.9"}
+
+// checkTypes are the record types which can be checked
+var checkTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "TXT"}
+
+// checkResult is the answer of a single nameserver for the checked record
+type checkResult struct {
+	Nameserver string   `json:"nameserver"`
+	Answers    []string `json:"answers"`
+	Status     string   `json:"status"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// checkRecord queries every nameserver for the record and reports whether
+// each of them serves all of the expected values
+func checkRecord(ctx context.Context, nameservers []string, fqdn, rType string, expect []string) []checkResult {
+	want := make([]string, len(expect))
+	for i := range expect {
+		want[i] = normalizeAnswer(rType, expect[i])
+	}
+
+	results := make([]checkResult, len(nameservers))
+	for i := range nameservers {
+		results[i].Nameserver = nameservers[i]
+
+		answers, err := lookupRecord(ctx, nameservers[i], fqdn, rType)
+		if err != nil {
+			results[i].Answers = []string{}
+			results[i].Status = checkError
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].Answers = answers
+		results[i].Status = checkMatch
+		for j := range want {
+			if !slices.Contains(answers, want[j]) {
+				results[i].Status = checkMismatch
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// checkMatched returns the number of nameservers serving the expected values
+func checkMatched(results []checkResult) int {
+	n := 0
+	for i := range results {
+		if results[i].Status == checkMatch {
+			n++
+		}
+	}
+	return n
+}
+
+// lookupRecord asks the nameserver for the record, without falling back to
+// the resolvers of this machine.  The answers are normalized so they can be
+// compared with the record data of the API
+func lookupRecord(ctx context.Context, nameserver, fqdn, rType string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkQueryTimeout)
+	defer cancel()
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+
+	answers, err := lookupAnswers(ctx, resolver, fqdn+".", rType)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	for i := range answers {
+		answers[i] = normalizeAnswer(rType, answers[i])
+	}
+	slices.Sort(answers)
+
+	return answers, nil
+}
+
+// lookupAnswers returns the values of the record of the type
+func lookupAnswers(ctx context.Context, resolver *net.Resolver, fqdn, rType string) ([]string, error) {
+	var answers []string
+	switch rType {
+	case "A", "AAAA":
+		network := "ip4"
+		if rType == "AAAA" {
+			network = "ip6"
+		}
+
+		ips, err := resolver.LookupIP(ctx, network, fqdn)
+		for i := range ips {
+			answers = append(answers, ips[i].String())
+		}
+		return answers, err
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		return []string{cname}, err
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, fqdn)
+		for i := range mxs {
+			answers = append(answers, mxs[i].Host)
+		}
+		return answers, err
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, fqdn)
+		for i := range nss {
+			answers = append(answers, nss[i].Host)
+		}
+		return answers, err
+	case "TXT":
+		return resolver.LookupTXT(ctx, fqdn)
+	default:
+		return nil, fmt.Errorf("record type %s is not supported", rType)
+	}
+}
+
+// lookupError shortens the resolver errors to the reason, since the record
+// and nameserver are already shown next to it
+func lookupError(err error) error {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		return err
+	}
+
+	switch {
+	case dnsErr.IsNotFound:
+		return errors.New("no such record")
+	case dnsErr.IsTimeout:
+		return errors.New("timed out")
+	default:
+		return errors.New(dnsErr.Err)
+	}
+}
+
+// normalizeAnswer makes an answer comparable with the record data of the
+// API, which has no trailing dots on host names and quotes TXT values
+func normalizeAnswer(rType, answer string) string {
+	switch rType {
+	case "TXT":
+		return unquote(answer)
+	case "A", "AAAA":
+		if ip := net.ParseIP(answer); ip != nil {
+			return ip.String()
+		}
+		return answer
+	default:
+		return strings.ToLower(strings.TrimSuffix(answer, "."))
+	}
+}
+
+// checkFQDN returns the FQDN of the record name in the domain, where an empty
+// name or @ is the zone apex
+func checkFQDN(domain, name string) string {
+	domain = strings.TrimSuffix(domain, ".")
+	if name == "" || name == "@" {
+		return domain
+	}
+	return name + "." + domain
+}
@@ -2,9 +2,15 @@
 package dns
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -22,6 +28,165 @@ var (
 
 	domainLong    = ``
 	domainExample = ``
+
+	importLong = `Import the records of a standard BIND zone file into a domain.  The domain
+is created if it does not exist yet.  SOA records and NS records on the zone
+apex are skipped since they are managed by Vultr.  Each record is created
+individually and the result of every record is reported.
+`
+	importExample = `
+	# Full example
+	vultr-cli dns domain import --domain example.com --file example.com.zone
+
+	# Read the zone file from stdin
+	cat example.com.zone | vultr-cli dns domain import --domain example.com --file -
+`
+
+	exportLong = `Export all records of a domain in a portable format.  The bind format writes a
+standard zone file which can be loaded with 'dns domain import'.  The json and
+yaml formats write the record set as used by 'dns record apply'.
+`
+	exportExample = `
+	# Full example
+	vultr-cli dns domain export example.com --format bind > example.com.zone
+
+	# Export to YAML
+	vultr-cli dns domain export example.com --format yaml
+`
+
+	upsertLong = `Create a DNS record or update it when a record with the same name and type
+already exists.  Running the command again with the same values leaves the
+record unchanged which makes it safe to use in dynamic DNS and deploy scripts.
+Use @ or an empty name for the zone apex.
+`
+	upsertExample = `
+	# Full example
+	vultr-cli dns record upsert example.com --name www --type A --data 192.0.2.10 --ttl 300
+
+	# Point the zone apex at a new address
+	vultr-cli dns record upsert example.com --name @ --type A --data 192.0.2.10
+`
+
+	setTTLLong = `Set the TTL of every record of a domain matching the type and name in one
+pass, such as to lower the TTLs before a migration and raise them again once
+it is done.  Without --type and --name all records of the domain are updated.
+Records which already have the TTL are left alone.
+`
+	setTTLExample = `
+	# Lower the TTL of all A records before a migration
+	vultr-cli dns record set-ttl example.com --type A --ttl 300
+
+	# Only the records on the zone apex
+	vultr-cli dns record set-ttl example.com --name @ --ttl 300
+
+	# Show the records which would change without updating them
+	vultr-cli dns record set-ttl example.com --type A --ttl 300 --dry-run
+`
+
+	checkLong = `Check whether a record of the domain has propagated by querying the Vultr
+nameservers and the Cloudflare, Google and Quad9 public resolvers directly.
+Every nameserver must serve all of the expected values.  Without --expect the
+values of the matching records in Vultr DNS are expected.  The command exits
+with an error until the record has propagated, or waits for it with --wait.
+`
+	checkExample = `
+	# Check that www.example.com points at the new address
+	vultr-cli dns check example.com --record www --expect 192.0.2.10
+
+	# Wait until the MX records of the zone apex are served everywhere
+	vultr-cli dns check example.com --type MX --wait
+
+	# Only query the Vultr nameservers
+	vultr-cli dns check example.com --record www --nameserver ns1.vultr.com,ns2.vultr.com
+`
+
+	dnssecLong = `Manage DNSSEC for a domain.  Once DNSSEC is enabled the DS records shown by
+'dns dnssec info' must be added at the registrar of the domain.
+`
+	dnssecExample = `
+	# Enable DNSSEC and show the DS records for the registrar
+	vultr-cli dns dnssec enable example.com
+
+	# Show the DS records
+	vultr-cli dns dnssec info example.com
+
+	# Disable DNSSEC
+	vultr-cli dns dnssec disable example.com
+`
+
+	ddnsLong = `Run a dynamic DNS updater for a record of the domain.  The public IP address of
+this machine is looked up every interval and the A record (and optionally the
+AAAA record) is created or updated whenever the address changes.  The updater
+runs until it is interrupted, or only once with --once for use from cron.
+`
+	ddnsExample = `
+	# Keep home.example.com pointed at this machine
+	vultr-cli dns ddns example.com --record home --interval 5m
+
+	# Update both the A and AAAA records once and exit
+	vultr-cli dns ddns example.com --record home --ipv6 --once
+`
+
+	acmeLong = `Create and remove the _acme-challenge TXT records used by ACME DNS-01
+validation, allowing Vultr DNS to be used for wildcard certificates.
+
+The commands follow the certbot manual hook contract, reading CERTBOT_DOMAIN
+and CERTBOT_VALIDATION from the environment, and the lego exec provider
+contract, taking the record FQDN and value as arguments.  The Vultr DNS zone
+is found automatically from the domain.  'present' waits until the record is
+served by the Vultr nameservers before returning.
+`
+	acmeExample = `
+	# Create and remove a challenge record
+	vultr-cli dns acme present --domain example.com --token <validation>
+	vultr-cli dns acme cleanup --domain example.com --token <validation>
+
+	# certbot manual hooks
+	certbot certonly --manual --preferred-challenges dns -d '*.example.com' \
+	  --manual-auth-hook 'vultr-cli dns acme present' \
+	  --manual-cleanup-hook 'vultr-cli dns acme cleanup'
+
+	# lego exec provider
+	EXEC_PATH=/usr/local/bin/vultr-acme.sh lego --dns exec -d '*.example.com' run
+	# where vultr-acme.sh runs: vultr-cli dns acme "$1" "$2" "$3"
+`
+
+	applyLong = `Converge the records of a domain to the record set in a YAML or JSON file.
+Records missing from the domain are created, records which differ are updated
+and records which are not in the file are deleted once the creates and updates
+are done.  NS records on the zone apex are left alone unless the file contains
+apex NS records.  The file uses the format written by
+'dns domain export --format yaml'.
+`
+	applyExample = `
+	# Full example
+	vultr-cli dns record apply example.com -f records.yaml
+
+	# Show the planned changes without applying them
+	vultr-cli dns record apply example.com -f records.yaml --dry-run
+
+	# Example file
+	records:
+	  - name: "@"
+	    type: A
+	    data: 192.0.2.10
+	    ttl: 300
+	  - name: www
+	    type: CNAME
+	    data: example.com
+	  - name: ""
+	    type: MX
+	    data: mail.example.com
+	    priority: 10
+`
+)
+
+const (
+	ddnsTTL = 300
+
+	acmeTTL                = 120
+	acmePropagationTimeout = 5 * time.Minute
+	acmePollInterval       = 10 * time.Second
 )
 
 // NewCmdDNS provides the CLI command functionality for DNS
@@ -56,7 +221,7 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			dms, meta, err := o.domainList()
+			dms, meta, err := utils.ListAll(cmd, o.Base.Options, o.domainList)
 			if err != nil {
 				return fmt.Errorf("error retrieving domain list : %v", err)
 			}
@@ -78,6 +243,7 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(domainList)
 
 	// Domain Get
 	domainGet := &cobra.Command{
@@ -301,6 +467,94 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	domainSOAUpdate.Flags().StringP("ns-primary", "n", "", "primary nameserver to store in the SOA record")
 	domainSOAUpdate.Flags().StringP("email", "e", "", "administrative email to store in the SOA record")
 
+	// Domain Import
+	domainImport := &cobra.Command{
+		Use:     "import",
+		Short:   "Import records from a BIND zone file",
+		Long:    importLong,
+		Example: importExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, errDo := cmd.Flags().GetString("domain")
+			if errDo != nil {
+				return fmt.Errorf("error parsing 'domain' flag for domain import : %v", errDo)
+			}
+
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing 'file' flag for domain import : %v", errFi)
+			}
+
+			recs, err := readZoneFile(file, domain)
+			if err != nil {
+				return err
+			}
+
+			results, err := o.domainImport(domain, recs)
+			if err != nil {
+				return fmt.Errorf("error importing dns domain : %v", err)
+			}
+
+			data := &DNSImportPrinter{Domain: domain, Results: results}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d records failed to import", failed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	domainImport.Flags().StringP("domain", "d", "", "name of the domain to import the records into")
+	if err := domainImport.MarkFlagRequired("domain"); err != nil {
+		fmt.Printf("error marking domain import 'domain' flag required: %v", err)
+		os.Exit(1)
+	}
+	domainImport.Flags().StringP("file", "f", "", "path to the BIND zone file or - to read from stdin")
+	if err := domainImport.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking domain import 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	// Domain Export
+	domainExport := &cobra.Command{
+		Use:     "export <Domain Name>",
+		Short:   "Export the records of a domain",
+		Long:    exportLong,
+		Example: exportExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, errFo := cmd.Flags().GetString("format")
+			if errFo != nil {
+				return fmt.Errorf("error parsing 'format' flag for domain export : %v", errFo)
+			}
+
+			if format != "bind" && format != "json" && format != "yaml" {
+				return fmt.Errorf("invalid format %q, must be one of bind, json or yaml", format)
+			}
+
+			recs, err := o.recordListAll(args[0])
+			if err != nil {
+				return fmt.Errorf("error retrieving domain records : %v", err)
+			}
+
+			if format == "bind" {
+				return writeZoneFile(os.Stdout, args[0], recs)
+			}
+
+			fmt.Println(string(printer.MarshalObject(newZoneSpec(args[0], recs), format)))
+
+			return nil
+		},
+	}
+
+	domainExport.Flags().String("format", "bind", "output format of the export. One of bind, json or yaml")
+
 	domain.AddCommand(
 		domainList,
 		domainGet,
@@ -310,6 +564,320 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		domainDNSSECInfo,
 		domainSOAInfo,
 		domainSOAUpdate,
+		domainImport,
+		domainExport,
+	)
+
+	// DNSSEC
+	dnssec := &cobra.Command{
+		Use:     "dnssec",
+		Short:   "Commands to manage DNSSEC",
+		Long:    dnssecLong,
+		Example: dnssecExample,
+	}
+
+	// DNSSEC Enable
+	dnssecEnable := &cobra.Command{
+		Use:   "enable <Domain Name>",
+		Short: "Enable DNSSEC and show the DS records",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.DomainDNSSECEnabled = "enabled"
+			if err := o.domainUpdate(); err != nil {
+				return fmt.Errorf("error enabling dnssec : %v", err)
+			}
+
+			info, err := o.domainDNSSECGet()
+			if err != nil {
+				return fmt.Errorf("error getting domain dnssec info : %v", err)
+			}
+
+			data := newDNSSECRecordsPrinter(args[0], info)
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	// DNSSEC Disable
+	dnssecDisable := &cobra.Command{
+		Use:   "disable <Domain Name>",
+		Short: "Disable DNSSEC",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.DomainDNSSECEnabled = "disabled"
+			if err := o.domainUpdate(); err != nil {
+				return fmt.Errorf("error disabling dnssec : %v", err)
+			}
+
+			o.Base.Printer.Display(
+				printer.Info("dns domain DNSSEC has been disabled, remove the DS records at the registrar"),
+				nil,
+			)
+
+			return nil
+		},
+	}
+
+	// DNSSEC Info
+	dnssecInfo := &cobra.Command{
+		Use:   "info <Domain Name>",
+		Short: "Show the DS records to add at the registrar",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dm, err := o.domainGet()
+			if err != nil {
+				return fmt.Errorf("error retrieving domain : %v", err)
+			}
+
+			if dm.DNSSec != "enabled" {
+				o.Base.Printer.Display(printer.Info("DNSSEC is not enabled for this domain"), nil)
+				return nil
+			}
+
+			info, err := o.domainDNSSECGet()
+			if err != nil {
+				return fmt.Errorf("error getting domain dnssec info : %v", err)
+			}
+
+			data := newDNSSECRecordsPrinter(args[0], info)
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	dnssec.AddCommand(
+		dnssecEnable,
+		dnssecDisable,
+		dnssecInfo,
+	)
+
+	// DDNS
+	ddns := &cobra.Command{
+		Use:     "ddns <Domain Name>",
+		Short:   "Keep a DNS record updated with this machine's public IP",
+		Long:    ddnsLong,
+		Example: ddnsExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, errNa := cmd.Flags().GetString("record")
+			if errNa != nil {
+				return fmt.Errorf("error parsing 'record' flag for ddns : %v", errNa)
+			}
+
+			interval, errIn := cmd.Flags().GetDuration("interval")
+			if errIn != nil {
+				return fmt.Errorf("error parsing 'interval' flag for ddns : %v", errIn)
+			}
+
+			ttl, errTt := cmd.Flags().GetInt("ttl")
+			if errTt != nil {
+				return fmt.Errorf("error parsing 'ttl' flag for ddns : %v", errTt)
+			}
+
+			ipv4, errV4 := cmd.Flags().GetBool("ipv4")
+			if errV4 != nil {
+				return fmt.Errorf("error parsing 'ipv4' flag for ddns : %v", errV4)
+			}
+
+			ipv6, errV6 := cmd.Flags().GetBool("ipv6")
+			if errV6 != nil {
+				return fmt.Errorf("error parsing 'ipv6' flag for ddns : %v", errV6)
+			}
+
+			ipURL, errIU := cmd.Flags().GetString("ip-url")
+			if errIU != nil {
+				return fmt.Errorf("error parsing 'ip-url' flag for ddns : %v", errIU)
+			}
+
+			ip6URL, errI6 := cmd.Flags().GetString("ip6-url")
+			if errI6 != nil {
+				return fmt.Errorf("error parsing 'ip6-url' flag for ddns : %v", errI6)
+			}
+
+			once, errOn := cmd.Flags().GetBool("once")
+			if errOn != nil {
+				return fmt.Errorf("error parsing 'once' flag for ddns : %v", errOn)
+			}
+
+			if !ipv4 && !ipv6 {
+				return errors.New("at least one of --ipv4 or --ipv6 must be enabled")
+			}
+
+			if interval < time.Minute {
+				return errors.New("interval must be at least 1m")
+			}
+
+			if name == "@" {
+				name = ""
+			}
+
+			var targets []ddnsTarget
+			if ipv4 {
+				targets = append(targets, ddnsTarget{Name: name, Type: "A", TTL: ttl, URL: ipURL})
+			}
+			if ipv6 {
+				targets = append(targets, ddnsTarget{Name: name, Type: "AAAA", TTL: ttl, URL: ip6URL})
+			}
+
+			return o.ddns(targets, interval, once)
+		},
+	}
+
+	ddns.Flags().StringP("record", "r", "", "name of the record to update, use @ for the zone apex")
+	if err := ddns.MarkFlagRequired("record"); err != nil {
+		fmt.Printf("error marking dns ddns 'record' flag required: %v", err)
+		os.Exit(1)
+	}
+	ddns.Flags().DurationP("interval", "i", 5*time.Minute, "how often to check the public IP address") //nolint:mnd
+	ddns.Flags().IntP("ttl", "l", ddnsTTL, "ttl for the record")
+	ddns.Flags().Bool("ipv4", true, "update the A record with the public IPv4 address")
+	ddns.Flags().Bool("ipv6", false, "update the AAAA record with the public IPv6 address")
+	ddns.Flags().String("ip-url", utils.PublicIPv4URL, "URL which returns the public IPv4 address as plain text")
+	ddns.Flags().String("ip6-url", utils.PublicIPv6URL, "URL which returns the public IPv6 address as plain text")
+	ddns.Flags().Bool("once", false, "update the records once and exit")
+
+	// ACME
+	acme := &cobra.Command{
+		Use:     "acme",
+		Short:   "ACME DNS-01 challenge hooks",
+		Long:    acmeLong,
+		Example: acmeExample,
+	}
+
+	acmeArgs := func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return errors.New("please provide either no arguments or the record FQDN and value")
+		}
+		return nil
+	}
+
+	// acmeChallenge resolves the challenge record FQDN and value from the
+	// arguments, flags or certbot environment
+	acmeChallenge := func(cmd *cobra.Command, args []string) (string, string, error) {
+		if len(args) == 2 {
+			return strings.TrimSuffix(args[0], "."), args[1], nil
+		}
+
+		domain, errDo := cmd.Flags().GetString("domain")
+		if errDo != nil {
+			return "", "", fmt.Errorf("error parsing 'domain' flag for acme : %v", errDo)
+		}
+
+		token, errTo := cmd.Flags().GetString("token")
+		if errTo != nil {
+			return "", "", fmt.Errorf("error parsing 'token' flag for acme : %v", errTo)
+		}
+
+		if domain == "" {
+			domain = os.Getenv("CERTBOT_DOMAIN")
+		}
+
+		if token == "" {
+			token = os.Getenv("CERTBOT_VALIDATION")
+		}
+
+		if domain == "" || token == "" {
+			return "", "", errors.New("please provide --domain and --token or set CERTBOT_DOMAIN and CERTBOT_VALIDATION")
+		}
+
+		return acmeRecordName(domain), token, nil
+	}
+
+	// ACME Present
+	acmePresent := &cobra.Command{
+		Use:   "present [<Record FQDN> <Value>]",
+		Short: "Create the challenge TXT record",
+		Args:  acmeArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fqdn, token, err := acmeChallenge(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			nameserver, errNs := cmd.Flags().GetString("nameserver")
+			if errNs != nil {
+				return fmt.Errorf("error parsing 'nameserver' flag for acme present : %v", errNs)
+			}
+
+			timeout, errTi := cmd.Flags().GetDuration("propagation-timeout")
+			if errTi != nil {
+				return fmt.Errorf("error parsing 'propagation-timeout' flag for acme present : %v", errTi)
+			}
+
+			if err := o.acmePresent(fqdn, token); err != nil {
+				return fmt.Errorf("error creating acme challenge record : %v", err)
+			}
+
+			if timeout > 0 {
+				if err := waitForTXT(o.Base.Context, nameserver, fqdn, token, timeout); err != nil {
+					return fmt.Errorf("error waiting for acme challenge record : %v", err)
+				}
+			}
+
+			o.Base.Printer.Display(printer.Info("acme challenge record has been created"), nil)
+
+			return nil
+		},
+	}
+
+	acmePresent.Flags().String("nameserver", "ns1.vultr.com", "nameserver queried to confirm the record is served")
+	acmePresent.Flags().Duration(
+		"propagation-timeout",
+		acmePropagationTimeout,
+		"how long to wait for the record to be served, 0 to skip waiting",
+	)
+
+	// ACME Cleanup
+	acmeCleanup := &cobra.Command{
+		Use:   "cleanup [<Record FQDN> <Value>]",
+		Short: "Remove the challenge TXT record",
+		Args:  acmeArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fqdn, token, err := acmeChallenge(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if err := o.acmeCleanup(fqdn, token); err != nil {
+				return fmt.Errorf("error removing acme challenge record : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info("acme challenge record has been removed"), nil)
+
+			return nil
+		},
+	}
+
+	for _, c := range []*cobra.Command{acmePresent, acmeCleanup} {
+		c.Flags().StringP("domain", "d", "", "domain being validated, defaults to CERTBOT_DOMAIN")
+		c.Flags().StringP("token", "t", "", "validation value of the challenge, defaults to CERTBOT_VALIDATION")
+	}
+
+	acme.AddCommand(
+		acmePresent,
+		acmeCleanup,
 	)
 
 	// Record
@@ -332,7 +900,7 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			recs, meta, err := o.recordList()
+			recs, meta, err := utils.ListAll(cmd, o.Base.Options, o.recordList)
 			if err != nil {
 				return fmt.Errorf("error retrieiving domain records : %v", err)
 			}
@@ -354,6 +922,7 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(recordList)
 
 	// Record Get
 	recordGet := &cobra.Command{
@@ -542,19 +1111,339 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	recordUpdate.Flags().IntP("ttl", "", 0, "time to live for the record")
 	recordUpdate.Flags().IntP("priority", "p", 0, "only required for MX and SRV")
 
+	// Record Upsert
+	recordUpsert := &cobra.Command{
+		Use:     "upsert <Domain Name>",
+		Short:   "Create or update a DNS record matched on name and type",
+		Long:    upsertLong,
+		Example: upsertExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rType, errTy := cmd.Flags().GetString("type")
+			if errTy != nil {
+				return fmt.Errorf("error parsing 'type' flag for domain record upsert : %v", errTy)
+			}
+
+			name, errNa := cmd.Flags().GetString("name")
+			if errNa != nil {
+				return fmt.Errorf("error parsing 'name' flag for domain record upsert : %v", errNa)
+			}
+
+			dt, errDa := cmd.Flags().GetString("data")
+			if errDa != nil {
+				return fmt.Errorf("error parsing 'data' flag for domain record upsert : %v", errDa)
+			}
+
+			ttl, errTt := cmd.Flags().GetInt("ttl")
+			if errTt != nil {
+				return fmt.Errorf("error parsing 'ttl' flag for domain record upsert : %v", errTt)
+			}
+
+			priority, errPr := cmd.Flags().GetInt("priority")
+			if errPr != nil {
+				return fmt.Errorf("error parsing 'priority' flag for domain record upsert : %v", errPr)
+			}
+
+			if name == "@" {
+				name = ""
+			}
+
+			o.RecordReq = &govultr.DomainRecordReq{
+				Name: name,
+				Type: strings.ToUpper(rType),
+				Data: dt,
+				TTL:  ttl,
+			}
+
+			if cmd.Flags().Changed("priority") {
+				o.RecordReq.Priority = govultr.IntToIntPtr(priority)
+			}
+
+			rec, action, err := o.recordUpsert()
+			if err != nil {
+				return fmt.Errorf("error upserting domain record : %v", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "domain record %s\n", action)
+
+			data := &DNSRecordPrinter{Record: *rec}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	recordUpsert.Flags().StringP("type", "t", "", "type for the record")
+	if err := recordUpsert.MarkFlagRequired("type"); err != nil {
+		fmt.Printf("error marking dns record upsert 'type' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	recordUpsert.Flags().StringP("name", "n", "", "name of the record, use @ for the zone apex")
+	if err := recordUpsert.MarkFlagRequired("name"); err != nil {
+		fmt.Printf("error marking dns record upsert 'name' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	recordUpsert.Flags().StringP("data", "d", "", "data for the record")
+	if err := recordUpsert.MarkFlagRequired("data"); err != nil {
+		fmt.Printf("error marking dns record upsert 'data' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	recordUpsert.Flags().IntP("ttl", "l", 0, "ttl for the record")
+	recordUpsert.Flags().IntP("priority", "p", 0, "only required for MX and SRV")
+
+	// Record Apply
+	recordApply := &cobra.Command{
+		Use:     "apply <Domain Name>",
+		Short:   "Converge the DNS records of a domain to a file",
+		Long:    applyLong,
+		Example: applyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing 'file' flag for domain record apply : %v", errFi)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing 'dry-run' flag for domain record apply : %v", errDr)
+			}
+
+			spec := &zoneSpec{}
+			if err := utils.ReadSpecFile(file, spec); err != nil {
+				return err
+			}
+
+			if spec.Domain != "" && !strings.EqualFold(strings.TrimSuffix(spec.Domain, "."), args[0]) {
+				return fmt.Errorf("file %s is for domain %s, not %s", file, spec.Domain, args[0])
+			}
+
+			changes, err := o.recordApply(spec, dryRun)
+			if err != nil {
+				return fmt.Errorf("error applying domain records : %v", err)
+			}
+
+			if len(changes) == 0 {
+				o.Base.Printer.Display(printer.Info("domain records are up to date"), nil)
+				return nil
+			}
+
+			data := &DNSChangesPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d changes failed to apply", failed, len(changes))
+			}
+
+			return nil
+		},
+	}
+
+	recordApply.Flags().StringP("file", "f", "", "path to a YAML or JSON record set file or - to read from stdin")
+	if err := recordApply.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking dns record apply 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+	recordApply.Flags().Bool("dry-run", false, "show the planned changes without applying them")
+
+	// Record Set TTL
+	recordSetTTL := &cobra.Command{
+		Use:     "set-ttl <Domain Name>",
+		Short:   "Set the TTL of the matching DNS records of a domain",
+		Long:    setTTLLong,
+		Example: setTTLExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rType, errTy := cmd.Flags().GetString("type")
+			if errTy != nil {
+				return fmt.Errorf("error parsing 'type' flag for domain record set-ttl : %v", errTy)
+			}
+
+			name, errNa := cmd.Flags().GetString("name")
+			if errNa != nil {
+				return fmt.Errorf("error parsing 'name' flag for domain record set-ttl : %v", errNa)
+			}
+
+			ttl, errTt := cmd.Flags().GetInt("ttl")
+			if errTt != nil {
+				return fmt.Errorf("error parsing 'ttl' flag for domain record set-ttl : %v", errTt)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing 'dry-run' flag for domain record set-ttl : %v", errDr)
+			}
+
+			if ttl <= 0 {
+				return errors.New("ttl must be greater than 0")
+			}
+
+			changes, err := o.recordSetTTL(rType, name, ttl, dryRun)
+			if err != nil {
+				return fmt.Errorf("error setting domain record TTLs : %v", err)
+			}
+
+			if len(changes) == 0 {
+				o.Base.Printer.Display(printer.Info("no records need updating"), nil)
+				return nil
+			}
+
+			data := &DNSChangesPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d records failed to update", failed, len(changes))
+			}
+
+			return nil
+		},
+	}
+
+	recordSetTTL.Flags().StringP("type", "t", "", "only update records of this type")
+	recordSetTTL.Flags().StringP("name", "n", "", "only update records with this name, use @ for the zone apex")
+	recordSetTTL.Flags().IntP("ttl", "l", 0, "new ttl for the records")
+	if err := recordSetTTL.MarkFlagRequired("ttl"); err != nil {
+		fmt.Printf("error marking dns record set-ttl 'ttl' flag required: %v", err)
+		os.Exit(1)
+	}
+	recordSetTTL.Flags().Bool("dry-run", false, "show the records which would change without updating them")
+
 	record.AddCommand(
 		recordList,
 		recordGet,
 		recordCreate,
 		recordUpdate,
 		recordDelete,
+		recordUpsert,
+		recordApply,
+		recordSetTTL,
+	)
+
+	// Check
+	check := &cobra.Command{
+		Use:     "check <Domain Name>",
+		Short:   "Check whether a DNS record has propagated",
+		Long:    checkLong,
+		Example: checkExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a domain name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, errRe := cmd.Flags().GetString("record")
+			if errRe != nil {
+				return fmt.Errorf("error parsing 'record' flag for dns check : %v", errRe)
+			}
+
+			rType, errTy := cmd.Flags().GetString("type")
+			if errTy != nil {
+				return fmt.Errorf("error parsing 'type' flag for dns check : %v", errTy)
+			}
+
+			expect, errEx := cmd.Flags().GetStringSlice("expect")
+			if errEx != nil {
+				return fmt.Errorf("error parsing 'expect' flag for dns check : %v", errEx)
+			}
+
+			nameservers, errNs := cmd.Flags().GetStringSlice("nameserver")
+			if errNs != nil {
+				return fmt.Errorf("error parsing 'nameserver' flag for dns check : %v", errNs)
+			}
+
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			rType = strings.ToUpper(rType)
+			if !slices.Contains(checkTypes, rType) {
+				return fmt.Errorf("record type %s is not supported, must be one of %s", rType, strings.Join(checkTypes, ", "))
+			}
+
+			if len(nameservers) == 0 {
+				return errors.New("please provide at least one nameserver")
+			}
+
+			if len(expect) == 0 {
+				var err error
+				if expect, err = o.checkExpect(name, rType); err != nil {
+					return err
+				}
+			}
+
+			data := &DNSCheckPrinter{Record: checkFQDN(args[0], name), Type: rType, Expect: expect}
+			matched := func() int {
+				data.Results = checkRecord(o.Base.Context, nameservers, data.Record, rType, expect)
+				return checkMatched(data.Results)
+			}
+
+			var errWait error
+			if wait {
+				errWait = o.Base.Wait(fmt.Sprintf("%s %s record", data.Record, rType), timeout, func() (string, bool, error) {
+					n := matched()
+					return fmt.Sprintf("%d of %d nameservers match", n, len(nameservers)), n == len(nameservers), nil
+				})
+			} else {
+				matched()
+			}
+
+			o.Base.Printer.Display(data, nil)
+
+			if errWait != nil {
+				return errWait
+			}
+
+			if n := checkMatched(data.Results); n < len(nameservers) {
+				return fmt.Errorf("%s %s record has not propagated to %d of %d nameservers",
+					data.Record, rType, len(nameservers)-n, len(nameservers))
+			}
+
+			return nil
+		},
+	}
+
+	check.Flags().StringP("record", "r", "", "name of the record, empty or @ for the zone apex")
+	check.Flags().StringP("type", "t", "A", fmt.Sprintf("type of the record, one of %s", strings.Join(checkTypes, ", ")))
+	check.Flags().StringSliceP(
+		"expect",
+		"e",
+		[]string{},
+		"values the record should have, the values in Vultr DNS when not given",
 	)
+	check.Flags().StringSlice("nameserver", checkNameservers, "nameservers and resolvers to query")
+	utils.AddWaitFlags(check, "the record has propagated to every nameserver")
 
 	cmd.AddCommand(
 		domain,
+		dnssec,
+		ddns,
+		acme,
+		check,
 		record,
 	)
 
+	utils.RegisterCompletion(cmd, "<Domain Name>", utils.CompleteDomains(o.Base))
+
 	return cmd
 }
 
@@ -611,12 +1500,67 @@ func (o *options) domainSOAUpdate() error {
 	return o.Base.Client.Domain.UpdateSoa(o.Base.Context, o.Base.Args[0], o.SOAUpdateReq)
 }
 
+// domainImport creates the domain when needed and then each of the zone
+// records, collecting the outcome of every record
+func (o *options) domainImport(domain string, recs []zoneRecord) ([]importResult, error) {
+	if _, _, err := o.Base.Client.Domain.Get(o.Base.Context, domain); err != nil {
+		if _, _, err := o.Base.Client.Domain.Create(o.Base.Context, &govultr.DomainReq{Domain: domain}); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]importResult, len(recs))
+	for i := range recs {
+		results[i] = importResult{
+			Line:     recs[i].Line,
+			Type:     recs[i].Type,
+			Name:     recs[i].Name,
+			Data:     recs[i].Data,
+			Priority: recs[i].Priority,
+			TTL:      recs[i].TTL,
+		}
+
+		if reason := skipImport(&recs[i]); reason != "" {
+			results[i].Status = statusSkipped
+			results[i].Error = reason
+			continue
+		}
+
+		rec, _, err := o.Base.Client.DomainRecord.Create(o.Base.Context, domain, &govultr.DomainRecordReq{
+			Name:     recs[i].Name,
+			Type:     recs[i].Type,
+			Data:     recs[i].Data,
+			TTL:      recs[i].TTL,
+			Priority: recs[i].Priority,
+		})
+		if err != nil {
+			results[i].Status = statusFailed
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].Status = statusCreated
+		results[i].ID = rec.ID
+	}
+
+	return results, nil
+}
+
 // recordList ...
 func (o *options) recordList() ([]govultr.DomainRecord, *govultr.Meta, error) {
 	rec, meta, _, err := o.Base.Client.DomainRecord.List(o.Base.Context, o.Base.Args[0], o.Base.Options)
 	return rec, meta, err
 }
 
+// recordListAll retrieves every record of the domain, following the paging
+// cursor until all pages are fetched
+func (o *options) recordListAll(domain string) ([]govultr.DomainRecord, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.DomainRecord, *govultr.Meta, error) {
+		recs, meta, _, err := o.Base.Client.DomainRecord.List(o.Base.Context, domain, opts)
+		return recs, meta, err
+	})
+}
+
 // recordGet ...
 func (o *options) recordGet() (*govultr.DomainRecord, error) {
 	rec, _, err := o.Base.Client.DomainRecord.Get(o.Base.Context, o.Base.Args[0], o.Base.Args[1])
@@ -634,6 +1578,329 @@ func (o *options) recordUpdate() error {
 	return o.Base.Client.DomainRecord.Update(o.Base.Context, o.Base.Args[0], o.Base.Args[1], o.RecordReq)
 }
 
+// recordUpsert creates the record in RecordReq or updates the existing record
+// with the same name and type.  The resulting record is returned along with
+// the action which was taken
+func (o *options) recordUpsert() (*govultr.DomainRecord, string, error) {
+	recs, err := o.recordListAll(o.Base.Args[0])
+	if err != nil {
+		return nil, "", err
+	}
+
+	var match *govultr.DomainRecord
+	for i := range recs {
+		if recs[i].Name != o.RecordReq.Name || !strings.EqualFold(recs[i].Type, o.RecordReq.Type) {
+			continue
+		}
+
+		if match != nil {
+			return nil, "", fmt.Errorf(
+				"multiple %s records named %q exist, use 'dns record update' with a record ID",
+				o.RecordReq.Type,
+				o.RecordReq.Name,
+			)
+		}
+		match = &recs[i]
+	}
+
+	if match == nil {
+		rec, _, err := o.Base.Client.DomainRecord.Create(o.Base.Context, o.Base.Args[0], o.RecordReq)
+		return rec, "created", err
+	}
+
+	updated := *match
+	updated.Data = o.RecordReq.Data
+	if o.RecordReq.TTL != 0 {
+		updated.TTL = o.RecordReq.TTL
+	}
+	if o.RecordReq.Priority != nil {
+		updated.Priority = *o.RecordReq.Priority
+	}
+
+	if updated == *match {
+		return match, "unchanged", nil
+	}
+
+	if err := o.Base.Client.DomainRecord.Update(o.Base.Context, o.Base.Args[0], match.ID, o.RecordReq); err != nil {
+		return nil, "", err
+	}
+
+	return &updated, "updated", nil
+}
+
+// recordApply plans the changes needed to converge the domain to the spec
+// and, unless it is a dry run, applies each of them
+func (o *options) recordApply(spec *zoneSpec, dryRun bool) ([]recordChange, error) {
+	live, err := o.recordListAll(o.Base.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	changes := planZone(spec, live)
+	if dryRun {
+		return changes, nil
+	}
+
+	for i := range changes {
+		var err error
+		switch changes[i].Action {
+		case changeCreate:
+			var rec *govultr.DomainRecord
+			rec, _, err = o.Base.Client.DomainRecord.Create(o.Base.Context, o.Base.Args[0], changes[i].req())
+			if err == nil {
+				changes[i].ID = rec.ID
+			}
+		case changeUpdate:
+			err = o.Base.Client.DomainRecord.Update(o.Base.Context, o.Base.Args[0], changes[i].ID, changes[i].req())
+		case changeDelete:
+			err = o.Base.Client.DomainRecord.Delete(o.Base.Context, o.Base.Args[0], changes[i].ID)
+		}
+
+		if err != nil {
+			changes[i].Status = statusFailed
+			changes[i].Error = err.Error()
+			continue
+		}
+
+		changes[i].Status = statusDone
+	}
+
+	return changes, nil
+}
+
+// recordSetTTL sets the TTL of the records matching the type and name and,
+// unless it is a dry run, updates each of them
+func (o *options) recordSetTTL(rType, name string, ttl int, dryRun bool) ([]recordChange, error) {
+	live, err := o.recordListAll(o.Base.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	changes := planTTL(live, rType, name, ttl)
+	if dryRun {
+		return changes, nil
+	}
+
+	for i := range changes {
+		err := o.Base.Client.DomainRecord.Update(o.Base.Context, o.Base.Args[0], changes[i].ID, changes[i].req())
+		if err != nil {
+			changes[i].Status = statusFailed
+			changes[i].Error = err.Error()
+			continue
+		}
+
+		changes[i].Status = statusDone
+	}
+
+	return changes, nil
+}
+
+// checkExpect returns the values of the records in Vultr DNS with the name
+// and type, which dns check expects when no values are given
+func (o *options) checkExpect(name, rType string) ([]string, error) {
+	if name == "@" {
+		name = ""
+	}
+
+	recs, err := o.recordListAll(o.Base.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving domain records : %v", err)
+	}
+
+	var expect []string
+	for i := range recs {
+		if recs[i].Name == name && strings.EqualFold(recs[i].Type, rType) {
+			expect = append(expect, recs[i].Data)
+		}
+	}
+
+	if len(expect) == 0 {
+		return nil, fmt.Errorf("no %s records named %q found in Vultr DNS, please provide --expect", rType, name)
+	}
+
+	return expect, nil
+}
+
+// ddnsTarget is a record kept updated by ddns and the URL used to look up its
+// address
+type ddnsTarget struct {
+	Name string
+	Type string
+	TTL  int
+	URL  string
+}
+
+// ddns upserts the records whenever the public address changes, until the
+// process is interrupted.  When once is set the records are checked a single
+// time and any failure is returned
+func (o *options) ddns(targets []ddnsTarget, interval time.Duration, once bool) error {
+	// the context is canceled by an interrupt
+	ctx := o.Base.Context
+
+	current := make(map[string]string, len(targets))
+	for {
+		for i := range targets {
+			err := o.ddnsUpdate(ctx, &targets[i], current)
+			if err != nil && once {
+				return err
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format(time.RFC3339), err)
+			}
+		}
+
+		if once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ddnsUpdate looks up the public address for the target and upserts the
+// record when it differs from the last address which was set
+func (o *options) ddnsUpdate(ctx context.Context, t *ddnsTarget, current map[string]string) error {
+	ip, err := utils.PublicIP(ctx, t.URL, t.Type == "AAAA")
+	if err != nil {
+		return fmt.Errorf("error looking up public address for %s record : %v", t.Type, err)
+	}
+
+	if current[t.Type] == ip {
+		return nil
+	}
+
+	o.RecordReq = &govultr.DomainRecordReq{Name: t.Name, Type: t.Type, Data: ip, TTL: t.TTL}
+	_, action, err := o.recordUpsert()
+	if err != nil {
+		return fmt.Errorf("error updating %s record : %v", t.Type, err)
+	}
+
+	current[t.Type] = ip
+	fmt.Fprintf(os.Stderr, "%s %s record %s %s\n", time.Now().Format(time.RFC3339), t.Type, action, ip)
+
+	return nil
+}
+
+// domainListAll retrieves every domain on the account
+func (o *options) domainListAll() ([]govultr.Domain, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Domain, *govultr.Meta, error) {
+		dms, meta, _, err := o.Base.Client.Domain.List(o.Base.Context, opts)
+		return dms, meta, err
+	})
+}
+
+// zoneFor returns the longest domain on the account containing the FQDN and
+// the record name relative to it
+func (o *options) zoneFor(fqdn string) (string, string, error) {
+	dms, err := o.domainListAll()
+	if err != nil {
+		return "", "", err
+	}
+
+	fqdn = strings.ToLower(fqdn)
+	zone := ""
+	for i := range dms {
+		dm := strings.ToLower(dms[i].Domain)
+		if (fqdn == dm || strings.HasSuffix(fqdn, "."+dm)) && len(dm) > len(zone) {
+			zone = dm
+		}
+	}
+
+	if zone == "" {
+		return "", "", fmt.Errorf("no dns domain found for %s", fqdn)
+	}
+
+	name, err := relativeName(fqdn, zone)
+	return zone, name, err
+}
+
+// acmePresent creates the challenge TXT record unless it already exists
+func (o *options) acmePresent(fqdn, token string) error {
+	zone, name, err := o.zoneFor(fqdn)
+	if err != nil {
+		return err
+	}
+
+	recs, err := o.recordListAll(zone)
+	if err != nil {
+		return err
+	}
+
+	for i := range recs {
+		if recs[i].Type == "TXT" && recs[i].Name == name && unquote(recs[i].Data) == token {
+			return nil
+		}
+	}
+
+	_, _, err = o.Base.Client.DomainRecord.Create(o.Base.Context, zone, &govultr.DomainRecordReq{
+		Name: name,
+		Type: "TXT",
+		Data: strconv.Quote(token),
+		TTL:  acmeTTL,
+	})
+
+	return err
+}
+
+// acmeCleanup deletes the challenge TXT records holding the token
+func (o *options) acmeCleanup(fqdn, token string) error {
+	zone, name, err := o.zoneFor(fqdn)
+	if err != nil {
+		return err
+	}
+
+	recs, err := o.recordListAll(zone)
+	if err != nil {
+		return err
+	}
+
+	for i := range recs {
+		if recs[i].Type != "TXT" || recs[i].Name != name || unquote(recs[i].Data) != token {
+			continue
+		}
+
+		if err := o.Base.Client.DomainRecord.Delete(o.Base.Context, zone, recs[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// acmeRecordName returns the challenge record FQDN for the domain being
+// validated, which for wildcards is the record of the base domain
+func acmeRecordName(domain string) string {
+	domain = strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+	if strings.HasPrefix(domain, "_acme-challenge.") {
+		return domain
+	}
+	return "_acme-challenge." + domain
+}
+
+// waitForTXT polls the nameserver until it serves a TXT record for the FQDN
+// holding the token
+func waitForTXT(ctx context.Context, nameserver, fqdn, token string, timeout time.Duration) error {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+
+	return cli.Poll(ctx, acmePollInterval, timeout, func() (bool, error) {
+		txts, err := resolver.LookupTXT(ctx, fqdn+".")
+		if err != nil {
+			return false, nil //nolint:nilerr
+		}
+		return slices.Contains(txts, token), nil
+	})
+}
+
 // recordDelete ...
 func (o *options) recordDelete() error {
 	return o.Base.Client.DomainRecord.Delete(o.Base.Context, o.Base.Args[0], o.Base.Args[1])
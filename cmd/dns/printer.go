@@ -1,7 +1,10 @@
 package dns
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -277,3 +280,304 @@ func (d *DNSSECPrinter) Data() [][]string {
 func (d *DNSSECPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// DNSImportPrinter ...
+type DNSImportPrinter struct {
+	Domain  string         `json:"domain"`
+	Results []importResult `json:"records"`
+}
+
+// JSON ...
+func (d *DNSImportPrinter) JSON() []byte {
+	return printer.MarshalObject(d, "json")
+}
+
+// YAML ...
+func (d *DNSImportPrinter) YAML() []byte {
+	return printer.MarshalObject(d, "yaml")
+}
+
+// Columns ...
+func (d *DNSImportPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"LINE",
+		"TYPE",
+		"NAME",
+		"DATA",
+		"PRIORITY",
+		"TTL",
+		"STATUS",
+		"DETAIL",
+	}}
+}
+
+// Data ...
+func (d *DNSImportPrinter) Data() [][]string {
+	if len(d.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range d.Results {
+		priority := ""
+		if d.Results[i].Priority != nil {
+			priority = strconv.Itoa(*d.Results[i].Priority)
+		}
+
+		detail := d.Results[i].ID
+		if d.Results[i].Error != "" {
+			detail = d.Results[i].Error
+		}
+
+		data = append(data, []string{
+			strconv.Itoa(d.Results[i].Line),
+			d.Results[i].Type,
+			d.Results[i].Name,
+			d.Results[i].Data,
+			priority,
+			strconv.Itoa(d.Results[i].TTL),
+			d.Results[i].Status,
+			detail,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (d *DNSImportPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of records which could not be created
+func (d *DNSImportPrinter) failed() int {
+	n := 0
+	for i := range d.Results {
+		if d.Results[i].Status == statusFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// ======================================
+
+// DNSChangesPrinter ...
+type DNSChangesPrinter struct {
+	Changes []recordChange `json:"changes"`
+}
+
+// JSON ...
+func (d *DNSChangesPrinter) JSON() []byte {
+	return printer.MarshalObject(d, "json")
+}
+
+// YAML ...
+func (d *DNSChangesPrinter) YAML() []byte {
+	return printer.MarshalObject(d, "yaml")
+}
+
+// Columns ...
+func (d *DNSChangesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"ID",
+		"TYPE",
+		"NAME",
+		"DATA",
+		"PRIORITY",
+		"TTL",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (d *DNSChangesPrinter) Data() [][]string {
+	if len(d.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range d.Changes {
+		priority := ""
+		if d.Changes[i].Priority != nil {
+			priority = strconv.Itoa(*d.Changes[i].Priority)
+		}
+
+		status := d.Changes[i].Status
+		if d.Changes[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, d.Changes[i].Error)
+		}
+
+		data = append(data, []string{
+			d.Changes[i].Action,
+			d.Changes[i].ID,
+			d.Changes[i].Type,
+			d.Changes[i].Name,
+			d.Changes[i].Data,
+			priority,
+			strconv.Itoa(d.Changes[i].TTL),
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (d *DNSChangesPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of changes which could not be applied
+func (d *DNSChangesPrinter) failed() int {
+	n := 0
+	for i := range d.Changes {
+		if d.Changes[i].Status == statusFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// ======================================
+
+// dsRecord is a delegation signer record as entered at the registrar
+type dsRecord struct {
+	KeyTag     string `json:"key_tag"`
+	Algorithm  string `json:"algorithm"`
+	DigestType string `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+// DNSSECRecordsPrinter ...
+type DNSSECRecordsPrinter struct {
+	Domain string     `json:"domain"`
+	DS     []dsRecord `json:"ds_records"`
+	DNSKEY []string   `json:"dnskey_records"`
+}
+
+// newDNSSECRecordsPrinter splits the DNSSEC info returned by the API into the
+// DS and DNSKEY records
+func newDNSSECRecordsPrinter(domain string, info []string) *DNSSECRecordsPrinter {
+	d := &DNSSECRecordsPrinter{Domain: domain, DS: []dsRecord{}, DNSKEY: []string{}}
+	for i := range info {
+		fields := strings.Fields(info[i])
+		if j := slices.Index(fields, "DS"); j >= 0 && len(fields) >= j+5 { //nolint:mnd
+			d.DS = append(d.DS, dsRecord{
+				KeyTag:     fields[j+1],
+				Algorithm:  fields[j+2],
+				DigestType: fields[j+3],
+				Digest:     strings.Join(fields[j+4:], ""),
+			})
+		} else if j := slices.Index(fields, "DNSKEY"); j >= 0 {
+			d.DNSKEY = append(d.DNSKEY, strings.Join(fields[j+1:], " "))
+		}
+	}
+
+	return d
+}
+
+// JSON ...
+func (d *DNSSECRecordsPrinter) JSON() []byte {
+	return printer.MarshalObject(d, "json")
+}
+
+// YAML ...
+func (d *DNSSECRecordsPrinter) YAML() []byte {
+	return printer.MarshalObject(d, "yaml")
+}
+
+// Columns ...
+func (d *DNSSECRecordsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"KEY TAG",
+		"ALGORITHM",
+		"DIGEST TYPE",
+		"DIGEST",
+	}}
+}
+
+// Data ...
+func (d *DNSSECRecordsPrinter) Data() [][]string {
+	if len(d.DS) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range d.DS {
+		data = append(data, []string{
+			d.DS[i].KeyTag,
+			d.DS[i].Algorithm,
+			d.DS[i].DigestType,
+			d.DS[i].Digest,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (d *DNSSECRecordsPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// DNSCheckPrinter ...
+type DNSCheckPrinter struct {
+	Record  string        `json:"record"`
+	Type    string        `json:"type"`
+	Expect  []string      `json:"expect"`
+	Results []checkResult `json:"results"`
+}
+
+// JSON ...
+func (d *DNSCheckPrinter) JSON() []byte {
+	return printer.MarshalObject(d, "json")
+}
+
+// YAML ...
+func (d *DNSCheckPrinter) YAML() []byte {
+	return printer.MarshalObject(d, "yaml")
+}
+
+// Columns ...
+func (d *DNSCheckPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"NAMESERVER",
+		"ANSWER",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (d *DNSCheckPrinter) Data() [][]string {
+	var data [][]string
+	for i := range d.Results {
+		answer := strings.Join(d.Results[i].Answers, ", ")
+		if answer == "" {
+			answer = "---"
+		}
+
+		status := d.Results[i].Status
+		if d.Results[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, d.Results[i].Error)
+		}
+
+		data = append(data, []string{
+			d.Results[i].Nameserver,
+			answer,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (d *DNSCheckPrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,698 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// zoneRecord is a single resource record read from a BIND zone file.  The name
+// is relative to the domain and is empty for records on the zone apex
+type zoneRecord struct {
+	Line     int
+	Name     string
+	Type     string
+	Data     string
+	TTL      int
+	Priority *int
+}
+
+const (
+	statusCreated = "created"
+	statusSkipped = "skipped"
+	statusFailed  = "failed"
+	statusPlanned = "planned"
+	statusDone    = "done"
+)
+
+// importResult holds the outcome of importing a single zone record
+type importResult struct {
+	Line     int    `json:"line"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority *int   `json:"priority,omitempty"`
+	TTL      int    `json:"ttl"`
+	Status   string `json:"status"`
+	ID       string `json:"id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// importTypes are the record types which can be created through the API
+var importTypes = []string{"A", "AAAA", "CNAME", "NS", "MX", "SRV", "TXT", "CAA", "SSHFP"}
+
+// skipImport returns the reason a zone record will not be imported or an
+// empty string when it should be created
+func skipImport(rec *zoneRecord) string {
+	switch {
+	case rec.Type == "SOA":
+		return "SOA is managed by Vultr"
+	case rec.Type == "NS" && rec.Name == "":
+		return "apex NS records are managed by Vultr"
+	}
+
+	for i := range importTypes {
+		if rec.Type == importTypes[i] {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("record type %s is not supported", rec.Type)
+}
+
+// readZoneFile parses the BIND zone file at the path, or stdin when the path
+// is "-"
+func readZoneFile(path, domain string) ([]zoneRecord, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %s : %v", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	recs, err := parseZoneFile(r, domain)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse zone file %s : %v", path, err)
+	}
+
+	return recs, nil
+}
+
+// zoneSpec is the portable JSON and YAML representation of the records of a
+// domain
+type zoneSpec struct {
+	Domain  string       `json:"domain" yaml:"domain"`
+	Records []recordSpec `json:"records" yaml:"records"`
+}
+
+// recordSpec is a single record of a zoneSpec
+type recordSpec struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Data     string `json:"data" yaml:"data"`
+	TTL      int    `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Priority *int   `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// newZoneSpec converts the API records of the domain to a zoneSpec
+func newZoneSpec(domain string, recs []govultr.DomainRecord) *zoneSpec {
+	spec := &zoneSpec{Domain: domain, Records: []recordSpec{}}
+	for i := range recs {
+		rec := recordSpec{
+			Name: recs[i].Name,
+			Type: recs[i].Type,
+			Data: recs[i].Data,
+			TTL:  recs[i].TTL,
+		}
+
+		if hasPriority(recs[i].Type) {
+			rec.Priority = govultr.IntToIntPtr(recs[i].Priority)
+		}
+
+		spec.Records = append(spec.Records, rec)
+	}
+
+	return spec
+}
+
+// writeZoneFile writes the records of the domain in BIND zone file format
+func writeZoneFile(w io.Writer, domain string, recs []govultr.DomainRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0) //nolint:mnd
+
+	fmt.Fprintf(tw, "; %s exported from Vultr DNS\n", domain)
+	fmt.Fprintf(tw, "$ORIGIN %s.\n", strings.TrimSuffix(domain, "."))
+
+	for i := range recs {
+		name := recs[i].Name
+		if name == "" {
+			name = "@"
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\tIN\t%s\t%s\n", name, recs[i].TTL, recs[i].Type, bindData(&recs[i]))
+	}
+
+	return tw.Flush()
+}
+
+// bindData returns the zone file rdata for an API record
+func bindData(rec *govultr.DomainRecord) string {
+	switch rec.Type {
+	case "CNAME", "NS", "PTR":
+		return fqdn(rec.Data)
+	case "MX":
+		return fmt.Sprintf("%d %s", rec.Priority, fqdn(rec.Data))
+	case "SRV":
+		fields := strings.Fields(rec.Data)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = fqdn(fields[len(fields)-1])
+		}
+		return fmt.Sprintf("%d %s", rec.Priority, strings.Join(fields, " "))
+	case "TXT", "SPF":
+		if strings.HasPrefix(rec.Data, "\"") {
+			return rec.Data
+		}
+		return strconv.Quote(rec.Data)
+	default:
+		return rec.Data
+	}
+}
+
+// hasPriority reports whether the record type uses the priority field
+func hasPriority(rType string) bool {
+	return rType == "MX" || rType == "SRV"
+}
+
+// fqdn adds the trailing dot to a host name
+func fqdn(host string) string {
+	if strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+const (
+	changeCreate = "create"
+	changeUpdate = "update"
+	changeDelete = "delete"
+)
+
+// recordChange is a single step of the plan which converges the live records
+// of a domain to a zoneSpec
+type recordChange struct {
+	Action   string `json:"action"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	TTL      int    `json:"ttl,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// req returns the API request for a create or update change
+func (c *recordChange) req() *govultr.DomainRecordReq {
+	return &govultr.DomainRecordReq{
+		Name:     c.Name,
+		Type:     c.Type,
+		Data:     c.Data,
+		TTL:      c.TTL,
+		Priority: c.Priority,
+	}
+}
+
+// planZone compares the desired records with the live records and returns
+// the creates, updates and deletes needed to converge them, in the order they
+// are applied so a record being replaced keeps answering until the new one
+// exists.  Records are
+// matched on name, type and data first so that unchanged records are left
+// alone, and the remaining records of the same name and type are updated in
+// place.  Live NS records on the zone apex are only touched when the spec
+// declares apex NS records itself
+func planZone(spec *zoneSpec, live []govultr.DomainRecord) []recordChange { //nolint:gocyclo
+	desired := make([]recordSpec, len(spec.Records))
+	manageApexNS := false
+	for i := range spec.Records {
+		desired[i] = spec.Records[i]
+		desired[i].Type = strings.ToUpper(desired[i].Type)
+		if desired[i].Name == "@" {
+			desired[i].Name = ""
+		}
+		if desired[i].Type == "NS" && desired[i].Name == "" {
+			manageApexNS = true
+		}
+	}
+
+	used := make([]bool, len(live))
+	match := func(d *recordSpec, sameData bool) int {
+		for i := range live {
+			if used[i] || live[i].Name != d.Name || !strings.EqualFold(live[i].Type, d.Type) {
+				continue
+			}
+			if sameData && live[i].Data != d.Data {
+				continue
+			}
+			used[i] = true
+			return i
+		}
+		return -1
+	}
+
+	var creates, updates, deletes []recordChange
+	var pending []*recordSpec
+	for i := range desired {
+		d := &desired[i]
+		idx := match(d, true)
+		if idx < 0 {
+			pending = append(pending, d)
+			continue
+		}
+
+		ttlChanged := d.TTL != 0 && d.TTL != live[idx].TTL
+		priorityChanged := d.Priority != nil && *d.Priority != live[idx].Priority
+		if ttlChanged || priorityChanged {
+			updates = append(updates, newRecordChange(changeUpdate, live[idx].ID, d))
+		}
+	}
+
+	for _, d := range pending {
+		if idx := match(d, false); idx >= 0 {
+			updates = append(updates, newRecordChange(changeUpdate, live[idx].ID, d))
+		} else {
+			creates = append(creates, newRecordChange(changeCreate, "", d))
+		}
+	}
+
+	for i := range live {
+		if used[i] || live[i].Type == "SOA" || (live[i].Type == "NS" && live[i].Name == "" && !manageApexNS) {
+			continue
+		}
+
+		rec := recordSpec{Name: live[i].Name, Type: live[i].Type, Data: live[i].Data, TTL: live[i].TTL}
+		deletes = append(deletes, newRecordChange(changeDelete, live[i].ID, &rec))
+	}
+
+	return append(append(creates, updates...), deletes...)
+}
+
+// PlannedRecord is a change planned by PlanRecords.  The action is one of
+// create, update or delete and the ID is that of the live record, empty for
+// a create
+type PlannedRecord struct {
+	Action string
+	ID     string
+	Record govultr.DomainRecordReq
+}
+
+// PlanRecords plans the changes which converge the live records of a domain
+// to the desired records the same way as dns record apply, so other commands
+// managing records behave alike.  The changes are in the order to apply them
+func PlanRecords(desired []govultr.DomainRecordReq, live []govultr.DomainRecord) []PlannedRecord {
+	spec := &zoneSpec{}
+	for i := range desired {
+		spec.Records = append(spec.Records, recordSpec{
+			Name:     desired[i].Name,
+			Type:     desired[i].Type,
+			Data:     desired[i].Data,
+			TTL:      desired[i].TTL,
+			Priority: desired[i].Priority,
+		})
+	}
+
+	changes := planZone(spec, live)
+	planned := make([]PlannedRecord, len(changes))
+	for i := range changes {
+		planned[i] = PlannedRecord{Action: changes[i].Action, ID: changes[i].ID, Record: *changes[i].req()}
+	}
+
+	return planned
+}
+
+// planTTL returns the updates which set the TTL of the live records matching
+// the type and name.  An empty type or name matches any record and @ matches
+// the zone apex.  SOA records are never matched and records which already
+// have the TTL are left alone
+func planTTL(live []govultr.DomainRecord, recordType, name string, ttl int) []recordChange {
+	anyName := name == ""
+	if name == "@" {
+		name = ""
+	}
+
+	var updates []recordChange
+	for i := range live {
+		if live[i].Type == "SOA" || live[i].TTL == ttl {
+			continue
+		}
+		if recordType != "" && !strings.EqualFold(live[i].Type, recordType) {
+			continue
+		}
+		if !anyName && live[i].Name != name {
+			continue
+		}
+
+		rec := recordSpec{Name: live[i].Name, Type: live[i].Type, Data: live[i].Data, TTL: ttl}
+		if hasPriority(live[i].Type) {
+			rec.Priority = govultr.IntToIntPtr(live[i].Priority)
+		}
+		updates = append(updates, newRecordChange(changeUpdate, live[i].ID, &rec))
+	}
+
+	return updates
+}
+
+// newRecordChange builds a planned change for the record
+func newRecordChange(action, id string, rec *recordSpec) recordChange {
+	return recordChange{
+		Action:   action,
+		ID:       id,
+		Type:     rec.Type,
+		Name:     rec.Name,
+		Data:     rec.Data,
+		TTL:      rec.TTL,
+		Priority: rec.Priority,
+		Status:   statusPlanned,
+	}
+}
+
+// zoneLine is a logical zone file entry once comments have been stripped and
+// parenthesised continuations have been joined
+type zoneLine struct {
+	number   int
+	indented bool
+	tokens   []string
+}
+
+// parseZoneFile reads a standard BIND zone file for the domain.  $ORIGIN and
+// $TTL directives, @ and relative owner names, omitted owners, comments and
+// multi-line records are supported.  Host names in record data are returned
+// fully qualified without the trailing dot, as expected by the API
+func parseZoneFile(r io.Reader, domain string) ([]zoneRecord, error) { //nolint:gocyclo
+	lines, err := readZoneLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	origin := domain + "."
+	owner := ""
+	defaultTTL := 0
+
+	var records []zoneRecord
+	for _, l := range lines {
+		toks := l.tokens
+
+		switch strings.ToUpper(toks[0]) {
+		case "$ORIGIN":
+			if len(toks) < 2 {
+				return nil, fmt.Errorf("line %d : $ORIGIN requires a name", l.number)
+			}
+			origin = absoluteName(toks[1], origin)
+			continue
+		case "$TTL":
+			if len(toks) < 2 {
+				return nil, fmt.Errorf("line %d : $TTL requires a value", l.number)
+			}
+			ttl, ok := parseZoneTTL(toks[1])
+			if !ok {
+				return nil, fmt.Errorf("line %d : invalid $TTL value %q", l.number, toks[1])
+			}
+			defaultTTL = ttl
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d : %s directives are not supported", l.number, toks[0])
+		}
+
+		if !l.indented {
+			owner = absoluteName(toks[0], origin)
+			toks = toks[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d : record has no owner name", l.number)
+		}
+
+		ttl := defaultTTL
+		for len(toks) > 0 {
+			if v, ok := parseZoneTTL(toks[0]); ok {
+				ttl = v
+			} else if !isZoneClass(toks[0]) {
+				break
+			}
+			toks = toks[1:]
+		}
+
+		if len(toks) == 0 {
+			return nil, fmt.Errorf("line %d : record has no type", l.number)
+		}
+
+		name, err := relativeName(owner, domain)
+		if err != nil {
+			return nil, fmt.Errorf("line %d : %v", l.number, err)
+		}
+
+		rec := zoneRecord{Line: l.number, Name: name, Type: strings.ToUpper(toks[0]), TTL: ttl}
+		if err := rec.setData(toks[1:], origin); err != nil {
+			return nil, fmt.Errorf("line %d : %v", l.number, err)
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// setData converts the zone file rdata fields into the data and priority
+// values used by the API for the record type
+func (z *zoneRecord) setData(rdata []string, origin string) error {
+	want := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "PTR": 1, "MX": 2, "SRV": 4, "CAA": 3}
+	if n, ok := want[z.Type]; ok && len(rdata) != n {
+		return fmt.Errorf("%s record requires %d data fields, found %d", z.Type, n, len(rdata))
+	}
+
+	if len(rdata) == 0 {
+		return fmt.Errorf("%s record has no data", z.Type)
+	}
+
+	switch z.Type {
+	case "CNAME", "NS", "PTR":
+		z.Data = hostData(rdata[0], origin)
+	case "MX", "SRV":
+		priority, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid %s priority %q", z.Type, rdata[0])
+		}
+		z.Priority = &priority
+
+		fields := append([]string{}, rdata[1:]...)
+		fields[len(fields)-1] = hostData(fields[len(fields)-1], origin)
+		z.Data = strings.Join(fields, " ")
+	case "TXT", "SPF":
+		var txt strings.Builder
+		for i := range rdata {
+			txt.WriteString(unquote(rdata[i]))
+		}
+		z.Data = strconv.Quote(txt.String())
+	case "CAA":
+		z.Data = fmt.Sprintf("%s %s %s", rdata[0], strings.ToLower(rdata[1]), strconv.Quote(unquote(rdata[2])))
+	default:
+		z.Data = strings.Join(rdata, " ")
+	}
+
+	return nil
+}
+
+// readZoneLines splits the zone file into logical lines
+func readZoneLines(r io.Reader) ([]zoneLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024) //nolint:mnd
+
+	var lines []zoneLine
+	var current *zoneLine
+	depth := 0
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		text := scanner.Text()
+
+		toks, delta, err := tokenizeZoneLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d : %v", number, err)
+		}
+
+		if current == nil {
+			if len(toks) == 0 && delta == 0 {
+				continue
+			}
+			current = &zoneLine{
+				number:   number,
+				indented: text != "" && unicode.IsSpace(rune(text[0])),
+			}
+		}
+
+		current.tokens = append(current.tokens, toks...)
+		depth += delta
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d : unbalanced parentheses", number)
+		}
+
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				lines = append(lines, *current)
+			}
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d : unterminated parentheses", current.number)
+	}
+
+	return lines, nil
+}
+
+// tokenizeZoneLine splits a physical line into whitespace separated fields,
+// keeping quoted strings intact and dropping comments.  The returned delta is
+// the change in parenthesis depth caused by the line
+func tokenizeZoneLine(text string) ([]string, int, error) {
+	var toks []string
+	var field strings.Builder
+	delta := 0
+	quoted := false
+
+	flush := func() {
+		if field.Len() > 0 {
+			toks = append(toks, field.String())
+			field.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if quoted {
+			field.WriteByte(c)
+			if c == '\\' && i+1 < len(text) {
+				i++
+				field.WriteByte(text[i])
+			} else if c == '"' {
+				quoted = false
+				flush()
+			}
+			continue
+		}
+
+		switch {
+		case c == ';':
+			flush()
+			return toks, delta, nil
+		case c == '"':
+			flush()
+			quoted = true
+			field.WriteByte(c)
+		case c == '(':
+			flush()
+			delta++
+		case c == ')':
+			flush()
+			delta--
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			field.WriteByte(c)
+		}
+	}
+
+	if quoted {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+
+	flush()
+	return toks, delta, nil
+}
+
+// parseZoneTTL parses a TTL given in seconds or with BIND style unit
+// suffixes such as 1h30m
+func parseZoneTTL(s string) (int, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, true
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, value := 0, 0
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			value = value*10 + int(c-'0')
+			digits = true
+			continue
+		}
+
+		mult, ok := units[byte(unicode.ToLower(rune(c)))]
+		if !ok || !digits {
+			return 0, false
+		}
+		total += value * mult
+		value, digits = 0, false
+	}
+
+	if digits {
+		return 0, false
+	}
+
+	return total, true
+}
+
+// isZoneClass reports whether the field is a DNS class
+func isZoneClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// absoluteName resolves a zone file name against the current origin
+func absoluteName(name, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + origin
+	}
+}
+
+// relativeName returns the owner name relative to the domain as used by the
+// API, which is empty for the zone apex
+func relativeName(owner, domain string) (string, error) {
+	owner = strings.TrimSuffix(owner, ".")
+	if owner == domain {
+		return "", nil
+	}
+
+	if !strings.HasSuffix(owner, "."+domain) {
+		return "", fmt.Errorf("name %s is outside of the %s zone", owner, domain)
+	}
+
+	return strings.TrimSuffix(owner, "."+domain), nil
+}
+
+// hostData returns a fully qualified host name without the trailing dot
+func hostData(host, origin string) string {
+	if host == "." {
+		return host
+	}
+	return strings.TrimSuffix(absoluteName(host, origin), ".")
+}
+
+// unquote strips the quotes from a zone file string field
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if v, err := strconv.Unquote(s); err == nil {
+			return v
+		}
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// liveRecords is the zone the plans are made against
+var liveRecords = []govultr.DomainRecord{
+	{ID: "soa", Type: "SOA", Name: "", Data: "ns1.vultr.com", TTL: 3600},
+	{ID: "ns1", Type: "NS", Name: "", Data: "ns1.vultr.com", TTL: 3600},
+	{ID: "apex", Type: "A", Name: "", Data: "192.0.2.1", TTL: 3600},
+	{ID: "www", Type: "A", Name: "www", Data: "192.0.2.2", TTL: 300},
+	{ID: "mx", Type: "MX", Name: "", Data: "mail.example.com", Priority: 10, TTL: 3600},
+	{ID: "old", Type: "TXT", Name: "old", Data: "gone", TTL: 3600},
+}
+
+func describeChanges(changes []recordChange) []string {
+	out := make([]string, len(changes))
+	for i := range changes {
+		c := changes[i]
+		out[i] = fmt.Sprintf("%s %s %s %q %s", c.Action, c.ID, c.Type, c.Name, c.Data)
+	}
+	return out
+}
+
+func TestPlanZone(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []recordSpec
+		want    []string
+	}{
+		{
+			name: "unchanged",
+			records: []recordSpec{
+				{Name: "@", Type: "a", Data: "192.0.2.1"},
+				{Name: "www", Type: "A", Data: "192.0.2.2", TTL: 300},
+				{Name: "", Type: "MX", Data: "mail.example.com", Priority: govultr.IntToIntPtr(10)},
+				{Name: "old", Type: "TXT", Data: "gone"},
+			},
+			want: []string{},
+		},
+		{
+			name: "create, update and delete in order",
+			records: []recordSpec{
+				{Name: "api", Type: "A", Data: "192.0.2.9"},
+				{Name: "", Type: "A", Data: "192.0.2.1"},
+				{Name: "www", Type: "A", Data: "192.0.2.3"},
+				{Name: "", Type: "MX", Data: "mail.example.com", Priority: govultr.IntToIntPtr(10)},
+			},
+			want: []string{
+				`create  A "api" 192.0.2.9`,
+				`update www A "www" 192.0.2.3`,
+				`delete old TXT "old" gone`,
+			},
+		},
+		{
+			name: "ttl and priority changes update in place",
+			records: []recordSpec{
+				{Name: "", Type: "A", Data: "192.0.2.1", TTL: 60},
+				{Name: "www", Type: "A", Data: "192.0.2.2"},
+				{Name: "", Type: "MX", Data: "mail.example.com", Priority: govultr.IntToIntPtr(20)},
+				{Name: "old", Type: "TXT", Data: "gone"},
+			},
+			want: []string{
+				`update apex A "" 192.0.2.1`,
+				`update mx MX "" mail.example.com`,
+			},
+		},
+		{
+			name: "a second record of the same name is created",
+			records: []recordSpec{
+				{Name: "", Type: "A", Data: "192.0.2.1"},
+				{Name: "www", Type: "A", Data: "192.0.2.2"},
+				{Name: "www", Type: "A", Data: "192.0.2.3"},
+				{Name: "", Type: "MX", Data: "mail.example.com"},
+				{Name: "old", Type: "TXT", Data: "gone"},
+			},
+			want: []string{
+				`create  A "www" 192.0.2.3`,
+			},
+		},
+		{
+			name: "apex NS records are only deleted when managed",
+			records: []recordSpec{
+				{Name: "@", Type: "NS", Data: "ns.example.net"},
+			},
+			want: []string{
+				`update ns1 NS "" ns.example.net`,
+				`delete apex A "" 192.0.2.1`,
+				`delete www A "www" 192.0.2.2`,
+				`delete mx MX "" mail.example.com`,
+				`delete old TXT "old" gone`,
+			},
+		},
+		{
+			name:    "empty spec keeps SOA and apex NS",
+			records: nil,
+			want: []string{
+				`delete apex A "" 192.0.2.1`,
+				`delete www A "www" 192.0.2.2`,
+				`delete mx MX "" mail.example.com`,
+				`delete old TXT "old" gone`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeChanges(planZone(&zoneSpec{Records: tt.records}, liveRecords))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("planZone() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanRecords(t *testing.T) {
+	desired := []govultr.DomainRecordReq{
+		{Name: "", Type: "A", Data: "192.0.2.1"},
+		{Name: "www", Type: "A", Data: "192.0.2.3", TTL: 300},
+		{Name: "api", Type: "CNAME", Data: "www.example.com"},
+	}
+
+	want := []PlannedRecord{
+		{Action: changeCreate, Record: govultr.DomainRecordReq{Name: "api", Type: "CNAME", Data: "www.example.com"}},
+		{Action: changeUpdate, ID: "www", Record: govultr.DomainRecordReq{
+			Name: "www", Type: "A", Data: "192.0.2.3", TTL: 300,
+		}},
+		{Action: changeDelete, ID: "mx", Record: govultr.DomainRecordReq{
+			Name: "", Type: "MX", Data: "mail.example.com", TTL: 3600,
+		}},
+		{Action: changeDelete, ID: "old", Record: govultr.DomainRecordReq{Name: "old", Type: "TXT", Data: "gone", TTL: 3600}},
+	}
+
+	got := PlanRecords(desired, liveRecords)
+	if len(got) != len(want) {
+		t.Fatalf("PlanRecords() returned %d changes, want %d : %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Action != w.Action || g.ID != w.ID || g.Record.Name != w.Record.Name || g.Record.Type != w.Record.Type ||
+			g.Record.Data != w.Record.Data || g.Record.TTL != w.Record.TTL {
+			t.Errorf("PlanRecords()[%d] = %+v, want %+v", i, g, w)
+		}
+	}
+}
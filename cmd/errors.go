@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// Exit codes let scripts tell why a command failed
+const (
+	ExitError       = 1 // any other failure
+	ExitValidation  = 2 // invalid command, arguments or flags
+	ExitAuth        = 3 // missing or rejected API key
+	ExitNotFound    = 4 // the resource does not exist
+	ExitRateLimited = 5 // too many requests, even after retrying
+	ExitServer      = 6 // the API failed with a 5xx status
+
+	// ExitInterrupted is for commands stopped by Ctrl-C or SIGTERM
+	ExitInterrupted = cli.ExitInterrupted
+)
+
+// commandError describes why a command failed
+type commandError struct {
+	Type     string `json:"type" yaml:"type"`
+	Message  string `json:"message" yaml:"message"`
+	Status   int    `json:"status,omitempty" yaml:"status,omitempty"`
+	APIError string `json:"api_error,omitempty" yaml:"api_error,omitempty"`
+	ExitCode int    `json:"exit_code" yaml:"exit_code"`
+}
+
+// started is set once the command itself runs, errors before that come from
+// parsing the command line
+var started bool
+
+// trackRun marks when the command of the tree starts running
+func trackRun(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		trackRun(c)
+	}
+
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			started = true
+			return run(cmd, args)
+		}
+	}
+
+	if run := cmd.Run; run != nil {
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			started = true
+			run(cmd, args)
+		}
+	}
+}
+
+// classify works out the type and exit code of the error from the last
+// failed API request
+func classify(err error) *commandError {
+	ce := &commandError{Type: "error", Message: strings.TrimSpace(err.Error()), ExitCode: ExitError}
+
+	if base.Interrupted() {
+		ce.Type, ce.ExitCode = "interrupted", ExitInterrupted
+		return ce
+	}
+
+	if err.Error() == utils.APIKeyError {
+		ce.Type, ce.ExitCode = "auth", ExitAuth
+		return ce
+	}
+
+	if !started {
+		ce.Type, ce.ExitCode = "validation", ExitValidation
+		return ce
+	}
+
+	apiErr := base.LastAPIError()
+	if apiErr == nil {
+		return ce
+	}
+
+	ce.Status, ce.APIError = apiErr.Status, apiErr.Message
+
+	switch {
+	case apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden:
+		ce.Type, ce.ExitCode = "auth", ExitAuth
+	case apiErr.Status == http.StatusNotFound:
+		ce.Type, ce.ExitCode = "not_found", ExitNotFound
+	case apiErr.Status == http.StatusTooManyRequests:
+		ce.Type, ce.ExitCode = "rate_limited", ExitRateLimited
+	case apiErr.Status == http.StatusBadRequest || apiErr.Status == http.StatusUnprocessableEntity:
+		ce.Type, ce.ExitCode = "validation", ExitValidation
+	case apiErr.Status >= http.StatusInternalServerError:
+		ce.Type, ce.ExitCode = "server", ExitServer
+	}
+
+	return ce
+}
+
+// exitWithError prints the error, as an object when JSON or YAML output was
+// asked for, and exits with the matching code
+func exitWithError(err error) {
+	ce := classify(err)
+
+	base.Logger().Error("command failed",
+		slog.String("type", ce.Type),
+		slog.String("error", ce.Message),
+		slog.Int("status", ce.Status),
+		slog.Int("exit_code", ce.ExitCode),
+	)
+
+	switch format := viper.GetString("output"); format {
+	case "json", "yaml":
+		fmt.Fprintf(os.Stderr, "%s\n", printer.MarshalObject(map[string]*commandError{"error": ce}, format))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	os.Exit(ce.ExitCode)
+}
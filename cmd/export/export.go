@@ -0,0 +1,263 @@
+// Package export provides the functionality for exporting resources to other
+// tools from the CLI
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	exportDirPermission  = 0o700
+	exportFilePermission = 0o600
+
+	importScriptFile = "import.sh"
+)
+
+var (
+	exportLong    = `Export the live resources of the account to the configuration formats of other tools`
+	exportExample = `
+	# Full example
+	vultr-cli export terraform
+	`
+
+	terraformLong = `Write Terraform resource blocks for the existing instances, DNS domains and records and
+firewall groups and rules of the account, along with an import.sh script which imports each
+of them into the Terraform state.
+
+Each resource type is written to its own .tf file in the directory.  Instances refer to their
+firewall group by address when firewall groups are exported too.  Existing files are only
+overwritten with --force.  The generated configuration is a starting point, run terraform plan
+after importing to review any differences.
+`
+	terraformExample = `
+	# Full example
+	vultr-cli export terraform
+
+	# Only export DNS and firewalls into the infra directory
+	vultr-cli export terraform --types dns,firewall --dir infra
+
+	# Import the exported resources
+	cd infra && terraform init && sh import.sh
+	`
+)
+
+// NewCmdExport provides the CLI command for exporting resources
+func NewCmdExport(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export resources to other tools",
+		Long:    exportLong,
+		Example: exportExample,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+	}
+
+	// Terraform
+	terraform := &cobra.Command{
+		Use:     "terraform",
+		Short:   "Export resources as Terraform configuration",
+		Aliases: []string{"tf"},
+		Long:    terraformLong,
+		Example: terraformExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types, errTy := cmd.Flags().GetStringSlice("types")
+			if errTy != nil {
+				return fmt.Errorf("error parsing flag 'types' for export terraform : %v", errTy)
+			}
+
+			dir, errDi := cmd.Flags().GetString("dir")
+			if errDi != nil {
+				return fmt.Errorf("error parsing flag 'dir' for export terraform : %v", errDi)
+			}
+
+			force, errFo := cmd.Flags().GetBool("force")
+			if errFo != nil {
+				return fmt.Errorf("error parsing flag 'force' for export terraform : %v", errFo)
+			}
+
+			for i := range types {
+				types[i] = strings.ToLower(strings.TrimSpace(types[i]))
+				if !slices.Contains(exportTypes, types[i]) {
+					return fmt.Errorf("invalid type %q, must be one of %s", types[i], strings.Join(exportTypes, ", "))
+				}
+			}
+
+			// files are written in a fixed order so references resolve
+			ordered := slices.DeleteFunc(slices.Clone(exportTypes), func(t string) bool {
+				return !slices.Contains(types, t)
+			})
+
+			files, err := o.exportTerraform(ordered, dir, force)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&ExportPrinter{Files: files}, nil)
+
+			return nil
+		},
+	}
+
+	terraform.Flags().StringSliceP(
+		"types",
+		"t",
+		exportTypes,
+		fmt.Sprintf("(optional) comma separated resource types to export, any of %s", strings.Join(exportTypes, ", ")),
+	)
+	terraform.Flags().StringP("dir", "d", ".", "(optional) directory the files are written to")
+	terraform.Flags().Bool("force", false, "(optional) overwrite existing files")
+
+	cmd.AddCommand(
+		terraform,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// exportedFile is a file written by an export
+type exportedFile struct {
+	Type      string `json:"type"`
+	Path      string `json:"path"`
+	Resources int    `json:"resources"`
+}
+
+// exportTerraform retrieves the resources of the types and writes their
+// configuration and the import script to the directory
+func (o *options) exportTerraform(types []string, dir string, force bool) ([]exportedFile, error) {
+	tf := newTerraform()
+	for _, exportType := range types {
+		var err error
+		switch exportType {
+		case typeFirewall:
+			err = o.exportFirewalls(tf)
+		case typeInstance:
+			err = o.exportInstances(tf)
+		case typeDNS:
+			err = o.exportDomains(tf)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]exportedFile, 0, len(types)+1)
+	contents := make([]string, 0, len(types)+1)
+	total := 0
+	for _, exportType := range types {
+		n := len(tf.resources[exportType])
+		files = append(files, exportedFile{Type: exportType, Path: filepath.Join(dir, tfFiles[exportType]), Resources: n})
+		contents = append(contents, tf.file(exportType))
+		total += n
+	}
+	files = append(files, exportedFile{Type: "import", Path: filepath.Join(dir, importScriptFile), Resources: total})
+	contents = append(contents, tf.importScript(types))
+
+	if !force {
+		for i := range files {
+			if _, err := os.Stat(files[i].Path); err == nil {
+				return nil, fmt.Errorf("%s already exists, use --force to overwrite it", files[i].Path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, exportDirPermission); err != nil {
+		return nil, fmt.Errorf("error creating directory %s : %v", dir, err)
+	}
+
+	for i := range files {
+		if err := os.WriteFile(files[i].Path, []byte(contents[i]), exportFilePermission); err != nil {
+			return nil, fmt.Errorf("error writing %s : %v", files[i].Path, err)
+		}
+	}
+
+	return files, nil
+}
+
+// exportFirewalls adds every firewall group and its rules
+func (o *options) exportFirewalls(tf *terraform) error {
+	groups, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.FirewallGroup.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving firewall groups : %v", err)
+	}
+
+	for i := range groups {
+		rules, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallRule, *govultr.Meta, error) {
+			items, meta, _, err := o.Base.Client.FirewallRule.List(o.Base.Context, groups[i].ID, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving the rules of firewall group %s : %v", groups[i].ID, err)
+		}
+
+		tf.addFirewallGroup(&groups[i], rules)
+	}
+
+	return nil
+}
+
+// exportInstances adds every instance
+func (o *options) exportInstances(tf *terraform) error {
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving instances : %v", err)
+	}
+
+	for i := range instances {
+		tf.addInstance(&instances[i])
+	}
+
+	return nil
+}
+
+// exportDomains adds every domain and its records
+func (o *options) exportDomains(tf *terraform) error {
+	domains, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Domain, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Domain.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving domains : %v", err)
+	}
+
+	for i := range domains {
+		records, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.DomainRecord, *govultr.Meta, error) {
+			items, meta, _, err := o.Base.Client.DomainRecord.List(o.Base.Context, domains[i].Domain, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving the records of domain %s : %v", domains[i].Domain, err)
+		}
+
+		tf.addDomain(domains[i].Domain, records)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// ExportPrinter ...
+type ExportPrinter struct {
+	Files []exportedFile `json:"files"`
+}
+
+// JSON ...
+func (e *ExportPrinter) JSON() []byte {
+	return printer.MarshalObject(e, "json")
+}
+
+// YAML ...
+func (e *ExportPrinter) YAML() []byte {
+	return printer.MarshalObject(e, "yaml")
+}
+
+// Columns ...
+func (e *ExportPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"PATH",
+		"RESOURCES",
+	}}
+}
+
+// Data ...
+func (e *ExportPrinter) Data() [][]string {
+	if len(e.Files) == 0 {
+		return [][]string{0: {"---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range e.Files {
+		data = append(data, []string{
+			e.Files[i].Type,
+			e.Files[i].Path,
+			strconv.Itoa(e.Files[i].Resources),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (e *ExportPrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,257 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	typeInstance = "instance"
+	typeDNS      = "dns"
+	typeFirewall = "firewall"
+)
+
+// exportTypes are the resource types which can be exported, in the order
+// their files are written
+var exportTypes = []string{typeFirewall, typeInstance, typeDNS}
+
+// tfFiles are the files written for each resource type
+var tfFiles = map[string]string{
+	typeFirewall: "vultr_firewall.tf",
+	typeInstance: "vultr_instance.tf",
+	typeDNS:      "vultr_dns.tf",
+}
+
+// invalidName matches the characters which may not be used in a Terraform
+// resource name
+var invalidName = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// tfResource is a single resource block and the ID used to import it
+type tfResource struct {
+	Type     string
+	Name     string
+	ImportID string
+	Attrs    []tfAttr
+}
+
+// tfAttr is an argument of a resource block with its value already written
+// as an HCL expression
+type tfAttr struct {
+	Key   string
+	Value string
+}
+
+// address returns the resource address used by terraform import
+func (r *tfResource) address() string {
+	return r.Type + "." + r.Name
+}
+
+// attr adds an argument to the block
+func (r *tfResource) attr(key, value string) {
+	r.Attrs = append(r.Attrs, tfAttr{Key: key, Value: value})
+}
+
+// block writes the resource block with the equals signs aligned the way
+// terraform fmt does
+func (r *tfResource) block() string {
+	width := 0
+	for i := range r.Attrs {
+		width = max(width, len(r.Attrs[i].Key))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %q %q {\n", r.Type, r.Name)
+	for i := range r.Attrs {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, r.Attrs[i].Key, r.Attrs[i].Value)
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// hclString quotes a string, escaping the template sequences HCL would
+// otherwise interpolate
+func hclString(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+	return strconv.Quote(s)
+}
+
+// hclList writes a list of strings
+func hclList(items []string) string {
+	quoted := make([]string, len(items))
+	for i := range items {
+		quoted[i] = hclString(items[i])
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// terraform builds the resource blocks of an account
+type terraform struct {
+	names map[string]bool
+	// groups maps the ID of each exported firewall group to its address
+	groups    map[string]string
+	resources map[string][]*tfResource
+}
+
+// newTerraform returns an empty export
+func newTerraform() *terraform {
+	return &terraform{
+		names:     map[string]bool{},
+		groups:    map[string]string{},
+		resources: map[string][]*tfResource{},
+	}
+}
+
+// add starts a resource block named after the label, making the name unique
+// within the resource type
+func (t *terraform) add(exportType, resourceType, label, importID string) *tfResource {
+	base := strings.Trim(invalidName.ReplaceAllString(strings.ToLower(label), "_"), "_-")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+
+	name := base
+	for i := 2; t.names[resourceType+"."+name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	t.names[resourceType+"."+name] = true
+
+	r := &tfResource{Type: resourceType, Name: name, ImportID: importID}
+	t.resources[exportType] = append(t.resources[exportType], r)
+	return r
+}
+
+// addFirewallGroup adds the group and its rules
+func (t *terraform) addFirewallGroup(group *govultr.FirewallGroup, rules []govultr.FirewallRule) {
+	label := group.Description
+	if label == "" {
+		label = "firewall_group"
+	}
+
+	g := t.add(typeFirewall, "vultr_firewall_group", label, group.ID)
+	g.attr("description", hclString(group.Description))
+	t.groups[group.ID] = g.address()
+
+	for i := range rules {
+		r := t.add(typeFirewall, "vultr_firewall_rule", g.Name+"_"+rules[i].Protocol+"_"+rules[i].Port,
+			fmt.Sprintf("%s,%d", group.ID, rules[i].ID))
+		r.attr("firewall_group_id", g.address()+".id")
+		r.attr("protocol", hclString(rules[i].Protocol))
+		r.attr("ip_type", hclString(rules[i].IPType))
+		r.attr("subnet", hclString(rules[i].Subnet))
+		r.attr("subnet_size", strconv.Itoa(rules[i].SubnetSize))
+		if rules[i].Port != "" {
+			r.attr("port", hclString(rules[i].Port))
+		}
+		if rules[i].Source != "" {
+			r.attr("source", hclString(rules[i].Source))
+		}
+		if rules[i].Notes != "" {
+			r.attr("notes", hclString(rules[i].Notes))
+		}
+	}
+}
+
+// addInstance adds the instance, referring to its firewall group when the
+// group is exported too
+func (t *terraform) addInstance(instance *govultr.Instance) {
+	r := t.add(typeInstance, "vultr_instance", instance.Label, instance.ID)
+	r.attr("label", hclString(instance.Label))
+	r.attr("region", hclString(instance.Region))
+	r.attr("plan", hclString(instance.Plan))
+
+	switch {
+	case instance.AppID != 0:
+		r.attr("app_id", strconv.Itoa(instance.AppID))
+	case instance.ImageID != "":
+		r.attr("image_id", hclString(instance.ImageID))
+	default:
+		r.attr("os_id", strconv.Itoa(instance.OsID))
+	}
+
+	if instance.Hostname != "" {
+		r.attr("hostname", hclString(instance.Hostname))
+	}
+
+	if len(instance.Tags) > 0 {
+		r.attr("tags", hclList(instance.Tags))
+	}
+
+	if instance.FirewallGroupID != "" {
+		if address, ok := t.groups[instance.FirewallGroupID]; ok {
+			r.attr("firewall_group_id", address+".id")
+		} else {
+			r.attr("firewall_group_id", hclString(instance.FirewallGroupID))
+		}
+	}
+
+	r.attr("enable_ipv6", strconv.FormatBool(slices.Contains(instance.Features, "ipv6")))
+
+	backups := "disabled"
+	if slices.Contains(instance.Features, "auto_backups") {
+		backups = "enabled"
+	}
+	r.attr("backups", hclString(backups))
+}
+
+// addDomain adds the domain and its records.  The SOA record is managed by
+// the domain itself so it is left out
+func (t *terraform) addDomain(domain string, records []govultr.DomainRecord) {
+	d := t.add(typeDNS, "vultr_dns_domain", domain, domain)
+	d.attr("domain", hclString(domain))
+
+	for i := range records {
+		rec := &records[i]
+		if rec.Type == "SOA" {
+			continue
+		}
+
+		label := rec.Name
+		if label == "" {
+			label = "apex"
+		}
+
+		r := t.add(typeDNS, "vultr_dns_record", d.Name+"_"+label+"_"+rec.Type, domain+","+rec.ID)
+		r.attr("domain", d.address()+".id")
+		r.attr("name", hclString(rec.Name))
+		r.attr("type", hclString(rec.Type))
+		r.attr("data", hclString(rec.Data))
+		r.attr("ttl", strconv.Itoa(rec.TTL))
+		if rec.Type == "MX" || rec.Type == "SRV" {
+			r.attr("priority", strconv.Itoa(rec.Priority))
+		}
+	}
+}
+
+// file returns the contents of the .tf file of the resource type
+func (t *terraform) file(exportType string) string {
+	blocks := make([]string, len(t.resources[exportType]))
+	for i, r := range t.resources[exportType] {
+		blocks[i] = r.block()
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// importScript returns a shell script which imports every exported resource
+// into the Terraform state
+func (t *terraform) importScript(types []string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString("# Imports the resources exported by vultr-cli into the Terraform state\n")
+	b.WriteString("set -e\n\n")
+
+	for _, exportType := range types {
+		for _, r := range t.resources[exportType] {
+			fmt.Fprintf(&b, "terraform import %s %s\n", r.address(), utils.ShellQuote(r.ImportID))
+		}
+	}
+
+	return b.String()
+}
@@ -2,10 +2,14 @@
 package firewall
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -57,6 +61,94 @@ var (
 	# Shortened example with aliases
 	vultr-cli fw r get 704ac064-4ff2-49ca-a6e6-88262cca8f8a f31ade4f-2308-4a58-82c6-2d1bae0837b3
 	`
+	groupCloneLong = `Create a new firewall group and copy every rule of the source group into it.
+Instances are not moved to the new group.`
+	groupCloneExample = `
+	# Full example
+	vultr-cli firewall group clone 704ac064-4ff2-49ca-a6e6-88262cca8f8a --description="staging"
+	`
+
+	groupInstancesLong = `List the instances and Kubernetes clusters which use the firewall group.  Load
+balancers have their own firewall rules and are never attached to a group.`
+	groupInstancesExample = `
+	# Full example
+	vultr-cli firewall group instances 704ac064-4ff2-49ca-a6e6-88262cca8f8a
+	`
+
+	ruleApplyLong = `Converge the rules of a firewall group to the rules in a YAML or JSON file.
+Rules missing from the group are created and rules which are not in the file
+are deleted; matching rules are left alone.  New rules are created before any
+rules are deleted so access is not interrupted.  Rules are matched on ip type,
+protocol, port, network and source.  Notes are not compared since rules cannot
+be updated in place.`
+	ruleApplyExample = `
+	# Full example
+	vultr-cli firewall rule apply 704ac064-4ff2-49ca-a6e6-88262cca8f8a -f rules.yaml
+
+	# Show the planned changes without applying them
+	vultr-cli firewall rule apply 704ac064-4ff2-49ca-a6e6-88262cca8f8a -f rules.yaml --dry-run
+
+	# Example file
+	rules:
+	  - protocol: tcp
+	    port: "22"
+	    subnet: 192.0.2.0/24
+	    notes: office ssh
+	  - protocol: tcp
+	    port: "443"
+	    subnet: 0.0.0.0/0
+	  - protocol: tcp
+	    port: "443"
+	    subnet: ::/0
+	  - protocol: icmp
+	    ip_type: v4
+	    subnet: 0.0.0.0
+	    subnet_size: 0
+	`
+
+	ruleAllowLong = `Create the firewall rules for common access in one step.  Rules for both IPv4
+and IPv6 are created and rules which already exist in the group are skipped.
+
+  allow-ssh    TCP port 22 from the public IP of this machine
+  allow-http   TCP port 80 from anywhere
+  allow-https  TCP port 443 from anywhere
+  allow-my-ip  all TCP, UDP and ICMP traffic from the public IP of this machine
+
+Use --cidr to allow other networks instead.  With --ttl the created rules are
+temporary and are removed by 'firewall rule expire' once the time has passed.
+Expired rules are also removed the next time a firewall command which makes
+changes is run.`
+	ruleAllowExample = `
+	# Allow SSH from this machine
+	vultr-cli firewall rule allow-ssh 704ac064-4ff2-49ca-a6e6-88262cca8f8a
+
+	# Allow SSH from the office network
+	vultr-cli firewall rule allow-ssh 704ac064-4ff2-49ca-a6e6-88262cca8f8a --cidr 192.0.2.0/24
+
+	# Open a web server
+	vultr-cli firewall rule allow-http 704ac064-4ff2-49ca-a6e6-88262cca8f8a
+	vultr-cli firewall rule allow-https 704ac064-4ff2-49ca-a6e6-88262cca8f8a
+
+	# Allow this machine for the next two hours
+	vultr-cli firewall rule allow-my-ip 704ac064-4ff2-49ca-a6e6-88262cca8f8a --ttl 2h
+	`
+
+	ruleExpireLong = `Remove the temporary firewall rules created with --ttl whose time has passed.
+The temporary rules are kept in ~/.vultr-cli/firewall-rules.json.  Run the
+command from cron to close access on time, since otherwise expired rules are
+only removed the next time a firewall command which makes changes is run.
+A rule is only deleted while its number still holds the same rule.`
+	ruleExpireExample = `
+	# Remove the expired rules
+	vultr-cli firewall rule expire
+
+	# Remove every temporary rule now
+	vultr-cli firewall rule expire --all
+
+	# crontab entry checking every 5 minutes
+	*/5 * * * * vultr-cli firewall rule expire
+	`
+
 	ruleListLong    = `List all firewall rules in the provided firewall group`
 	ruleListExample = `
 	# Full example
@@ -68,7 +160,7 @@ var (
 )
 
 // NewCmdFirewall provides the CLI command functionality for Firewall
-func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
+func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	o := &options{Base: base}
 
 	cmd := &cobra.Command{
@@ -80,6 +172,9 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			if !o.Base.HasAuth {
 				return errors.New(utils.APIKeyError)
 			}
+			if changesRules(cmd) {
+				o.expireOnRun()
+			}
 			return nil
 		},
 	}
@@ -99,7 +194,7 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			groups, meta, err := o.listGroups()
+			groups, meta, err := utils.ListAll(cmd, o.Base.Options, o.listGroups)
 			if err != nil {
 				return fmt.Errorf("error retrieving firewall group list : %v", err)
 			}
@@ -121,6 +216,7 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(groupList)
 
 	// Group Get
 	groupGet := &cobra.Command{
@@ -233,12 +329,77 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	// Group Clone
+	groupClone := &cobra.Command{
+		Use:     "clone <Firewall Group ID>",
+		Short:   "Create a new firewall group with a copy of every rule",
+		Long:    groupCloneLong,
+		Example: groupCloneExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a firewall group ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			description, errDe := cmd.Flags().GetString("description")
+			if errDe != nil {
+				return fmt.Errorf("error parsing 'description' flag for firewall group clone : %v", errDe)
+			}
+
+			grp, err := o.cloneGroup(description)
+			if err != nil {
+				return fmt.Errorf("error cloning firewall group : %v", err)
+			}
+
+			data := &FirewallGroupPrinter{Group: *grp}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	groupClone.Flags().StringP(
+		"description",
+		"d",
+		"",
+		"(optional) Description of the new firewall group. Defaults to the source description with (copy) appended.",
+	)
+
+	// Group Instances
+	groupInstances := &cobra.Command{
+		Use:     "instances <Firewall Group ID>",
+		Short:   "List the resources attached to a firewall group",
+		Aliases: []string{"usage"},
+		Long:    groupInstancesLong,
+		Example: groupInstancesExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a firewall group ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, err := o.groupUsage()
+			if err != nil {
+				return fmt.Errorf("error retrieving firewall group usage : %v", err)
+			}
+
+			data := &FirewallGroupUsagePrinter{Resources: resources}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
 	group.AddCommand(
 		groupList,
 		groupGet,
 		groupCreate,
 		groupUpdate,
 		groupDelete,
+		groupClone,
+		groupInstances,
 	)
 
 	// Rule
@@ -266,7 +427,7 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			rules, meta, err := o.listRules()
+			rules, meta, err := utils.ListAll(cmd, o.Base.Options, o.listRules)
 			if err != nil {
 				return fmt.Errorf("error retrieving firewall rule list : %v", err)
 			}
@@ -288,6 +449,7 @@ func NewCmdFirewall(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(ruleList)
 
 	// Rule Get
 	ruleGet := &cobra.Command{
@@ -464,11 +626,222 @@ a port or a colon separated port range.`,
 		},
 	}
 
+	// Rule Apply
+	ruleApply := &cobra.Command{
+		Use:     "apply <Firewall Group ID>",
+		Short:   "Converge firewall rules to a file",
+		Long:    ruleApplyLong,
+		Example: ruleApplyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a firewall group ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing 'file' flag for firewall rule apply : %v", errFi)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing 'dry-run' flag for firewall rule apply : %v", errDr)
+			}
+
+			spec := &rulesSpec{}
+			if err := utils.ReadSpecFile(file, spec); err != nil {
+				return err
+			}
+
+			desired, errSp := spec.toReqs()
+			if errSp != nil {
+				return fmt.Errorf("error in firewall rules file : %v", errSp)
+			}
+
+			changes, err := o.applyRules(desired, dryRun)
+			if err != nil {
+				return fmt.Errorf("error applying firewall rules : %v", err)
+			}
+
+			if len(changes) == 0 {
+				o.Base.Printer.Display(printer.Info("firewall rules are up to date"), nil)
+				return nil
+			}
+
+			data := &FirewallChangesPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d changes failed to apply", failed, len(changes))
+			}
+
+			return nil
+		},
+	}
+
+	ruleApply.Flags().StringP("file", "f", "", "path to a YAML or JSON rules file or - to read from stdin")
+	if err := ruleApply.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking firewall rule apply 'file' flag required : %v", err)
+		os.Exit(1)
+	}
+	ruleApply.Flags().Bool("dry-run", false, "show the planned changes without applying them")
+
+	// Rule Allow Helpers
+	allowCmd := func(use, short string, allow quickAllow) *cobra.Command {
+		c := &cobra.Command{
+			Use:     use + " <Firewall Group ID>",
+			Short:   short,
+			Long:    ruleAllowLong,
+			Example: ruleAllowExample,
+			Args: func(cmd *cobra.Command, args []string) error {
+				if len(args) < 1 {
+					return errors.New("please provide a firewall group ID")
+				}
+				return nil
+			},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cidrs, errCi := cmd.Flags().GetStringSlice("cidr")
+				if errCi != nil {
+					return fmt.Errorf("error parsing 'cidr' flag for firewall rule %s : %v", use, errCi)
+				}
+
+				noIPv6, errV6 := cmd.Flags().GetBool("no-ipv6")
+				if errV6 != nil {
+					return fmt.Errorf("error parsing 'no-ipv6' flag for firewall rule %s : %v", use, errV6)
+				}
+
+				notes, errNo := cmd.Flags().GetString("notes")
+				if errNo != nil {
+					return fmt.Errorf("error parsing 'notes' flag for firewall rule %s : %v", use, errNo)
+				}
+
+				ttl, errTt := cmd.Flags().GetDuration("ttl")
+				if errTt != nil {
+					return fmt.Errorf("error parsing 'ttl' flag for firewall rule %s : %v", use, errTt)
+				}
+
+				if ttl < 0 {
+					return errors.New("ttl must not be negative")
+				}
+
+				if len(cidrs) == 0 {
+					var err error
+					if cidrs, err = allow.defaultSources(o.Base.Context, noIPv6); err != nil {
+						return fmt.Errorf("error detecting public ip : %v", err)
+					}
+				}
+
+				reqs, err := allow.rules(cidrs, notes)
+				if err != nil {
+					return err
+				}
+
+				rules, err := o.createMissingRules(reqs)
+				if err != nil {
+					return fmt.Errorf("error creating firewall rules : %v", err)
+				}
+
+				if ttl > 0 {
+					expires := time.Now().Add(ttl)
+					n, err := trackRules(o.Base.Args[0], rules, reqs, expires)
+					if err != nil {
+						return err
+					}
+					if n > 0 {
+						fmt.Fprintf(os.Stderr, "the temporary rules expire at %s\n", expires.Format(time.RFC3339))
+					}
+				}
+
+				if len(rules) == 0 {
+					o.Base.Printer.Display(printer.Info("firewall rules already exist"), nil)
+					return nil
+				}
+
+				data := &FirewallRulesPrinter{Rules: rules}
+				o.Base.Printer.Display(data, nil)
+
+				return nil
+			},
+		}
+
+		c.Flags().StringSlice("cidr", []string{}, "(optional) networks in CIDR notation to allow instead of the default")
+		c.Flags().Bool("no-ipv6", false, "(optional) only create IPv4 rules")
+		c.Flags().StringP("notes", "n", allow.notes, "(optional) notes for the created rules")
+		c.Flags().Duration("ttl", 0, "(optional) remove the created rules after this long, such as 2h")
+
+		return c
+	}
+
+	ruleAllowSSH := allowCmd("allow-ssh", "Allow SSH from this machine", quickAllow{
+		protocols: []string{"tcp"},
+		port:      "22",
+		mine:      true,
+		notes:     "ssh",
+	})
+	ruleAllowHTTP := allowCmd("allow-http", "Allow HTTP from anywhere", quickAllow{
+		protocols: []string{"tcp"},
+		port:      "80",
+		notes:     "http",
+	})
+	ruleAllowHTTPS := allowCmd("allow-https", "Allow HTTPS from anywhere", quickAllow{
+		protocols: []string{"tcp"},
+		port:      "443",
+		notes:     "https",
+	})
+	ruleAllowMyIP := allowCmd("allow-my-ip", "Allow all traffic from this machine", quickAllow{
+		protocols: []string{"tcp", "udp", "icmp"},
+		port:      "1:65535",
+		mine:      true,
+		notes:     "my ip",
+	})
+
+	// Rule Expire
+	ruleExpire := &cobra.Command{
+		Use:     "expire",
+		Short:   "Remove the expired temporary firewall rules",
+		Long:    ruleExpireLong,
+		Example: ruleExpireExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, errAl := cmd.Flags().GetBool("all")
+			if errAl != nil {
+				return fmt.Errorf("error parsing 'all' flag for firewall rule expire : %v", errAl)
+			}
+
+			rules, err := o.expireRules(all)
+			if err != nil {
+				return fmt.Errorf("error removing expired firewall rules : %v", err)
+			}
+
+			if len(rules) == 0 {
+				o.Base.Printer.Display(printer.Info("no temporary firewall rules have expired"), nil)
+				return nil
+			}
+
+			data := &FirewallExpiredRulesPrinter{Rules: rules}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d expired rules could not be removed", failed, len(rules))
+			}
+
+			return nil
+		},
+	}
+
+	ruleExpire.Flags().Bool("all", false, "(optional) remove every temporary rule, including those which have not expired")
+
 	rule.AddCommand(
 		ruleList,
 		ruleGet,
 		ruleCreate,
 		ruleDelete,
+		ruleApply,
+		ruleAllowSSH,
+		ruleAllowHTTP,
+		ruleAllowHTTPS,
+		ruleAllowMyIP,
+		ruleExpire,
 	)
 
 	cmd.AddCommand(
@@ -476,6 +849,9 @@ a port or a colon separated port range.`,
 		rule,
 	)
 
+	utils.RegisterCompletion(cmd, "<Firewall Group ID>", utils.CompleteFirewallGroups(o.Base))
+	utils.RegisterResolver(cmd, "<Firewall Group ID>", utils.ResolveFirewallGroups(o.Base))
+
 	return cmd
 }
 
@@ -544,3 +920,285 @@ func (o *options) deleteRule() error {
 	}
 	return o.Base.Client.FirewallRule.Delete(o.Base.Context, o.Base.Args[0], id)
 }
+
+// listAllRules retrieves every rule of the firewall group
+func (o *options) listAllRules(groupID string) ([]govultr.FirewallRule, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallRule, *govultr.Meta, error) {
+		rules, meta, _, err := o.Base.Client.FirewallRule.List(o.Base.Context, groupID, opts)
+		return rules, meta, err
+	})
+}
+
+// groupUsage returns the instances and kubernetes clusters using the firewall
+// group in the args
+func (o *options) groupUsage() ([]groupResource, error) {
+	var resources []groupResource
+
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range instances {
+		if instances[i].FirewallGroupID != o.Base.Args[0] {
+			continue
+		}
+
+		resources = append(resources, groupResource{
+			Type:   "instance",
+			ID:     instances[i].ID,
+			Label:  instances[i].Label,
+			Region: instances[i].Region,
+			IP:     instances[i].MainIP,
+		})
+	}
+
+	clusters, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Cluster, *govultr.Meta, error) {
+		clusters, meta, _, err := o.Base.Client.Kubernetes.ListClusters(o.Base.Context, opts)
+		return clusters, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range clusters {
+		if clusters[i].FirewallGroupID != o.Base.Args[0] {
+			continue
+		}
+
+		resources = append(resources, groupResource{
+			Type:   "kubernetes",
+			ID:     clusters[i].ID,
+			Label:  clusters[i].Label,
+			Region: clusters[i].Region,
+			IP:     clusters[i].IP,
+		})
+	}
+
+	return resources, nil
+}
+
+// cloneGroup creates a new group holding a copy of the rules of the group in
+// the args
+func (o *options) cloneGroup(description string) (*govultr.FirewallGroup, error) {
+	src, err := o.getGroup()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := o.listAllRules(src.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if description == "" {
+		description = strings.TrimSpace(src.Description + " (copy)")
+	}
+
+	o.GroupReq = &govultr.FirewallGroupReq{Description: description}
+	grp, err := o.createGroup()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		req := utils.FirewallRuleToReq(&rules[i])
+		if _, _, err := o.Base.Client.FirewallRule.Create(o.Base.Context, grp.ID, req); err != nil {
+			return nil, fmt.Errorf("firewall group %s was created but copying rule %d failed : %v", grp.ID, rules[i].ID, err)
+		}
+	}
+
+	grp, _, err = o.Base.Client.FirewallGroup.Get(o.Base.Context, grp.ID)
+	return grp, err
+}
+
+// createMissingRules creates the rules which do not already exist in the group
+// and returns the created rules
+func (o *options) createMissingRules(reqs []govultr.FirewallRuleReq) ([]govultr.FirewallRule, error) {
+	live, err := o.listAllRules(o.Base.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(live))
+	for i := range live {
+		existing[utils.FirewallRuleKey(utils.FirewallRuleToReq(&live[i]))] = true
+	}
+
+	var created []govultr.FirewallRule
+	for i := range reqs {
+		if existing[utils.FirewallRuleKey(&reqs[i])] {
+			continue
+		}
+
+		rule, _, err := o.Base.Client.FirewallRule.Create(o.Base.Context, o.Base.Args[0], &reqs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		existing[utils.FirewallRuleKey(&reqs[i])] = true
+		created = append(created, *rule)
+	}
+
+	return created, nil
+}
+
+// applyRules plans the rules to create and delete to converge the group to
+// the desired rules and, unless it is a dry run, applies them.  Creates are
+// applied before deletes
+func (o *options) applyRules(desired []govultr.FirewallRuleReq, dryRun bool) ([]ruleChange, error) {
+	live, err := o.listAllRules(o.Base.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]bool, len(live))
+	var changes []ruleChange
+	for i := range desired {
+		found := false
+		for j := range live {
+			if !used[j] && utils.FirewallRuleKey(&desired[i]) == utils.FirewallRuleKey(utils.FirewallRuleToReq(&live[j])) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			changes = append(changes, ruleChange{Action: "create", Rule: desired[i], Status: "planned"})
+		}
+	}
+
+	for i := range live {
+		if !used[i] {
+			changes = append(changes, ruleChange{
+				Action: "delete",
+				RuleID: live[i].ID,
+				Rule:   *utils.FirewallRuleToReq(&live[i]),
+				Status: "planned",
+			})
+		}
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	for i := range changes {
+		var err error
+		if changes[i].Action == "create" {
+			var rule *govultr.FirewallRule
+			rule, _, err = o.Base.Client.FirewallRule.Create(o.Base.Context, o.Base.Args[0], &changes[i].Rule)
+			if err == nil {
+				changes[i].RuleID = rule.ID
+			}
+		} else {
+			err = o.Base.Client.FirewallRule.Delete(o.Base.Context, o.Base.Args[0], changes[i].RuleID)
+		}
+
+		if err != nil {
+			changes[i].Status = "failed"
+			changes[i].Error = err.Error()
+			continue
+		}
+
+		changes[i].Status = "done"
+	}
+
+	return changes, nil
+}
+
+// ======================================
+
+// quickAllow describes the rules created by one of the allow helpers
+type quickAllow struct {
+	protocols []string
+	port      string
+	mine      bool
+	notes     string
+}
+
+// defaultSources returns the networks allowed when no --cidr is given, which
+// is the public IP of this machine or anywhere
+func (q *quickAllow) defaultSources(ctx context.Context, noIPv6 bool) ([]string, error) {
+	if !q.mine {
+		if noIPv6 {
+			return []string{"0.0.0.0/0"}, nil
+		}
+		return []string{"0.0.0.0/0", "::/0"}, nil
+	}
+
+	ip, err := utils.PublicIP(ctx, utils.PublicIPv4URL, false)
+	if err != nil {
+		return nil, err
+	}
+	sources := []string{ip + "/32"}
+
+	// not every network has IPv6 so a failed lookup is not an error
+	if !noIPv6 {
+		if ip6, err := utils.PublicIP(ctx, utils.PublicIPv6URL, true); err == nil {
+			sources = append(sources, ip6+"/128")
+		}
+	}
+
+	return sources, nil
+}
+
+// rules returns the create requests allowing the sources
+func (q *quickAllow) rules(sources []string, notes string) ([]govultr.FirewallRuleReq, error) {
+	var reqs []govultr.FirewallRuleReq
+	for i := range sources {
+		ip, network, err := net.ParseCIDR(sources[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %s", sources[i])
+		}
+
+		ipType := "v4"
+		if ip.To4() == nil {
+			ipType = "v6"
+		}
+
+		size, _ := network.Mask.Size()
+		for _, protocol := range q.protocols {
+			req := govultr.FirewallRuleReq{
+				IPType:     ipType,
+				Protocol:   protocol,
+				Subnet:     network.IP.String(),
+				SubnetSize: size,
+				Notes:      notes,
+			}
+
+			if protocol != "icmp" {
+				req.Port = q.port
+			}
+
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs, nil
+}
+
+// ======================================
+
+// rulesSpec is the declarative rule set accepted by rule apply
+type rulesSpec struct {
+	Rules []utils.FirewallRuleSpec `yaml:"rules"`
+}
+
+// toReqs validates the rules and converts them to rule create requests
+func (r *rulesSpec) toReqs() ([]govultr.FirewallRuleReq, error) {
+	reqs := make([]govultr.FirewallRuleReq, len(r.Rules))
+	for i := range r.Rules {
+		req, err := r.Rules[i].ToReq()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d : %v", i+1, err)
+		}
+		reqs[i] = *req
+	}
+
+	return reqs, nil
+}
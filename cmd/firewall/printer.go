@@ -1,7 +1,9 @@
 package firewall
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -221,3 +223,228 @@ func (f *FirewallRulePrinter) Data() [][]string {
 func (f *FirewallRulePrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// groupResource is a resource which uses a firewall group
+type groupResource struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Label  string `json:"label"`
+	Region string `json:"region"`
+	IP     string `json:"ip"`
+}
+
+// FirewallGroupUsagePrinter ...
+type FirewallGroupUsagePrinter struct {
+	Resources []groupResource `json:"resources"`
+}
+
+// JSON ...
+func (f *FirewallGroupUsagePrinter) JSON() []byte {
+	return printer.MarshalObject(f, "json")
+}
+
+// YAML ...
+func (f *FirewallGroupUsagePrinter) YAML() []byte {
+	return printer.MarshalObject(f, "yaml")
+}
+
+// Columns ...
+func (f *FirewallGroupUsagePrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"ID",
+		"LABEL",
+		"REGION",
+		"IP",
+	}}
+}
+
+// Data ...
+func (f *FirewallGroupUsagePrinter) Data() [][]string {
+	if len(f.Resources) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range f.Resources {
+		data = append(data, []string{
+			f.Resources[i].Type,
+			f.Resources[i].ID,
+			f.Resources[i].Label,
+			f.Resources[i].Region,
+			f.Resources[i].IP,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (f *FirewallGroupUsagePrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// ruleChange is a single step of the plan which converges a firewall group to
+// a rules file
+type ruleChange struct {
+	Action string                  `json:"action"`
+	RuleID int                     `json:"rule_number,omitempty"`
+	Rule   govultr.FirewallRuleReq `json:"rule"`
+	Status string                  `json:"status"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// FirewallChangesPrinter ...
+type FirewallChangesPrinter struct {
+	Changes []ruleChange `json:"changes"`
+}
+
+// JSON ...
+func (f *FirewallChangesPrinter) JSON() []byte {
+	return printer.MarshalObject(f, "json")
+}
+
+// YAML ...
+func (f *FirewallChangesPrinter) YAML() []byte {
+	return printer.MarshalObject(f, "yaml")
+}
+
+// Columns ...
+func (f *FirewallChangesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"RULE NUMBER",
+		"TYPE",
+		"PROTOCOL",
+		"PORT",
+		"NETWORK",
+		"SOURCE",
+		"NOTES",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (f *FirewallChangesPrinter) Data() [][]string {
+	if len(f.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range f.Changes {
+		id := ""
+		if f.Changes[i].RuleID != 0 {
+			id = strconv.Itoa(f.Changes[i].RuleID)
+		}
+
+		status := f.Changes[i].Status
+		if f.Changes[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, f.Changes[i].Error)
+		}
+
+		data = append(data, []string{
+			f.Changes[i].Action,
+			id,
+			f.Changes[i].Rule.IPType,
+			f.Changes[i].Rule.Protocol,
+			f.Changes[i].Rule.Port,
+			utils.FormatFirewallNetwork(f.Changes[i].Rule.Subnet, f.Changes[i].Rule.SubnetSize),
+			utils.GetFirewallSource(f.Changes[i].Rule.Source),
+			f.Changes[i].Rule.Notes,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (f *FirewallChangesPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of changes which could not be applied
+func (f *FirewallChangesPrinter) failed() int {
+	n := 0
+	for i := range f.Changes {
+		if f.Changes[i].Status == "failed" {
+			n++
+		}
+	}
+	return n
+}
+
+// ======================================
+
+// FirewallExpiredRulesPrinter ...
+type FirewallExpiredRulesPrinter struct {
+	Rules []expiredRule `json:"rules"`
+}
+
+// JSON ...
+func (f *FirewallExpiredRulesPrinter) JSON() []byte {
+	return printer.MarshalObject(f, "json")
+}
+
+// YAML ...
+func (f *FirewallExpiredRulesPrinter) YAML() []byte {
+	return printer.MarshalObject(f, "yaml")
+}
+
+// Columns ...
+func (f *FirewallExpiredRulesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"GROUP ID",
+		"RULE NUMBER",
+		"TYPE",
+		"PROTOCOL",
+		"PORT",
+		"NETWORK",
+		"EXPIRES",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (f *FirewallExpiredRulesPrinter) Data() [][]string {
+	var data [][]string
+	for i := range f.Rules {
+		status := f.Rules[i].Status
+		if f.Rules[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, f.Rules[i].Error)
+		}
+
+		data = append(data, []string{
+			f.Rules[i].GroupID,
+			strconv.Itoa(f.Rules[i].RuleID),
+			f.Rules[i].Rule.IPType,
+			f.Rules[i].Rule.Protocol,
+			f.Rules[i].Rule.Port,
+			utils.FormatFirewallNetwork(f.Rules[i].Rule.Subnet, f.Rules[i].Rule.SubnetSize),
+			f.Rules[i].Expires.Local().Format(time.RFC3339),
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (f *FirewallExpiredRulesPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of rules which could not be removed
+func (f *FirewallExpiredRulesPrinter) failed() int {
+	n := 0
+	for i := range f.Rules {
+		if f.Rules[i].Status == "failed" {
+			n++
+		}
+	}
+	return n
+}
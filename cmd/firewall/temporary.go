@@ -0,0 +1,264 @@
+package firewall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	tempRulesDirPermission  = 0o700
+	tempRulesFilePermission = 0o600
+)
+
+// tempRule is a firewall rule created with --ttl which is removed by rule
+// expire once it expires
+type tempRule struct {
+	GroupID string                  `json:"group_id"`
+	RuleID  int                     `json:"rule_number"`
+	Rule    govultr.FirewallRuleReq `json:"rule"`
+	Expires time.Time               `json:"expires"`
+}
+
+// expiredRule is the outcome of removing a temporary rule
+type expiredRule struct {
+	tempRule `yaml:",inline"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// tempRulesPath returns the file the temporary rules are kept in
+func tempRulesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".vultr-cli", "firewall-rules.json"), nil
+}
+
+// loadTempRules reads the temporary rules, which are empty when none were
+// created yet
+func loadTempRules() ([]tempRule, error) {
+	path, err := tempRulesPath()
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the temporary rules file : %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading temporary rules : %v", err)
+	}
+
+	var rules []tempRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("error reading temporary rules %s : %v", path, err)
+	}
+
+	return rules, nil
+}
+
+// saveTempRules writes the temporary rules through a temporary file so an
+// interrupted write doesn't lose track of rules which still need removing
+func saveTempRules(rules []tempRule) error {
+	path, err := tempRulesPath()
+	if err != nil {
+		return fmt.Errorf("unable to find the temporary rules file : %v", err)
+	}
+
+	if rules == nil {
+		rules = []tempRule{}
+	}
+
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error saving temporary rules : %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), tempRulesDirPermission); err != nil {
+		return fmt.Errorf("error saving temporary rules : %v", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, tempRulesFilePermission); err != nil {
+		return fmt.Errorf("error saving temporary rules : %v", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error saving temporary rules : %v", err)
+	}
+
+	return nil
+}
+
+// trackRules records the created rules of the group as temporary.  Rules of
+// the request which already existed as temporary rules have their expiry
+// pushed out instead, so running the command again keeps access open.  The
+// number of temporary rules is returned
+func trackRules(
+	groupID string,
+	created []govultr.FirewallRule,
+	reqs []govultr.FirewallRuleReq,
+	expires time.Time,
+) (int, error) {
+	rules, err := loadTempRules()
+	if err != nil {
+		return 0, err
+	}
+
+	requested := make(map[string]bool, len(reqs))
+	for i := range reqs {
+		requested[utils.FirewallRuleKey(&reqs[i])] = true
+	}
+
+	n := len(created)
+	for i := range rules {
+		if rules[i].GroupID != groupID || !requested[utils.FirewallRuleKey(&rules[i].Rule)] {
+			continue
+		}
+		if rules[i].Expires.Before(expires) {
+			rules[i].Expires = expires
+		}
+		n++
+	}
+
+	for i := range created {
+		rules = append(rules, tempRule{
+			GroupID: groupID,
+			RuleID:  created[i].ID,
+			Rule:    *utils.FirewallRuleToReq(&created[i]),
+			Expires: expires,
+		})
+	}
+
+	return n, saveTempRules(rules)
+}
+
+// expireRules deletes the temporary rules which have expired, or all of them
+// when all is set.  Rules which could not be deleted are kept so they are
+// tried again the next time
+func (o *options) expireRules(all bool) ([]expiredRule, error) {
+	rules, err := loadTempRules()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var kept []tempRule
+	var expired []expiredRule
+	for i := range rules {
+		if !all && rules[i].Expires.After(now) {
+			kept = append(kept, rules[i])
+			continue
+		}
+
+		result := expiredRule{tempRule: rules[i]}
+		if result.Status, err = o.deleteTempRule(&rules[i]); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			kept = append(kept, rules[i])
+		}
+
+		expired = append(expired, result)
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	if err := saveTempRules(kept); err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+// deleteTempRule deletes the temporary rule, once it is sure the rule number
+// still holds the rule which was created since numbers are reused when rules
+// are deleted.  A rule or group which is already gone, or a number which now
+// holds another rule, leaves nothing to remove
+func (o *options) deleteTempRule(rule *tempRule) (string, error) {
+	ctx, apiErr := cli.WithAPIError(o.Base.Context)
+
+	current, _, err := o.Base.Client.FirewallRule.Get(ctx, rule.GroupID, rule.RuleID)
+	if err != nil {
+		if failure := apiErr(); failure != nil && failure.Status == http.StatusNotFound {
+			return "gone", nil
+		}
+		return "", err
+	}
+
+	if current == nil || utils.FirewallRuleKey(utils.FirewallRuleToReq(current)) != utils.FirewallRuleKey(&rule.Rule) {
+		return "gone", nil
+	}
+
+	if err := o.Base.Client.FirewallRule.Delete(ctx, rule.GroupID, rule.RuleID); err != nil {
+		if failure := apiErr(); failure != nil && failure.Status == http.StatusNotFound {
+			return "gone", nil
+		}
+		return "", err
+	}
+
+	return "done", nil
+}
+
+// changesRules reports whether the command makes changes to firewall groups
+// or rules, which is when the expired temporary rules are removed as well.
+// Reads, such as those repeated by --watch, and dry runs leave them alone
+func changesRules(cmd *cobra.Command) bool {
+	if dryRun, err := cmd.Flags().GetBool("dry-run"); viper.GetBool("dry-run") || (err == nil && dryRun) {
+		return false
+	}
+
+	switch cmd.Name() {
+	case "list", "get", "instances", "expire":
+		return false
+	}
+
+	return true
+}
+
+// expireOnRun removes the expired temporary rules before a firewall command
+// runs, so rules expire even when rule expire isn't run from cron.  Failures
+// are only reported since they don't concern the command being run
+func (o *options) expireOnRun() {
+	rules, err := loadTempRules()
+	if err != nil || len(rules) == 0 {
+		return
+	}
+
+	due := false
+	for i := range rules {
+		due = due || !rules[i].Expires.After(time.Now())
+	}
+	if !due {
+		return
+	}
+
+	expired, err := o.expireRules(false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to remove expired firewall rules : %v\n", err)
+		return
+	}
+
+	for i := range expired {
+		if expired[i].Error != "" {
+			fmt.Fprintf(os.Stderr, "unable to remove expired firewall rule %d of group %s : %s\n",
+				expired[i].RuleID, expired[i].GroupID, expired[i].Error)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "removed expired firewall rule %d of group %s\n", expired[i].RuleID, expired[i].GroupID)
+	}
+}
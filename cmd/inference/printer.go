@@ -1,6 +1,7 @@
 package inference
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -131,6 +132,7 @@ func (u *UsagePrinter) Data() [][]string {
 		[]string{"CURRENT TOKENS", strconv.FormatInt(int64(u.Usage.Chat.CurrentTokens), 10)},
 		[]string{"MONTHLY ALLOTMENT", strconv.FormatInt(int64(u.Usage.Chat.MonthlyAllotment), 10)},
 		[]string{"OVERAGE", strconv.FormatInt(int64(u.Usage.Chat.Overage), 10)},
+		[]string{"ALLOTMENT USED", allotmentUsed(&u.Usage.Chat)},
 		[]string{" "},
 		[]string{"AUDIO USAGE"},
 		[]string{"TTS CHARACTERS", strconv.FormatInt(int64(u.Usage.Audio.TTSCharacters), 10)},
@@ -144,3 +146,13 @@ func (u *UsagePrinter) Data() [][]string {
 func (u *UsagePrinter) Paging() [][]string {
 	return nil
 }
+
+// allotmentUsed returns the share of the monthly token allotment consumed so
+// far
+func allotmentUsed(chat *govultr.InferenceChatUsage) string {
+	if chat.MonthlyAllotment == 0 {
+		return "---"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(chat.CurrentTokens)/float64(chat.MonthlyAllotment)*100) //nolint:mnd
+}
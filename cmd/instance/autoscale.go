@@ -0,0 +1,340 @@
+package instance
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	defaultAutoscaleInterval = 5 * time.Minute
+	defaultAutoscaleCooldown = 10 * time.Minute
+
+	// autoscaleMetricTimeout is how long the metric command gets for each
+	// instance
+	autoscaleMetricTimeout = 30 * time.Second
+
+	autoscaleNone = "none"
+	autoscaleOut  = "scale out"
+	autoscaleIn   = "scale in"
+)
+
+// autoscaleConfig is the group of tagged instances and the target it is
+// scaled to
+type autoscaleConfig struct {
+	Tag           string
+	Min           int
+	Max           int
+	Metric        string
+	MetricCommand []string
+	Target        float64
+	Snapshot      string
+	Region        string
+	Plan          string
+	Cooldown      time.Duration
+	Run           bool
+}
+
+// autoscaleCheck is the state of the group and the change made to it
+type autoscaleCheck struct {
+	Tag       string   `json:"tag"`
+	Instances int      `json:"instances"`
+	Ready     int      `json:"ready"`
+	Metric    string   `json:"metric"`
+	Average   *float64 `json:"average"`
+	Target    float64  `json:"target"`
+	Desired   int      `json:"desired"`
+	Action    string   `json:"action"`
+	Run       bool     `json:"run"`
+	Created   []string `json:"created"`
+	Destroyed []string `json:"destroyed"`
+	Failed    int      `json:"failed"`
+}
+
+// autoscale measures the tagged instances and works out how many are needed
+// to bring the average of the metric to the target.  Instances which are
+// still being set up or were created within the cooldown aren't measured, and
+// while there are any the group is only kept within --min and --max, so new
+// instances get the chance to take load before the group is scaled again.
+// The change is only made with --run
+func (o *options) autoscale(c *autoscaleConfig) (*autoscaleCheck, error) {
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		opts.Tag = c.Tag
+		insts, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return insts, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instances tagged %s : %v", c.Tag, err)
+	}
+
+	// newest first, which are the first to go when scaling in
+	slices.SortStableFunc(instances, func(a, b govultr.Instance) int {
+		return strings.Compare(b.DateCreated, a.DateCreated)
+	})
+
+	check := &autoscaleCheck{
+		Tag:       c.Tag,
+		Instances: len(instances),
+		Metric:    c.Metric,
+		Target:    c.Target,
+		Action:    autoscaleNone,
+		Run:       c.Run,
+		Created:   []string{},
+		Destroyed: []string{},
+	}
+
+	var ready []govultr.Instance
+	for i := range instances {
+		if instanceReady(&instances[i], c.Cooldown) {
+			ready = append(ready, instances[i])
+		}
+	}
+	check.Ready = len(ready)
+
+	check.Desired = len(instances)
+	if len(ready) > 0 {
+		check.Average = o.autoscaleAverage(c, ready)
+	}
+	if check.Average != nil && len(ready) == len(instances) {
+		check.Desired = int(math.Ceil(float64(len(ready)) * *check.Average / c.Target))
+	}
+	check.Desired = max(c.Min, min(c.Max, check.Desired))
+
+	switch {
+	case check.Desired > len(instances):
+		check.Action = autoscaleOut
+	case check.Desired < len(instances):
+		check.Action = autoscaleIn
+	}
+
+	if c.Run {
+		o.autoscaleApply(c, check, instances, ready)
+	}
+
+	return check, nil
+}
+
+// autoscaleApply creates or destroys instances to bring the group to the
+// desired size.  The instances are sorted newest first
+func (o *options) autoscaleApply(c *autoscaleConfig, check *autoscaleCheck, instances, ready []govultr.Instance) {
+	var template *govultr.Instance
+	switch {
+	case len(ready) > 0:
+		template = &ready[0]
+	case len(instances) > 0:
+		template = &instances[0]
+	}
+
+	for n := len(instances); n < check.Desired; n++ {
+		id, err := o.autoscaleCreate(c, template)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to create an instance tagged %s : %v\n", c.Tag, err)
+			check.Failed++
+			continue
+		}
+		check.Created = append(check.Created, id)
+	}
+
+	for i := 0; i < len(instances)-check.Desired; i++ {
+		if err := o.Base.Client.Instance.Delete(o.Base.Context, instances[i].ID); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to destroy instance %s : %v\n", instances[i].ID, err)
+			check.Failed++
+			continue
+		}
+		check.Destroyed = append(check.Destroyed, instances[i].ID)
+	}
+}
+
+// instanceReady reports whether the instance is running and old enough for
+// its metric to count
+func instanceReady(instance *govultr.Instance, cooldown time.Duration) bool {
+	if instance.Status != "active" || instance.PowerStatus != "running" || instance.ServerStatus != "ok" {
+		return false
+	}
+
+	created, err := time.Parse(time.RFC3339, instance.DateCreated)
+	return err != nil || time.Since(created) >= cooldown
+}
+
+// autoscaleAverage returns the average of the metric over the instances.
+// Instances whose metric can't be read are left out and reported, and nil is
+// returned when none could be read
+func (o *options) autoscaleAverage(c *autoscaleConfig, instances []govultr.Instance) *float64 {
+	var sum float64
+	n := 0
+	for i := range instances {
+		value, err := o.readMetric(c, &instances[i])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to read %s of instance %s : %v\n", c.Metric, instances[i].ID, err)
+			continue
+		}
+		sum += value
+		n++
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	average := sum / float64(n)
+	return &average
+}
+
+// readMetric runs the metric command for the instance, which prints the
+// current value of the metric
+func (o *options) readMetric(c *autoscaleConfig, instance *govultr.Instance) (float64, error) {
+	ctx, cancel := context.WithTimeout(o.Base.Context, autoscaleMetricTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.MetricCommand[0], c.MetricCommand[1:]...)
+	cmd.Env = append(os.Environ(),
+		"VULTR_METRIC="+c.Metric,
+		"VULTR_INSTANCE_ID="+instance.ID,
+		"VULTR_INSTANCE_LABEL="+instance.Label,
+		"VULTR_INSTANCE_IP="+instance.MainIP,
+		"VULTR_INSTANCE_INTERNAL_IP="+instance.InternalIP,
+	)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(stdout.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("the metric command printed %q rather than a number", strings.TrimSpace(stdout.String()))
+	}
+
+	return value, nil
+}
+
+// autoscaleCreate creates an instance from the snapshot.  The region, plan,
+// firewall group, tags and VPCs are taken from the template, which is the
+// newest ready instance of the group, unless given as flags
+func (o *options) autoscaleCreate(c *autoscaleConfig, template *govultr.Instance) (string, error) {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	label := fmt.Sprintf("%s-%s", c.Tag, hex.EncodeToString(suffix))
+
+	req := &govultr.InstanceCreateReq{
+		Region:     c.Region,
+		Plan:       c.Plan,
+		SnapshotID: c.Snapshot,
+		Label:      label,
+		Hostname:   label,
+		Tags:       []string{c.Tag},
+	}
+
+	if template != nil {
+		if req.Region == "" {
+			req.Region = template.Region
+		}
+		if req.Plan == "" {
+			req.Plan = template.Plan
+		}
+		req.FirewallGroupID = template.FirewallGroupID
+		if len(template.Tags) > 0 {
+			req.Tags = template.Tags
+		}
+		if template.V6MainIP != "" {
+			req.EnableIPv6 = govultr.BoolToBoolPtr(true)
+		}
+
+		vpcs, _, _, err := o.Base.Client.Instance.ListVPCInfo(o.Base.Context, template.ID, &govultr.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving the VPCs of instance %s : %v", template.ID, err)
+		}
+		for i := range vpcs {
+			req.AttachVPC = append(req.AttachVPC, vpcs[i].ID)
+		}
+	}
+
+	if req.Region == "" || req.Plan == "" {
+		return "", errors.New("no instance has the tag yet, please provide a region and plan")
+	}
+
+	instance, _, err := o.Base.Client.Instance.Create(o.Base.Context, req)
+	if err != nil {
+		return "", err
+	}
+
+	return instance.ID, nil
+}
+
+// describe summarizes the check for the log of autoscale when it keeps
+// running
+func (a *autoscaleCheck) describe() string {
+	average := "unknown"
+	if a.Average != nil {
+		average = strconv.FormatFloat(*a.Average, 'f', utils.FloatPrecision, 64)
+	}
+
+	msg := fmt.Sprintf(
+		"%s: %d instances, %d ready, %s %s of %s",
+		a.Tag,
+		a.Instances,
+		a.Ready,
+		a.Metric,
+		average,
+		strconv.FormatFloat(a.Target, 'f', utils.FloatPrecision, 64),
+	)
+
+	switch {
+	case a.Action == autoscaleNone:
+		return fmt.Sprintf("%s, keeping %d", msg, a.Desired)
+	case !a.Run:
+		return fmt.Sprintf("%s, would %s to %d", msg, a.Action, a.Desired)
+	}
+
+	msg = fmt.Sprintf("%s, %s to %d", msg, a.Action, a.Desired)
+	if len(a.Created) > 0 {
+		msg += ", created " + strings.Join(a.Created, ", ")
+	}
+	if len(a.Destroyed) > 0 {
+		msg += ", destroyed " + strings.Join(a.Destroyed, ", ")
+	}
+	if a.Failed > 0 {
+		msg += fmt.Sprintf(", %d failed", a.Failed)
+	}
+
+	return msg
+}
+
+// autoscaleWatch checks the group every interval until interrupted
+func (o *options) autoscaleWatch(c *autoscaleConfig, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		check, err := o.autoscale(c)
+		if err != nil {
+			// keep going through temporary API errors
+			fmt.Fprintf(os.Stderr, "%s %v\n", time.Now().Format(time.RFC3339), err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format(time.RFC3339), check.describe())
+		}
+
+		select {
+		case <-o.Base.Context.Done():
+			return o.Base.Context.Err()
+		case <-ticker.C:
+		}
+	}
+}
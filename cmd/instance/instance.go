@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/ip"
+	"github.com/vultr/vultr-cli/v3/cmd/marketplace"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/userdata"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
@@ -49,6 +51,16 @@ var (
 	# Full example with assigned ssh keys
 	vultr-cli instance create --region="ewr" --plan="vc2-2c-4gb" --os=1743 \
 		--ssh-keys="a14b6539-5583-41e8-a035-c07a76897f2b,be624232-56c7-4d5c-bf87-9bdaae7a1fbd"
+
+	# Full example with a marketplace app and its variables
+	vultr-cli instance create --region="ewr" --plan="vc2-2c-4gb" --image="openlitespeed-wordpress" \
+		--marketplace-variable="wp_admin_email=admin@example.com"
+
+	# Pick the region, plan, image, SSH keys and networking from prompts
+	vultr-cli instance create --interactive
+
+	# Wait until the instance has booted
+	vultr-cli instance create --region="ewr" --plan="vc2-2c-4gb" --os=1743 --wait --wait-timeout=10m
 	`
 	deleteLong    = ``
 	deleteExample = ``
@@ -76,6 +88,17 @@ var (
 	vultr-cli instance vpc detach <instanceID> --vpc-id="2126b7d9-5e2a-491e-8840-838aa6b5f294"
 	`
 
+	backupCreateLong = `Set the automatic backup schedule of an instance.  The hour is in UTC.
+
+  daily                    every day at --hour
+  weekly                   every week on --dow (0 is Sunday) at --hour
+  monthly                  every month on --dom at --hour
+  daily_alt_even|odd       every other day at --hour`
+	backupCreateExample = `
+	# Weekly backups on Saturday at 03:00 UTC
+	vultr-cli instance backup-schedule set <instanceID> --type weekly --dow 6 --hour 3
+	`
+
 	vpc2AttachLong    = `Attaches an existing VPC 2.0 network to the specified instance`
 	vpc2AttachExample = `
 	# Full example
@@ -86,6 +109,33 @@ var (
 	# Full example
 	vultr-cli instance vpc2 detach <instanceID> --vpc-id="2126b7d9-5e2a-491e-8840-838aa6b5f294"
 	`
+	autoscaleLong = `Keeps the number of instances with a tag between --min and --max, creating instances from
+--snapshot or destroying the newest ones to bring the average of a metric to the --target.
+
+The Vultr API doesn't report the CPU or memory use of instances, so the metric is read by the
+--metric-command, which runs once for each instance and prints the current value, such as by
+reading it over SSH or from a monitoring system.  It gets the metric and instance in the
+VULTR_METRIC, VULTR_INSTANCE_ID, VULTR_INSTANCE_LABEL, VULTR_INSTANCE_IP and
+VULTR_INSTANCE_INTERNAL_IP environment variables.
+
+New instances copy the region, plan, firewall group, tags and VPCs of the newest running instance
+of the group.  Instances which are still starting or were created within the --cooldown aren't measured,
+and while there are any the group is only kept within --min and --max.
+
+Changes are only made with --run.  The group is checked every --interval until interrupted, or a
+single time with --once, which suits running from cron.`
+	autoscaleExample = `
+	# Show what would change
+	vultr-cli instance autoscale --tag web --min 2 --max 10 --metric cpu --target 70 \
+		--snapshot 704ac064-4ff2-49ca-a6e6-88262cca8f8a --metric-command ./cpu.sh --once
+
+	# Keep scaling the group
+	vultr-cli instance autoscale --tag web --min 2 --max 10 --metric cpu --target 70 \
+		--snapshot 704ac064-4ff2-49ca-a6e6-88262cca8f8a --metric-command ./cpu.sh --run
+
+	# cpu.sh
+	ssh root@"$VULTR_INSTANCE_IP" "vmstat 1 2 | tail -1" | awk '{print 100 - $15}'
+	`
 )
 
 // NewCmdInstance ...
@@ -116,7 +166,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			instances, meta, err := o.list()
+			instances, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error getting instance list : %v", err)
 			}
@@ -138,6 +188,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -171,6 +222,18 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Aliases: []string{"c"},
 		Long:    createLong,
 		Example: createExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			interactive, errIn := cmd.Flags().GetBool("interactive")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'interactive' for instance create : %v", errIn)
+			}
+
+			if interactive {
+				return o.wizard(cmd)
+			}
+
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			region, errRe := cmd.Flags().GetString("region")
 			if errRe != nil {
@@ -282,6 +345,19 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'firewall-group' for instance create : %v", errFw)
 			}
 
+			appVars, errAv := cmd.Flags().GetStringToString("marketplace-variable")
+			if errAv != nil {
+				return fmt.Errorf("error parsing flag 'marketplace-variable' for instance create : %v", errAv)
+			}
+
+			if image != "" {
+				if err := marketplace.CheckVariables(o.Base, image, appVars); err != nil {
+					return err
+				}
+			} else if len(appVars) > 0 {
+				return errors.New("marketplace variables can only be used with --image")
+			}
+
 			o.CreateReq = &govultr.InstanceCreateReq{
 				Plan:            plan,
 				Region:          region,
@@ -305,6 +381,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				Backups:         "disabled",
 				EnableVPC:       govultr.BoolToBoolPtr(vpcEnable),
 				AttachVPC:       vpcAttach,
+				AppVariables:    appVars,
 			}
 
 			if backup {
@@ -315,11 +392,37 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				o.CreateReq.UserData = base64.StdEncoding.EncodeToString([]byte(userData))
 			}
 
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing flag 'yes' for instance create : %v", errYs)
+			}
+
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			ok, err := utils.ConfirmPrice(yes, fmt.Sprintf("Create a %s instance in %s", plan, region), func() (float64, error) {
+				return utils.PlanPrice(o.Base, plan)
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("instance create was cancelled")
+			}
+
 			instance, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating instance : %v", err)
 			}
 
+			if wait {
+				if instance, err = o.waitReady(instance, timeout); err != nil {
+					return err
+				}
+			}
+
 			data := &InstancePrinter{Instance: instance}
 			o.Base.Printer.Display(data, nil)
 
@@ -346,6 +449,9 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	create.Flags().StringP("image", "", "", "image ID of the application that will be installed on the server.")
 	create.MarkFlagsMutuallyExclusive("os", "iso", "snapshot", "app", "image")
 	create.MarkFlagsOneRequired("os", "iso", "snapshot", "app", "image")
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
+	utils.SetConfigDefault(create, "plan", utils.DefaultPlanKey)
+	utils.SetConfigDefault(create, "os", utils.DefaultOSKey, "iso", "snapshot", "app", "image")
 
 	create.Flags().StringP(
 		"ipxe",
@@ -372,6 +478,19 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	create.Flags().StringP("host", "", "", "The hostname to assign to this instance")
 	create.Flags().StringSliceP("tags", "", []string{}, "A comma-separated list of tags to assign to this instance")
 	create.Flags().StringP("firewall-group", "", "", "The firewall group to assign to this instance")
+	create.Flags().StringToString(
+		"marketplace-variable",
+		nil,
+		"key=value pairs for the user-supplied variables of the marketplace app given with --image",
+	)
+	create.Flags().BoolP(
+		"interactive",
+		"i",
+		false,
+		"prompt for the settings not given as flags and print the equivalent command",
+	)
+	utils.AddYesFlag(create)
+	utils.AddWaitFlags(create, "the instance is active and has booted")
 
 	// Update
 	// update := &cobra.Command{}
@@ -695,8 +814,9 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Backup
 	backup := &cobra.Command{
-		Use:   "backup",
-		Short: "List and create backup schedules for an instance",
+		Use:     "backup",
+		Short:   "List and create backup schedules for an instance",
+		Aliases: []string{"backup-schedule"},
 	}
 
 	// Backup Get
@@ -725,9 +845,11 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Backup Create
 	backupCreate := &cobra.Command{
-		Use:   "create <Instance ID>",
-		Short: "Create a backup schedule for an instance",
-		Long:  ``,
+		Use:     "create <Instance ID>",
+		Short:   "Create a backup schedule for an instance",
+		Aliases: []string{"set"},
+		Long:    backupCreateLong,
+		Example: backupCreateExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please provide an instance ID")
@@ -755,6 +877,10 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'dom' for instance backup create : %v", errDo)
 			}
 
+			if err := validateBackupSchedule(crontType, hour, dow, dom); err != nil {
+				return err
+			}
+
 			o.BackupCreateReq = &govultr.BackupScheduleReq{
 				Type: crontType,
 				Hour: govultr.IntToIntPtr(hour),
@@ -1061,12 +1187,27 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'plan' for instance plan upgrade : %v", errPl)
 			}
 
+			yes, errYs := cmd.Flags().GetBool("yes")
+			if errYs != nil {
+				return fmt.Errorf("error parsing flag 'yes' for instance plan upgrade : %v", errYs)
+			}
+
+			action := fmt.Sprintf("Upgrade instance %s to plan %s", o.Base.Args[0], plan)
+			ok, err := utils.ConfirmPrice(yes, action, func() (float64, error) {
+				return utils.PlanPrice(o.Base, plan)
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("instance plan upgrade was cancelled")
+			}
+
 			o.UpdateReq = &govultr.InstanceUpdateReq{
 				Plan: plan,
 			}
 
-			_, err := o.update()
-			if err != nil {
+			if _, err := o.update(); err != nil {
 				return fmt.Errorf("error upgrading plan on instance : %v", err)
 			}
 
@@ -1081,6 +1222,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		fmt.Printf("error marking instance plan upgrade 'plan' flag required: %v", err)
 		os.Exit(1)
 	}
+	utils.AddYesFlag(planUpgrade)
 
 	plan.AddCommand(
 		planList,
@@ -1107,7 +1249,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			v4s, meta, err := o.ipv4s()
+			v4s, meta, err := utils.ListAll(cmd, o.Base.Options, o.ipv4s)
 			if err != nil {
 				return fmt.Errorf("error getting ipv4 list for instance : %v", err)
 			}
@@ -1129,6 +1271,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(ipv4List)
 
 	// IPv4 Create
 	ipv4Create := &cobra.Command{
@@ -1210,7 +1353,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			v6s, meta, err := o.ipv6s()
+			v6s, meta, err := utils.ListAll(cmd, o.Base.Options, o.ipv6s)
 			if err != nil {
 				return fmt.Errorf("error getting ipv6 list for instance : %v", err)
 			}
@@ -1232,6 +1375,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(ipv6List)
 
 	ipv6.AddCommand(
 		ipv6List,
@@ -1506,7 +1650,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			vpc2s, meta, err := o.vpc2s()
+			vpc2s, meta, err := utils.ListAll(cmd, o.Base.Options, o.vpc2s)
 			if err != nil {
 				return fmt.Errorf("error getting vpc2 list for instance : %v", err)
 			}
@@ -1519,6 +1663,8 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Deprecated: "all vpc2 commands should be migrated to vpc.",
 	}
 
+	utils.AddListAllFlag(vpc2List)
+
 	// VPC2 Attach
 	vpc2Attach := &cobra.Command{
 		Use:     "attach <Instance ID>, <VPC2 ID>",
@@ -1613,6 +1759,155 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	// Autoscale
+	autoscale := &cobra.Command{
+		Use:     "autoscale",
+		Short:   "Scale a group of tagged instances to a metric target",
+		Long:    autoscaleLong,
+		Example: autoscaleExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, errTa := cmd.Flags().GetString("tag")
+			if errTa != nil {
+				return fmt.Errorf("error parsing flag 'tag' for instance autoscale : %v", errTa)
+			}
+
+			minimum, errMi := cmd.Flags().GetInt("min")
+			if errMi != nil {
+				return fmt.Errorf("error parsing flag 'min' for instance autoscale : %v", errMi)
+			}
+
+			maximum, errMa := cmd.Flags().GetInt("max")
+			if errMa != nil {
+				return fmt.Errorf("error parsing flag 'max' for instance autoscale : %v", errMa)
+			}
+
+			metric, errMe := cmd.Flags().GetString("metric")
+			if errMe != nil {
+				return fmt.Errorf("error parsing flag 'metric' for instance autoscale : %v", errMe)
+			}
+
+			metricCommand, errMC := cmd.Flags().GetString("metric-command")
+			if errMC != nil {
+				return fmt.Errorf("error parsing flag 'metric-command' for instance autoscale : %v", errMC)
+			}
+
+			target, errTg := cmd.Flags().GetFloat64("target")
+			if errTg != nil {
+				return fmt.Errorf("error parsing flag 'target' for instance autoscale : %v", errTg)
+			}
+
+			snapshot, errSn := cmd.Flags().GetString("snapshot")
+			if errSn != nil {
+				return fmt.Errorf("error parsing flag 'snapshot' for instance autoscale : %v", errSn)
+			}
+
+			region, errRe := cmd.Flags().GetString("region")
+			if errRe != nil {
+				return fmt.Errorf("error parsing flag 'region' for instance autoscale : %v", errRe)
+			}
+
+			plan, errPl := cmd.Flags().GetString("plan")
+			if errPl != nil {
+				return fmt.Errorf("error parsing flag 'plan' for instance autoscale : %v", errPl)
+			}
+
+			cooldown, errCo := cmd.Flags().GetDuration("cooldown")
+			if errCo != nil {
+				return fmt.Errorf("error parsing flag 'cooldown' for instance autoscale : %v", errCo)
+			}
+
+			interval, errIn := cmd.Flags().GetDuration("interval")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'interval' for instance autoscale : %v", errIn)
+			}
+
+			once, errOn := cmd.Flags().GetBool("once")
+			if errOn != nil {
+				return fmt.Errorf("error parsing flag 'once' for instance autoscale : %v", errOn)
+			}
+
+			run, errRu := cmd.Flags().GetBool("run")
+			if errRu != nil {
+				return fmt.Errorf("error parsing flag 'run' for instance autoscale : %v", errRu)
+			}
+
+			command, errSp := utils.SplitArgs(metricCommand)
+			if errSp != nil {
+				return fmt.Errorf("unable to parse metric command : %v", errSp)
+			}
+			if len(command) == 0 {
+				return errors.New("please provide the metric command to run")
+			}
+
+			switch {
+			case minimum < 0:
+				return errors.New("min can't be negative")
+			case maximum < max(minimum, 1):
+				return errors.New("max must be at least 1 and no less than min")
+			case target <= 0:
+				return errors.New("target must be positive")
+			case interval <= 0:
+				return errors.New("interval must be positive")
+			}
+
+			c := &autoscaleConfig{
+				Tag:           tag,
+				Min:           minimum,
+				Max:           maximum,
+				Metric:        metric,
+				MetricCommand: command,
+				Target:        target,
+				Snapshot:      snapshot,
+				Region:        region,
+				Plan:          plan,
+				Cooldown:      cooldown,
+				Run:           run,
+			}
+
+			if !once {
+				return o.autoscaleWatch(c, interval)
+			}
+
+			check, err := o.autoscale(c)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&AutoscalePrinter{Check: check}, nil)
+
+			if check.Failed > 0 {
+				total := check.Failed + len(check.Created) + len(check.Destroyed)
+				return fmt.Errorf("%d of %d instance changes failed", check.Failed, total)
+			}
+
+			return nil
+		},
+	}
+
+	autoscale.Flags().String("tag", "", "tag of the instances in the group")
+	autoscale.Flags().Int("min", 1, "(optional) fewest instances to keep")
+	autoscale.Flags().Int("max", 0, "most instances to keep")
+	autoscale.Flags().String("metric", "cpu", "(optional) name of the metric, passed to the metric command")
+	autoscale.Flags().String("metric-command", "", "command printing the metric of the instance in its environment")
+	autoscale.Flags().Float64("target", 0, "average of the metric to scale the group to")
+	autoscale.Flags().String("snapshot", "", "ID of the snapshot to create instances from")
+	autoscale.Flags().String("region", "", "(optional) region of new instances, instead of that of the group")
+	autoscale.Flags().String("plan", "", "(optional) plan of new instances, instead of that of the group")
+	autoscale.Flags().Duration(
+		"cooldown",
+		defaultAutoscaleCooldown,
+		"(optional) how long new instances are left to settle before they are measured",
+	)
+	autoscale.Flags().Duration("interval", defaultAutoscaleInterval, "(optional) delay between checks of the group")
+	autoscale.Flags().Bool("once", false, "(optional) check the group a single time")
+	autoscale.Flags().Bool("run", false, "(optional) create and destroy instances rather than only report")
+	for _, f := range []string{"tag", "max", "metric-command", "target", "snapshot"} {
+		if err := autoscale.MarkFlagRequired(f); err != nil {
+			fmt.Printf("error marking instance autoscale '%s' flag required: %v", f, err)
+			os.Exit(1)
+		}
+	}
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -1638,8 +1933,12 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		vpc,
 		vpc2,
 		bandwidth,
+		autoscale,
 	)
 
+	utils.RegisterCompletion(cmd, "<Instance ID>", utils.CompleteInstances(o.Base))
+	utils.RegisterResolver(cmd, "<Instance ID>", utils.ResolveInstances(o.Base))
+
 	return cmd
 }
 
@@ -1661,6 +1960,27 @@ func (o *options) list() ([]govultr.Instance, *govultr.Meta, error) {
 	return insts, meta, err
 }
 
+// waitReady polls the new instance until it is active and its server status
+// is ok.  The default password is only returned on create so it is kept.
+func (o *options) waitReady(created *govultr.Instance, timeout time.Duration) (*govultr.Instance, error) {
+	instance := created
+	err := o.Base.Wait("instance "+created.ID, timeout, func() (string, bool, error) {
+		var err error
+		if instance, _, err = o.Base.Client.Instance.Get(o.Base.Context, created.ID); err != nil {
+			return "", false, err
+		}
+
+		status := fmt.Sprintf("%s, %s, %s", instance.Status, instance.PowerStatus, instance.ServerStatus)
+		return status, instance.Status == "active" && instance.ServerStatus == "ok", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instance.DefaultPassword = created.DefaultPassword
+	return instance, nil
+}
+
 func (o *options) get() (*govultr.Instance, error) {
 	inst, _, err := o.Base.Client.Instance.Get(o.Base.Context, o.Base.Args[0])
 	return inst, err
@@ -1707,6 +2027,30 @@ func (o *options) backupCreate() error {
 	return err
 }
 
+// validateBackupSchedule checks the schedule values are in range before they
+// are sent to the API
+func validateBackupSchedule(cronType string, hour, dow, dom int) error {
+	switch cronType {
+	case "daily", "weekly", "monthly", "daily_alt_even", "daily_alt_odd":
+	default:
+		return fmt.Errorf("invalid backup type %q", cronType)
+	}
+
+	if hour < 0 || hour > 23 { //nolint:mnd
+		return errors.New("hour must be between 0 and 23")
+	}
+
+	if cronType == "weekly" && (dow < 0 || dow > 6) { //nolint:mnd
+		return errors.New("dow must be between 0 and 6")
+	}
+
+	if cronType == "monthly" && (dom < 1 || dom > 28) { //nolint:mnd
+		return errors.New("dom must be between 1 and 28")
+	}
+
+	return nil
+}
+
 func (o *options) restore() error {
 	_, err := o.Base.Client.Instance.Restore(o.Base.Context, o.Base.Args[0], o.RestoreReq)
 	return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
 )
 
 // InstancesPrinter ...
@@ -65,6 +66,32 @@ func (i *InstancesPrinter) Data() [][]string {
 	return data
 }
 
+// WideColumns ...
+func (i *InstancesPrinter) WideColumns() [][]string {
+	return [][]string{0: append(i.Columns()[0],
+		"VPC IP",
+		"FIREWALL GROUP",
+		"FEATURES",
+	)}
+}
+
+// WideData ...
+func (i *InstancesPrinter) WideData() [][]string {
+	data := i.Data()
+	if len(i.Instances) == 0 {
+		return [][]string{0: append(data[0], "---", "---", "---")}
+	}
+
+	for j := range i.Instances {
+		data[j] = append(data[j],
+			i.Instances[j].InternalIP,
+			i.Instances[j].FirewallGroupID,
+			printer.ArrayOfStringsToString(i.Instances[j].Features),
+		)
+	}
+	return data
+}
+
 // Paging ...
 func (i *InstancesPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(i.Meta).Compose()
@@ -511,3 +538,67 @@ func (v *VPC2sPrinter) Data() [][]string {
 func (v *VPC2sPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(v.Meta).Compose()
 }
+
+// ======================================
+
+// AutoscalePrinter ...
+type AutoscalePrinter struct {
+	Check *autoscaleCheck `json:"autoscale"`
+}
+
+// JSON ...
+func (a *AutoscalePrinter) JSON() []byte {
+	return printer.MarshalObject(a, "json")
+}
+
+// YAML ...
+func (a *AutoscalePrinter) YAML() []byte {
+	return printer.MarshalObject(a, "yaml")
+}
+
+// Columns ...
+func (a *AutoscalePrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TAG",
+		"INSTANCES",
+		"READY",
+		"METRIC",
+		"AVERAGE",
+		"TARGET",
+		"DESIRED",
+		"ACTION",
+		"CREATED",
+		"DESTROYED",
+	}}
+}
+
+// Data ...
+func (a *AutoscalePrinter) Data() [][]string {
+	average := "---"
+	if a.Check.Average != nil {
+		average = strconv.FormatFloat(*a.Check.Average, 'f', utils.FloatPrecision, 64)
+	}
+
+	action := a.Check.Action
+	if action != autoscaleNone && !a.Check.Run {
+		action += " (not run)"
+	}
+
+	return [][]string{0: {
+		a.Check.Tag,
+		strconv.Itoa(a.Check.Instances),
+		strconv.Itoa(a.Check.Ready),
+		a.Check.Metric,
+		average,
+		strconv.FormatFloat(a.Check.Target, 'f', utils.FloatPrecision, 64),
+		strconv.Itoa(a.Check.Desired),
+		action,
+		printer.ArrayOfStringsToString(a.Check.Created),
+		printer.ArrayOfStringsToString(a.Check.Destroyed),
+	}}
+}
+
+// Paging ...
+func (a *AutoscalePrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,471 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// imageFlags are the flags which choose what is installed on the instance
+var imageFlags = []string{"os", "iso", "snapshot", "app", "image"}
+
+// plainArg matches the flag values which need no quoting in a shell
+var plainArg = regexp.MustCompile(`^[A-Za-z0-9_./:@,=+-]+$`)
+
+// wizard asks for the settings of a new instance, offering the choices
+// available on the account, and sets the flags of the create command from
+// the answers.  Flags which were already given are not asked for
+func (o *options) wizard(cmd *cobra.Command) error {
+	steps := []func(*cobra.Command) error{
+		o.askRegion,
+		o.askPlan,
+		o.askImage,
+		o.askSSHKeys,
+		o.askNetworking,
+		o.askDetails,
+	}
+
+	for _, step := range steps {
+		if err := step(cmd); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\nTo create the same instance again run:\n\n  %s\n\n", equivalentCommand(cmd))
+
+	return nil
+}
+
+func (o *options) askRegion(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("region") {
+		return nil
+	}
+
+	regions, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Region, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Region.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving regions : %v", err)
+	}
+
+	choices := make([]utils.Choice, len(regions))
+	for i := range regions {
+		choices[i] = utils.Choice{
+			Value: regions[i].ID,
+			Label: fmt.Sprintf("%s, %s (%s)", regions[i].City, regions[i].Country, regions[i].Continent),
+		}
+	}
+
+	return choose(cmd, "region", "Region", choices)
+}
+
+// askPlan offers the plans available in the region, cheapest first
+func (o *options) askPlan(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("plan") {
+		return nil
+	}
+
+	region, _ := cmd.Flags().GetString("region")
+	available, _, err := o.Base.Client.Region.Availability(o.Base.Context, region, "")
+	if err != nil {
+		return fmt.Errorf("error retrieving the plans available in %s : %v", region, err)
+	}
+
+	plans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Plan.List(o.Base.Context, "", opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving plans : %v", err)
+	}
+
+	plans = slices.DeleteFunc(plans, func(p govultr.Plan) bool {
+		return !slices.Contains(available.AvailablePlans, p.ID)
+	})
+	sort.SliceStable(plans, func(i, j int) bool { return plans[i].MonthlyCost < plans[j].MonthlyCost })
+
+	if len(plans) == 0 {
+		return fmt.Errorf("no plans are available in %s", region)
+	}
+
+	choices := make([]utils.Choice, len(plans))
+	for i := range plans {
+		choices[i] = utils.Choice{
+			Value: plans[i].ID,
+			Label: fmt.Sprintf(
+				"$%.2f/mo  %d vCPU, %d MB RAM, %d GB disk, %d GB bandwidth",
+				plans[i].MonthlyCost,
+				plans[i].VCPUCount,
+				plans[i].RAM,
+				plans[i].Disk,
+				plans[i].Bandwidth,
+			),
+		}
+	}
+
+	return choose(cmd, "plan", "Plan", choices)
+}
+
+// askImage asks whether to install an operating system, an application or
+// a snapshot and then which one
+func (o *options) askImage(cmd *cobra.Command) error {
+	for _, name := range imageFlags {
+		if cmd.Flags().Changed(name) {
+			return nil
+		}
+	}
+
+	source, err := utils.Choose("Install", []utils.Choice{
+		{Value: "os", Label: "an operating system"},
+		{Value: "app", Label: "a one-click or marketplace application"},
+		{Value: "snapshot", Label: "a snapshot of the account"},
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	switch source {
+	case "app":
+		return o.askApp(cmd)
+	case "snapshot":
+		return o.askSnapshot(cmd)
+	default:
+		return o.askOS(cmd)
+	}
+}
+
+func (o *options) askOS(cmd *cobra.Command) error {
+	systems, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.OS, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.OS.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving operating systems : %v", err)
+	}
+
+	choices := make([]utils.Choice, len(systems))
+	for i := range systems {
+		choices[i] = utils.Choice{
+			Value: strconv.Itoa(systems[i].ID),
+			Label: fmt.Sprintf("%s (%s)", systems[i].Name, systems[i].Arch),
+		}
+	}
+
+	return choose(cmd, "os", "Operating system", choices)
+}
+
+// askApp offers the applications, marketplace applications are installed
+// by their image and ask for their variables
+func (o *options) askApp(cmd *cobra.Command) error {
+	apps, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Application, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Application.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving applications : %v", err)
+	}
+
+	choices := make([]utils.Choice, len(apps))
+	for i := range apps {
+		choices[i] = utils.Choice{
+			Value: strconv.Itoa(apps[i].ID),
+			Label: fmt.Sprintf("%s (%s)", apps[i].DeployName, apps[i].Type),
+		}
+	}
+
+	id, err := utils.Choose("Application", choices, false)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(apps, func(a govultr.Application) bool { return strconv.Itoa(a.ID) == id })
+	if apps[i].ImageID == "" {
+		return setFlag(cmd, "app", id)
+	}
+
+	if err := setFlag(cmd, "image", apps[i].ImageID); err != nil {
+		return err
+	}
+
+	return o.askAppVariables(cmd, apps[i].ImageID)
+}
+
+func (o *options) askAppVariables(cmd *cobra.Command, image string) error {
+	vars, _, err := o.Base.Client.Marketplace.ListAppVariables(o.Base.Context, image)
+	if err != nil {
+		return fmt.Errorf("error getting marketplace app variables : %v", err)
+	}
+
+	for i := range vars {
+		required := vars[i].Required != nil && *vars[i].Required
+
+		question := fmt.Sprintf("%s, %s", vars[i].Name, vars[i].Description)
+		if !required {
+			question += " (optional)"
+		}
+
+		value := utils.Prompt(question, "")
+		if value == "" {
+			if required {
+				return fmt.Errorf("marketplace app %s requires the variable %s", image, vars[i].Name)
+			}
+			continue
+		}
+
+		if err := setFlag(cmd, "marketplace-variable", csvField(vars[i].Name+"="+value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *options) askSnapshot(cmd *cobra.Command) error {
+	snapshots, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving snapshots : %v", err)
+	}
+
+	if len(snapshots) == 0 {
+		return errors.New("the account has no snapshots")
+	}
+
+	choices := make([]utils.Choice, len(snapshots))
+	for i := range snapshots {
+		choices[i] = utils.Choice{
+			Value: snapshots[i].ID,
+			Label: fmt.Sprintf("%s (%s)", snapshots[i].Description, snapshots[i].DateCreated),
+		}
+	}
+
+	return choose(cmd, "snapshot", "Snapshot", choices)
+}
+
+func (o *options) askSSHKeys(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("ssh-keys") {
+		return nil
+	}
+
+	keys, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.SSHKey, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.SSHKey.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving SSH keys : %v", err)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	choices := make([]utils.Choice, len(keys))
+	for i := range keys {
+		choices[i] = utils.Choice{Value: keys[i].ID, Label: keys[i].Name}
+	}
+
+	return chooseMany(cmd, "ssh-keys", "SSH keys", choices)
+}
+
+// askNetworking asks for IPv6 and offers the VPCs of the region and the
+// firewall groups
+func (o *options) askNetworking(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("ipv6") && utils.Confirm("Enable IPv6?") {
+		if err := setFlag(cmd, "ipv6", "true"); err != nil {
+			return err
+		}
+	}
+
+	if err := o.askVPCs(cmd); err != nil {
+		return err
+	}
+
+	return o.askFirewallGroup(cmd)
+}
+
+func (o *options) askVPCs(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("vpc-ids") {
+		return nil
+	}
+
+	region, _ := cmd.Flags().GetString("region")
+	vpcs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.VPC.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving VPCs : %v", err)
+	}
+
+	var choices []utils.Choice
+	for i := range vpcs {
+		if vpcs[i].Region == region {
+			choices = append(choices, utils.Choice{
+				Value: vpcs[i].ID,
+				Label: fmt.Sprintf("%s (%s/%d)", vpcs[i].Description, vpcs[i].V4Subnet, vpcs[i].V4SubnetMask),
+			})
+		}
+	}
+
+	if len(choices) == 0 {
+		return nil
+	}
+
+	return chooseMany(cmd, "vpc-ids", "VPCs", choices)
+}
+
+func (o *options) askFirewallGroup(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("firewall-group") {
+		return nil
+	}
+
+	groups, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.FirewallGroup.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving firewall groups : %v", err)
+	}
+
+	if len(groups) == 0 {
+		return nil
+	}
+
+	choices := make([]utils.Choice, len(groups))
+	for i := range groups {
+		choices[i] = utils.Choice{
+			Value: groups[i].ID,
+			Label: fmt.Sprintf("%s (%d rules)", groups[i].Description, groups[i].RuleCount),
+		}
+	}
+
+	group, err := utils.Choose("Firewall group", choices, true)
+	if err != nil || group == "" {
+		return err
+	}
+
+	return setFlag(cmd, "firewall-group", group)
+}
+
+// askDetails asks for the label, hostname, tags and backups
+func (o *options) askDetails(cmd *cobra.Command) error {
+	label, _ := cmd.Flags().GetString("label")
+	if !cmd.Flags().Changed("label") {
+		label = utils.Prompt("Label", "")
+		if err := setFlagIfAny(cmd, "label", label); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("host") {
+		if err := setFlagIfAny(cmd, "host", utils.Prompt("Hostname", label)); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("tags") {
+		if err := setFlagIfAny(cmd, "tags", utils.Prompt("Tags, comma separated", "")); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("auto-backup") && utils.Confirm("Enable automatic backups?") {
+		return setFlag(cmd, "auto-backup", "true")
+	}
+
+	return nil
+}
+
+// choose sets the flag to the choice picked
+func choose(cmd *cobra.Command, flag, question string, choices []utils.Choice) error {
+	value, err := utils.Choose(question, choices, false)
+	if err != nil {
+		return err
+	}
+	return setFlag(cmd, flag, value)
+}
+
+// chooseMany sets the flag to the choices picked, if any
+func chooseMany(cmd *cobra.Command, flag, question string, choices []utils.Choice) error {
+	values, err := utils.ChooseMany(question, choices)
+	if err != nil {
+		return err
+	}
+	return setFlagIfAny(cmd, flag, strings.Join(values, ","))
+}
+
+func setFlagIfAny(cmd *cobra.Command, flag, value string) error {
+	if value == "" {
+		return nil
+	}
+	return setFlag(cmd, flag, value)
+}
+
+func setFlag(cmd *cobra.Command, flag, value string) error {
+	if err := cmd.Flags().Set(flag, value); err != nil {
+		return fmt.Errorf("error setting flag '%s' for instance create : %v", flag, err)
+	}
+	return nil
+}
+
+// equivalentCommand writes the create command with the flags which were
+// given or answered, leaving out --interactive
+func equivalentCommand(cmd *cobra.Command) string {
+	args := []string{cmd.CommandPath()}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name == "interactive" {
+			return
+		}
+
+		if f.Value.Type() == "bool" {
+			if f.Value.String() == "true" {
+				args = append(args, "--"+f.Name)
+			}
+			return
+		}
+
+		value := f.Value.String()
+		switch v := f.Value.(type) {
+		case pflag.SliceValue:
+			value = strings.Join(v.GetSlice(), ",")
+		default:
+			if f.Value.Type() == "stringToString" {
+				value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			}
+		}
+
+		args = append(args, "--"+f.Name+"="+quoteArg(value))
+	})
+
+	return strings.Join(args, " ")
+}
+
+// csvField quotes a key=value pair the way the flag parses it when the
+// value holds another equals sign
+func csvField(pair string) string {
+	if strings.Count(pair, "=") == 1 {
+		return pair
+	}
+	return `"` + strings.ReplaceAll(pair, `"`, `""`) + `"`
+}
+
+// quoteArg quotes a flag value for the shell when it needs it
+func quoteArg(s string) string {
+	if plainArg.MatchString(s) {
+		return s
+	}
+	return utils.ShellQuote(s)
+}
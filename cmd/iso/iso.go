@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -14,7 +16,7 @@ import (
 )
 
 // NewCmdISO provides the CLI command for ISO functions
-func NewCmdISO(base *cli.Base) *cobra.Command {
+func NewCmdISO(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
 
 	cmd := &cobra.Command{
@@ -38,7 +40,7 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			isos, meta, err := o.list()
+			isos, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving private ISO list : %v", err)
 			}
@@ -60,6 +62,7 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -89,13 +92,32 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 	create := &cobra.Command{
 		Use:   "create",
 		Short: "Create an ISO from url",
-		Long:  ``,
+		Long: `Create an ISO from url.
+
+Use --wait to follow the download, printing the status and size of the ISO
+until it is complete. Passing --sha512 also waits and fails the command when
+the checksum of the downloaded ISO does not match.`,
+		Example: `
+	# Full example
+	vultr-cli iso create --url="https://example.com/image.iso" --wait \
+		--sha512="cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce..."
+	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			url, errUR := cmd.Flags().GetString("url")
 			if errUR != nil {
 				return fmt.Errorf("error parsing flag 'url' for ISO create : %v", errUR)
 			}
 
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			sha512, errSh := cmd.Flags().GetString("sha512")
+			if errSh != nil {
+				return fmt.Errorf("error parsing flag 'sha512' for ISO create : %v", errSh)
+			}
+
 			o.CreateReq = &govultr.ISOReq{URL: url}
 
 			iso, err := o.create()
@@ -103,6 +125,21 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 				return fmt.Errorf("error creating ISO : %v", err)
 			}
 
+			if wait || sha512 != "" {
+				if iso, err = o.waitComplete(iso.ID, timeout); err != nil {
+					return err
+				}
+			}
+
+			if sha512 != "" && !strings.EqualFold(strings.TrimSpace(sha512), iso.SHA512Sum) {
+				return fmt.Errorf(
+					"checksum mismatch for ISO %s : expected sha512 %s, got %s",
+					iso.ID,
+					strings.TrimSpace(sha512),
+					iso.SHA512Sum,
+				)
+			}
+
 			data := &ISOPrinter{ISO: *iso}
 			o.Base.Printer.Display(data, nil)
 
@@ -111,6 +148,8 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 	}
 
 	create.Flags().StringP("url", "u", "", "url from where the ISO will be downloaded")
+	utils.AddWaitFlags(create, "the ISO is downloaded")
+	create.Flags().String("sha512", "", "(optional) expected sha512 checksum, verified once the ISO is downloaded")
 	if err := create.MarkFlagRequired("url"); err != nil {
 		printer.Error(fmt.Errorf("error marking iso create 'url' flag required : %v", err))
 		os.Exit(1)
@@ -146,7 +185,7 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			isos, meta, err := o.listPublic()
+			isos, meta, err := utils.ListAll(cmd, o.Base.Options, o.listPublic)
 			if err != nil {
 				return fmt.Errorf("error retrieving public ISO list : %v", err)
 			}
@@ -158,6 +197,8 @@ func NewCmdISO(base *cli.Base) *cobra.Command {
 		},
 	}
 
+	utils.AddListAllFlag(public)
+
 	cmd.AddCommand(list, get, create, del, public)
 
 	return cmd
@@ -187,6 +228,21 @@ func (o *options) del() error {
 	return o.Base.Client.ISO.Delete(o.Base.Context, o.Base.Args[0])
 }
 
+// waitComplete polls the ISO until it is complete, reporting the status and
+// size whenever they change
+func (o *options) waitComplete(id string, timeout time.Duration) (*govultr.ISO, error) {
+	var iso *govultr.ISO
+	err := o.Base.Wait("ISO "+id, timeout, func() (string, bool, error) {
+		var err error
+		if iso, _, err = o.Base.Client.ISO.Get(o.Base.Context, id); err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("%s, size %d", iso.Status, iso.Size), iso.Status == "complete", nil
+	})
+
+	return iso, err
+}
+
 func (o *options) listPublic() ([]govultr.PublicISO, *govultr.Meta, error) {
 	isos, meta, _, err := o.Base.Client.ISO.ListPublic(o.Base.Context, o.Base.Options)
 	return isos, meta, err
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -33,6 +34,12 @@ var (
 	# Shortened with alias commands
 	vultr-cli k c -l="my-cluster" -r="ewr" -v="v1.29.2+1" -n="quantity:3,plan:vc2-2c-4gb,label:my-nodepool,tag:my-tag"
 
+	# From a YAML or JSON cluster spec
+	vultr-cli kubernetes create --file="cluster.yaml"
+
+	# Wait until the cluster and its nodes are active
+	vultr-cli kubernetes create --file="cluster.yaml" --wait --wait-timeout=45m
+
 	# Node pool options
 	The --node-pools option allows you to pass in options for any number of
 	node pools when creating a cluster. The options are passed in a delimited
@@ -52,6 +59,37 @@ var (
 	Using node labels 
 	--node-pools="quantity:5,plan:vc2-2c-4gb,label:worker-pool,auto-scaler:true,min-nodes:5,max-nodes:10, \
 		node-labels:application=identity-service|worker-size=small"
+
+	# Cluster spec file
+	The --file option accepts a YAML or JSON document describing the whole
+	cluster, which is useful for clusters with several node pools or with
+	node labels and taints.  Use "-" to read the spec from stdin.
+
+	For example:
+
+	label: my-cluster
+	region: ewr
+	version: v1.29.2+1
+	high_avail: true
+	enable_firewall: false
+	vpc_id: 9d3cd2ab-2f8b-4ea4-a3cf-5c9a8b0cbd6f
+	node_pools:
+	  - label: main-node-pool
+	    plan: vc2-4c-8gb
+	    quantity: 1
+	  - label: worker-pool
+	    plan: vc2-2c-4gb
+	    quantity: 5
+	    tag: workers
+	    auto_scaler: true
+	    min_nodes: 5
+	    max_nodes: 10
+	    labels:
+	      application: identity-service
+	    taints:
+	      - key: dedicated
+	        value: workers
+	        effect: NoSchedule
 	`
 
 	getLong    = `Get a single kubernetes cluster from your account`
@@ -271,7 +309,7 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'summarize' for kubernetes list : %v", errSu)
 			}
 
-			k8s, meta, err := o.list()
+			k8s, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving kubernetes clusters list : %v", err)
 			}
@@ -299,6 +337,7 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 	list.Flags().BoolP("summarize", "", false, "(optional) Summarize the list output. One line per cluster.")
 
 	// Get
@@ -335,6 +374,27 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Example: createExample,
 		Aliases: []string{"c"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for kubernetes cluster create : %v", errFi)
+			}
+
+			if file != "" {
+				spec := &clusterSpec{}
+				if err := utils.ReadSpecFile(file, spec); err != nil {
+					return fmt.Errorf("error reading kubernetes cluster spec : %v", err)
+				}
+
+				req, errSp := spec.toClusterReq()
+				if errSp != nil {
+					return fmt.Errorf("error in kubernetes cluster spec : %v", errSp)
+				}
+
+				o.CreateReq = req
+
+				return o.runCreate(cmd)
+			}
+
 			label, errLa := cmd.Flags().GetString("label")
 			if errLa != nil {
 				return fmt.Errorf("error parsing flag 'label' for kubernetes cluster create : %v", errLa)
@@ -379,35 +439,13 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				EnableFirewall:  fw,
 			}
 
-			k8, err := o.create()
-			if err != nil {
-				return fmt.Errorf("error creating kubernetes cluster : %v", err)
-			}
-
-			data := &ClusterPrinter{Cluster: k8}
-			o.Base.Printer.Display(data, nil)
-
-			return nil
+			return o.runCreate(cmd)
 		},
 	}
 
 	create.Flags().StringP("label", "l", "", "label for your kubernetes cluster")
-	if err := create.MarkFlagRequired("label"); err != nil {
-		fmt.Printf("error marking kubernetes create 'label' flag required: %v", err)
-		os.Exit(1)
-	}
-
 	create.Flags().StringP("region", "r", "", "region you want your kubernetes cluster to be located in")
-	if err := create.MarkFlagRequired("region"); err != nil {
-		fmt.Printf("error marking kubernetes create 'region' flag required: %v", err)
-		os.Exit(1)
-	}
-
 	create.Flags().StringP("version", "v", "", "the kubernetes version you want for your cluster")
-	if err := create.MarkFlagRequired("version"); err != nil {
-		fmt.Printf("error marking kubernetes create 'version' flag required: %v", err)
-		os.Exit(1)
-	}
 
 	create.Flags().Bool(
 		"high-avail",
@@ -431,10 +469,21 @@ highly available, control planes`,
 required in node pool. Use / between each new node pool.  E.g: 
 'plan:vhf-8c-32gb,label:mynodepool,tag:my-tag,quantity:3/plan:vhf-8c-32gb,label:mynodepool2,quantity:3`,
 	)
-	if err := create.MarkFlagRequired("node-pools"); err != nil {
-		fmt.Printf("error marking kubernetes create 'ns-primary' flag required: %v", err)
-		os.Exit(1)
+
+	create.Flags().String(
+		"file",
+		"",
+		`(optional) path to a YAML or JSON cluster spec. Use "-" to read from stdin. Cannot be combined
+with the label, region, version or node-pools flags`,
+	)
+
+	for _, f := range []string{"label", "region", "version", "node-pools"} {
+		create.MarkFlagsOneRequired("file", f)
+		create.MarkFlagsMutuallyExclusive("file", f)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey, "file")
+	utils.AddYesFlag(create)
+	utils.AddWaitFlags(create, "the cluster and its nodes are active")
 
 	// Update
 	update := &cobra.Command{
@@ -694,7 +743,7 @@ required in node pool. Use / between each new node pool.  E.g:
 				return fmt.Errorf("error parsing flag 'summarize' for kubernetes node pool list : %v", errSu)
 			}
 
-			nps, meta, err := o.nodePools()
+			nps, meta, err := utils.ListAll(cmd, o.Base.Options, o.nodePools)
 			if err != nil {
 				return fmt.Errorf("error getting node pool list : %v", err)
 			}
@@ -722,6 +771,7 @@ required in node pool. Use / between each new node pool.  E.g:
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(npList)
 	npList.Flags().BoolP("summarize", "", false, "(optional) Summarize the list output. One line per node pool.")
 
 	// Node Pool Get
@@ -1083,6 +1133,10 @@ required in node pool. Use / between each new node pool.  E.g:
 		upgrades,
 	)
 
+	complete := utils.CompleteKubernetesClusters(o.Base)
+	utils.RegisterCompletion(cmd, "<Cluster ID>", complete)
+	utils.RegisterCompletion(cmd, "<clusterID>", complete)
+
 	return cmd
 }
 
@@ -1177,6 +1231,78 @@ func formatNodeLabels(nl string) map[string]string {
 	return data
 }
 
+// clusterSpec is the declarative cluster definition accepted by the create
+// command's --file option
+type clusterSpec struct {
+	Label          string         `yaml:"label"`
+	Region         string         `yaml:"region"`
+	Version        string         `yaml:"version"`
+	HighAvail      bool           `yaml:"high_avail"`
+	EnableFirewall bool           `yaml:"enable_firewall"`
+	VPCID          string         `yaml:"vpc_id"`
+	NodePools      []nodePoolSpec `yaml:"node_pools"`
+}
+
+// nodePoolSpec is a single node pool within a clusterSpec
+type nodePoolSpec struct {
+	Label      string            `yaml:"label"`
+	Plan       string            `yaml:"plan"`
+	Quantity   int               `yaml:"quantity"`
+	Tag        string            `yaml:"tag"`
+	AutoScaler bool              `yaml:"auto_scaler"`
+	MinNodes   int               `yaml:"min_nodes"`
+	MaxNodes   int               `yaml:"max_nodes"`
+	Labels     map[string]string `yaml:"labels"`
+	Taints     []govultr.Taint   `yaml:"taints"`
+}
+
+// toClusterReq validates the spec and converts it to a cluster create request
+func (c *clusterSpec) toClusterReq() (*govultr.ClusterReq, error) {
+	if c.Label == "" || c.Region == "" || c.Version == "" {
+		return nil, errors.New("label, region and version are required")
+	}
+
+	if len(c.NodePools) == 0 {
+		return nil, errors.New("at least one node pool is required")
+	}
+
+	req := &govultr.ClusterReq{
+		Label:           c.Label,
+		Region:          c.Region,
+		Version:         c.Version,
+		HAControlPlanes: c.HighAvail,
+		EnableFirewall:  c.EnableFirewall,
+		VPCID:           c.VPCID,
+	}
+
+	for i := range c.NodePools {
+		np := c.NodePools[i]
+		if np.Label == "" || np.Plan == "" || np.Quantity < 1 {
+			return nil, fmt.Errorf("node pool %d must include label, plan and a quantity of at least 1", i+1)
+		}
+
+		for j := range np.Taints {
+			if np.Taints[j].Key == "" || np.Taints[j].Effect == "" {
+				return nil, fmt.Errorf("node pool %q taints must include a key and an effect", np.Label)
+			}
+		}
+
+		req.NodePools = append(req.NodePools, govultr.NodePoolReq{
+			NodeQuantity: np.Quantity,
+			Label:        np.Label,
+			Plan:         np.Plan,
+			Tag:          np.Tag,
+			AutoScaler:   govultr.BoolToBoolPtr(np.AutoScaler),
+			MinNodes:     np.MinNodes,
+			MaxNodes:     np.MaxNodes,
+			Labels:       np.Labels,
+			Taints:       np.Taints,
+		})
+	}
+
+	return req, nil
+}
+
 type options struct {
 	Base        *cli.Base
 	CreateReq   *govultr.ClusterReq
@@ -1196,6 +1322,103 @@ func (o *options) get() (*govultr.Cluster, error) {
 	return k8, err
 }
 
+// runCreate creates the cluster from the create request once its price is
+// confirmed, waiting for it when --wait is given
+func (o *options) runCreate(cmd *cobra.Command) error {
+	wait, timeout, errWa := utils.GetWaitFlags(cmd)
+	if errWa != nil {
+		return errWa
+	}
+
+	if err := o.confirmCreate(cmd); err != nil {
+		return err
+	}
+
+	k8, err := o.create()
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes cluster : %v", err)
+	}
+
+	if wait {
+		if k8, err = o.waitActive(k8.ID, timeout); err != nil {
+			return err
+		}
+	}
+
+	data := &ClusterPrinter{Cluster: k8}
+	o.Base.Printer.Display(data, nil)
+
+	return nil
+}
+
+// waitActive polls the cluster until it and all of its nodes are active
+func (o *options) waitActive(id string, timeout time.Duration) (*govultr.Cluster, error) {
+	var k8 *govultr.Cluster
+	err := o.Base.Wait("kubernetes cluster "+id, timeout, func() (string, bool, error) {
+		var err error
+		if k8, _, err = o.Base.Client.Kubernetes.GetCluster(o.Base.Context, id); err != nil {
+			return "", false, err
+		}
+
+		nodes, active := 0, 0
+		for i := range k8.NodePools {
+			for j := range k8.NodePools[i].Nodes {
+				nodes++
+				if k8.NodePools[i].Nodes[j].Status == "active" {
+					active++
+				}
+			}
+		}
+
+		status := fmt.Sprintf("%s, %d of %d nodes active", k8.Status, active, nodes)
+		return status, k8.Status == "active" && nodes > 0 && active == nodes, nil
+	})
+
+	return k8, err
+}
+
+// confirmCreate asks to confirm the price of the nodes of the new cluster
+func (o *options) confirmCreate(cmd *cobra.Command) error {
+	yes, errYs := cmd.Flags().GetBool("yes")
+	if errYs != nil {
+		return fmt.Errorf("error parsing flag 'yes' for kubernetes cluster create : %v", errYs)
+	}
+
+	nodes := 0
+	for i := range o.CreateReq.NodePools {
+		nodes += o.CreateReq.NodePools[i].NodeQuantity
+	}
+
+	action := fmt.Sprintf("Create a kubernetes cluster with %d nodes in %s", nodes, o.CreateReq.Region)
+	if o.CreateReq.HAControlPlanes {
+		action += ", not counting the high availability control planes,"
+	}
+
+	ok, err := utils.ConfirmPrice(yes, action, o.nodesPrice)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("kubernetes cluster create was cancelled")
+	}
+
+	return nil
+}
+
+// nodesPrice returns the monthly price of the nodes of the new cluster
+func (o *options) nodesPrice() (float64, error) {
+	total := 0.0
+	for i := range o.CreateReq.NodePools {
+		price, err := utils.PlanPrice(o.Base, o.CreateReq.NodePools[i].Plan)
+		if err != nil {
+			return 0, err
+		}
+		total += price * float64(o.CreateReq.NodePools[i].NodeQuantity)
+	}
+
+	return total, nil
+}
+
 func (o *options) create() (*govultr.Cluster, error) {
 	k8, _, err := o.Base.Client.Kubernetes.CreateCluster(o.Base.Context, o.CreateReq)
 	return k8, err
@@ -2,14 +2,22 @@
 package loadbalancer
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
@@ -44,7 +52,7 @@ var (
 	vultr-cli load-balancer create --region="lax" --balancing-algorithm="roundrobin" --label="Example Load Balancer" \
 		--port=80 --check-interval=10 --healthy-threshold=15
 
-	You must pass --region; other arguments are optional
+	You must pass --region or --file; other arguments are optional
 
 	#Shortened example with aliases
 	vultr-cli lb c -r="lax" -b="roundrobin" -l="Example Load Balancer" -p=80 -c=10
@@ -52,7 +60,96 @@ var (
 	#Full example with attached VPC
 	vultr-cli load-balancer create --region="lax"  --label="Example Load Balancer with VPC" \
 		--vpc="e951822b-10b2-4c5e-b333-bf38033e7175" --balancing-algorithm="leastconn"
+
+	# Create from a YAML or JSON spec and wait until it is active
+	vultr-cli load-balancer create --file lb.yaml --wait
+
+	# Example spec file
+	region: lax
+	label: web
+	balancing_algorithm: roundrobin
+	ssl_redirect: true
+	instances:
+	  - 7d726ffe-3be1-4d5c-8c8b-3b8d7e6a2d1f
+	health_check:
+	  protocol: http
+	  port: 80
+	  path: /health
+	sticky_session:
+	  cookie_name: session
+	forwarding_rules:
+	  - frontend_protocol: http
+	    frontend_port: 80
+	    backend_protocol: http
+	    backend_port: 80
+	  - frontend_protocol: https
+	    frontend_port: 443
+	    backend_protocol: http
+	    backend_port: 80
+	firewall_rules:
+	  - port: 443
+	    ip_type: v4
+	    source: 0.0.0.0/0
+	ssl:
+	  certificate_file: cert.pem
+	  private_key_file: key.pem
+	  chain_file: chain.pem
+	`
+	instanceLong    = `Attach or detach the instances behind a load balancer`
+	instanceExample = `
+	# Attach every instance tagged "web"
+	vultr-cli load-balancer instance attach 57539f6f-66a2-4580-936b-d0af934bce5d --tag web
+
+	# Detach the instances with the label "web-3"
+	vultr-cli load-balancer instance detach 57539f6f-66a2-4580-936b-d0af934bce5d --label web-3
+
+	# Attach instances by ID
+	vultr-cli load-balancer instance attach 57539f6f-66a2-4580-936b-d0af934bce5d \
+		--instances="8d2f4a1e-7bd1-4d8e-9a2c-1f0e3f5b6a7c,2c9d0b7e-5a64-4f3e-8d1b-6e2a7c4f9d0e"
+	`
+
+	healthLong = `Show the status of a load balancer and the state of each attached instance.
+
+The API does not report the results of the load balancer's own health checks,
+so the instance state is taken from the instance itself.  With --probe the
+configured health check is also run from this machine against the main IP of
+each instance, which requires the instances to be reachable from here.`
+	healthExample = `
+	# Show the health of a load balancer
+	vultr-cli load-balancer health 57539f6f-66a2-4580-936b-d0af934bce5d
+
+	# Run the health check against each instance every 5 seconds during a deploy
+	vultr-cli load-balancer health 57539f6f-66a2-4580-936b-d0af934bce5d --probe --watch --interval 5s
+	`
+
+	firewallLong = `Manage the firewall rules of a load balancer.  Rules are added and removed
+one at a time, leaving the other rules of the load balancer in place.`
+	firewallExample = `
+	# Allow HTTPS from anywhere over IPv4
+	vultr-cli load-balancer firewall-rule create 57539f6f-66a2-4580-936b-d0af934bce5d --port 443 \
+		--ip-type v4 --source 0.0.0.0/0
+
+	# Remove a rule
+	vultr-cli load-balancer firewall-rule delete 57539f6f-66a2-4580-936b-d0af934bce5d \
+		a1b2c3d4-4f56-7a89-b0c1-d2e3f4a5b6c7
 	`
+
+	sslSetLong = `Set the SSL certificate of a load balancer from PEM files on disk.  The
+certificate and private key are checked to be a matching pair before they are
+uploaded.  When run from a certbot deploy hook without --certificate and
+--private-key, the renewed certificate in RENEWED_LINEAGE is used.`
+	sslSetExample = `
+	# Full example
+	vultr-cli load-balancer ssl set 57539f6f-66a2-4580-936b-d0af934bce5d --cert cert.pem --key key.pem \
+		--chain chain.pem
+
+	# certbot deploy hook
+	certbot renew --deploy-hook 'vultr-cli load-balancer ssl set 57539f6f-66a2-4580-936b-d0af934bce5d'
+
+	# Remove the certificate
+	vultr-cli load-balancer ssl remove 57539f6f-66a2-4580-936b-d0af934bce5d
+	`
+
 	updateLong    = `Update a Load Balancer with the desired settings`
 	updateExample = `
 	# Full example
@@ -76,6 +173,7 @@ const (
 	loadBalancerDefaultPort               = 80
 	loadBalancerDefaultFrontendPort       = 80
 	loadBalancerDefaultBackendPort        = 80
+	loadBalancerHealthInterval            = 10 * time.Second
 )
 
 // NewCmdLoadBalancer provides the CLI command for load balancers
@@ -111,7 +209,7 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'summarize' for load balancer list : %v", errSu)
 			}
 
-			lbs, meta, err := o.list()
+			lbs, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error getting load balancer : %v", err)
 			}
@@ -139,6 +237,7 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 	list.Flags().BoolP("summarize", "", false, "(optional) Summarize the list output. One line per load balancer.")
 
 	// Get
@@ -172,6 +271,27 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Long:    createLong,
 		Example: createExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFl := cmd.Flags().GetString("file")
+			if errFl != nil {
+				return fmt.Errorf("error parsing flag 'file' for load balancer create : %v", errFl)
+			}
+
+			if file != "" {
+				spec := &loadBalancerSpec{}
+				if err := utils.ReadSpecFile(file, spec); err != nil {
+					return fmt.Errorf("error reading load balancer spec : %v", err)
+				}
+
+				req, errSp := spec.toCreateReq()
+				if errSp != nil {
+					return fmt.Errorf("error in load balancer spec : %v", errSp)
+				}
+
+				o.CreateReq = req
+
+				return o.runCreate(cmd)
+			}
+
 			region, errRg := cmd.Flags().GetString("region")
 			if errRg != nil {
 				return fmt.Errorf("error parsing flag 'region' for load balancer create : %v", errRg)
@@ -423,22 +543,20 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				}
 			}
 
-			lb, err := o.create()
-			if err != nil {
-				return fmt.Errorf("error creating load balancer : %v", err)
-			}
-
-			o.Base.Printer.Display(&LBPrinter{LB: lb}, nil)
-
-			return nil
+			return o.runCreate(cmd)
 		},
 	}
 
 	create.Flags().StringP("region", "r", "", "region id you wish to have the load balancer created in")
-	if err := create.MarkFlagRequired("region"); err != nil {
-		fmt.Printf("error marking load-balancer create 'region' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.Flags().String(
+		"file",
+		"",
+		`(optional) path to a YAML or JSON load balancer spec. Use "-" to read from stdin. Cannot be combined
+with the --region flag and the other create flags are ignored`,
+	)
+	create.MarkFlagsOneRequired("file", "region")
+	create.MarkFlagsMutuallyExclusive("file", "region")
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey, "file")
 
 	create.Flags().StringP(
 		"balancing-algorithm",
@@ -547,6 +665,7 @@ When not provided, load balancer defaults to public network.`,
 		"http-version",
 		0,
 		"(optional) Set HTTP version. Use 2 for HTTP2 or 3 for HTTP3. HTTP3 requires HTTP2 to be enabled.")
+	utils.AddWaitFlags(create, "the load balancer is active")
 
 	// Update
 	update := &cobra.Command{
@@ -896,8 +1015,11 @@ When not provided, load balancer defaults to public network.`,
 
 	// Set Load Balancer SSL Certificate
 	sslSet := &cobra.Command{
-		Use:   "set-certificate <Load Balancer ID>",
-		Short: "Set SSL certificate on a load balancer",
+		Use:     "set-certificate <Load Balancer ID>",
+		Short:   "Set SSL certificate on a load balancer",
+		Aliases: []string{"set"},
+		Long:    sslSetLong,
+		Example: sslSetExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please provide a load balancer ID")
@@ -910,26 +1032,39 @@ When not provided, load balancer defaults to public network.`,
 				return fmt.Errorf("error parsing flag 'certificate' for load balancer ssl set-certificate: %v", errCert)
 			}
 
-			rawCertificate, err := os.ReadFile(filepath.Clean(certificate))
-			if err != nil {
-				return fmt.Errorf("error reading certificate file: %v", err)
-			}
-
 			privateKey, errKey := cmd.Flags().GetString("private-key")
 			if errKey != nil {
 				return fmt.Errorf("error parsing flag 'private-key' for load balancer ssl set-certificate: %v", errKey)
 			}
 
-			rawPrivateKey, err := os.ReadFile(filepath.Clean(privateKey))
-			if err != nil {
-				return fmt.Errorf("error reading private key file: %v", err)
-			}
-
 			certificateChain, errChain := cmd.Flags().GetString("chain")
 			if errChain != nil {
 				return fmt.Errorf("error parsing flag 'chain' for load balancer ssl set-certificate: %v", errChain)
 			}
 
+			// certbot deploy hooks provide the renewed certificate directory
+			if lineage := os.Getenv("RENEWED_LINEAGE"); lineage != "" && certificate == "" && privateKey == "" {
+				certificate = filepath.Join(lineage, "cert.pem")
+				privateKey = filepath.Join(lineage, "privkey.pem")
+				if certificateChain == "" {
+					certificateChain = filepath.Join(lineage, "chain.pem")
+				}
+			}
+
+			if certificate == "" || privateKey == "" {
+				return errors.New("please provide --certificate and --private-key, or run from a certbot deploy hook")
+			}
+
+			rawCertificate, err := os.ReadFile(filepath.Clean(certificate))
+			if err != nil {
+				return fmt.Errorf("error reading certificate file: %v", err)
+			}
+
+			rawPrivateKey, err := os.ReadFile(filepath.Clean(privateKey))
+			if err != nil {
+				return fmt.Errorf("error reading private key file: %v", err)
+			}
+
 			var rawCertificateChain []byte
 
 			if certificateChain != "" {
@@ -944,6 +1079,12 @@ When not provided, load balancer defaults to public network.`,
 				return fmt.Errorf("error parsing flag 'base64' for load balancer ssl set-certificate: %v", errB64)
 			}
 
+			if !base64Encoded {
+				if err := validateCertificate(rawCertificate, rawPrivateKey); err != nil {
+					return err
+				}
+			}
+
 			o.UpdateReq = &govultr.LoadBalancerReq{
 				SSL: &govultr.SSL{},
 			}
@@ -968,25 +1109,25 @@ When not provided, load balancer defaults to public network.`,
 		},
 	}
 
-	sslSet.Flags().String("certificate", "", "Path to SSL certificate")
-	if err := sslSet.MarkFlagRequired("certificate"); err != nil {
-		fmt.Printf("error marking load-balancer ssl set-certificate 'certificate' flag required: %v", err)
-		os.Exit(1)
-	}
-
-	sslSet.Flags().String("private-key", "", "Path to SSL private key")
-	if err := sslSet.MarkFlagRequired("private-key"); err != nil {
-		fmt.Printf("error marking load-balancer ssl set-certificate 'private-key' flag required: %v", err)
-		os.Exit(1)
-	}
-
+	sslSet.Flags().String("certificate", "", "Path to SSL certificate (alias --cert)")
+	sslSet.Flags().String("private-key", "", "Path to SSL private key (alias --key)")
 	sslSet.Flags().String("chain", "", "(optional) Path to SSL certificate chain")
 	sslSet.Flags().Bool("base64", false, "Indicates SSL values are Base64-encoded")
+	sslSet.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		switch name {
+		case "cert":
+			name = "certificate"
+		case "key":
+			name = "private-key"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	// Remove Load Balancer SSL
 	sslDelete := &cobra.Command{
-		Use:   "delete <Load Balancer ID>",
-		Short: "Delete a load balancer SSL configuration",
+		Use:     "delete <Load Balancer ID>",
+		Short:   "Delete a load balancer SSL configuration",
+		Aliases: []string{"remove"},
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please provide a load balancer ID")
@@ -1078,8 +1219,9 @@ When not provided, load balancer defaults to public network.`,
 	)
 	// Forwarding Rules
 	forwarding := &cobra.Command{
-		Use:   "forwarding",
-		Short: "Commands to manage forwarding rules on a load balancer",
+		Use:     "forwarding",
+		Short:   "Commands to manage forwarding rules on a load balancer",
+		Aliases: []string{"rule", "rules"},
 	}
 
 	// List Forwarding Rules
@@ -1093,7 +1235,9 @@ When not provided, load balancer defaults to public network.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rules, meta, err := o.listForwardingRules()
+			o.Base.Options = utils.GetPaging(cmd)
+
+			rules, meta, err := utils.ListAll(cmd, o.Base.Options, o.listForwardingRules)
 			if err != nil {
 				return fmt.Errorf("error listing load balancer forwarding rules : %v", err)
 			}
@@ -1115,6 +1259,7 @@ When not provided, load balancer defaults to public network.`,
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(listForwardingRules)
 
 	// Get Forwarding Rule
 	getForwardingRule := &cobra.Command{
@@ -1259,8 +1404,11 @@ When not provided, load balancer defaults to public network.`,
 
 	// Firewall
 	firewall := &cobra.Command{
-		Use:   "firewall",
-		Short: "Commands to retrieve firewall rules on a load balancer",
+		Use:     "firewall",
+		Short:   "Commands to manage firewall rules on a load balancer",
+		Aliases: []string{"firewall-rule", "firewall-rules"},
+		Long:    firewallLong,
+		Example: firewallExample,
 	}
 
 	// List Firewall Rules
@@ -1274,7 +1422,9 @@ When not provided, load balancer defaults to public network.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rules, meta, err := o.listFirewallRules()
+			o.Base.Options = utils.GetPaging(cmd)
+
+			rules, meta, err := utils.ListAll(cmd, o.Base.Options, o.listFirewallRules)
 			if err != nil {
 				return fmt.Errorf("error listing load balancer firewall rules : %v", err)
 			}
@@ -1296,6 +1446,7 @@ When not provided, load balancer defaults to public network.`,
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(listFirewallRules)
 
 	// Get Firewall Rule
 	getFirewallRule := &cobra.Command{
@@ -1320,9 +1471,243 @@ When not provided, load balancer defaults to public network.`,
 		},
 	}
 
+	// Create Firewall Rule
+	createFirewallRule := &cobra.Command{
+		Use:   "create <Load Balancer ID>",
+		Short: "Create a firewall rule",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a load balancer ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			port, errPo := cmd.Flags().GetInt("port")
+			if errPo != nil {
+				return fmt.Errorf("error parsing flag 'port' for firewall rule create : %v", errPo)
+			}
+
+			ipType, errIP := cmd.Flags().GetString("ip-type")
+			if errIP != nil {
+				return fmt.Errorf("error parsing flag 'ip-type' for firewall rule create : %v", errIP)
+			}
+
+			source, errSo := cmd.Flags().GetString("source")
+			if errSo != nil {
+				return fmt.Errorf("error parsing flag 'source' for firewall rule create : %v", errSo)
+			}
+
+			if ipType != "v4" && ipType != "v6" {
+				return errors.New("ip-type must be v4 or v6")
+			}
+
+			rules, err := o.createFirewallRule(&govultr.LBFirewallRule{Port: port, IPType: ipType, Source: source})
+			if err != nil {
+				return fmt.Errorf("error creating load balancer firewall rule : %v", err)
+			}
+
+			data := &FWRulesPrinter{Rules: rules}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	createFirewallRule.Flags().Int("port", 0, "the port on the load balancer to allow traffic to")
+	if err := createFirewallRule.MarkFlagRequired("port"); err != nil {
+		fmt.Printf("error marking load-balancer firewall create 'port' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	createFirewallRule.Flags().String("ip-type", "v4", "the ip type of the source | v4, v6")
+	createFirewallRule.Flags().String("source", "", "the source subnet in CIDR notation or 'cloudflare'")
+	if err := createFirewallRule.MarkFlagRequired("source"); err != nil {
+		fmt.Printf("error marking load-balancer firewall create 'source' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	// Delete Firewall Rule
+	deleteFirewallRule := &cobra.Command{
+		Use:   "delete <Load Balancer ID> <Rule ID>",
+		Short: "Delete a firewall rule",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a load balancer ID and a rule ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.deleteFirewallRule(); err != nil {
+				return fmt.Errorf("error deleting load balancer firewall rule : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info("Firewall rule has been deleted"), nil)
+
+			return nil
+		},
+	}
+
 	firewall.AddCommand(
 		listFirewallRules,
 		getFirewallRule,
+		createFirewallRule,
+		deleteFirewallRule,
+	)
+
+	// Health
+	health := &cobra.Command{
+		Use:     "health <Load Balancer ID>",
+		Short:   "Show load balancer and instance health",
+		Long:    healthLong,
+		Example: healthExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a load balancer ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			probe, errPr := cmd.Flags().GetBool("probe")
+			if errPr != nil {
+				return fmt.Errorf("error parsing flag 'probe' for load balancer health : %v", errPr)
+			}
+
+			watch, errWa := cmd.Flags().GetBool("watch")
+			if errWa != nil {
+				return fmt.Errorf("error parsing flag 'watch' for load balancer health : %v", errWa)
+			}
+
+			interval, errIn := cmd.Flags().GetDuration("interval")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'interval' for load balancer health : %v", errIn)
+			}
+
+			if watch && o.Base.Printer.Output != "text" {
+				return errors.New("--watch is only supported with text output")
+			}
+
+			// the context is canceled by an interrupt
+			ctx := o.Base.Context
+
+			for {
+				data, err := o.health(probe)
+				if err != nil {
+					return fmt.Errorf("error getting load balancer health : %v", err)
+				}
+
+				if watch {
+					fmt.Printf("%s\n", time.Now().Format(time.RFC3339))
+				}
+
+				o.Base.Printer.Display(data, nil)
+
+				if !watch {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+					fmt.Println()
+				}
+			}
+		},
+	}
+
+	health.Flags().Bool("probe", false, "run the health check from this machine against each instance")
+	health.Flags().BoolP("watch", "w", false, "keep polling and printing the health until interrupted")
+	health.Flags().Duration("interval", loadBalancerHealthInterval, "time between polls when watching")
+
+	// Instance
+	instance := &cobra.Command{
+		Use:     "instance",
+		Short:   "Commands to manage load balancer instances",
+		Long:    instanceLong,
+		Example: instanceExample,
+	}
+
+	// instanceRun returns the RunE for attach and detach, which differ only in
+	// how the matched instances are combined with the attached ones
+	instanceRun := func(attach bool) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			tag, errTa := cmd.Flags().GetString("tag")
+			if errTa != nil {
+				return fmt.Errorf("error parsing flag 'tag' for load balancer instance : %v", errTa)
+			}
+
+			label, errLa := cmd.Flags().GetString("label")
+			if errLa != nil {
+				return fmt.Errorf("error parsing flag 'label' for load balancer instance : %v", errLa)
+			}
+
+			ids, errIn := cmd.Flags().GetStringSlice("instances")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'instances' for load balancer instance : %v", errIn)
+			}
+
+			if tag != "" || label != "" {
+				matched, err := o.findInstances(tag, label)
+				if err != nil {
+					return fmt.Errorf("error finding instances : %v", err)
+				}
+
+				if len(matched) == 0 {
+					return errors.New("no instances matched the tag or label")
+				}
+
+				ids = append(ids, matched...)
+			}
+
+			changed, err := o.setInstances(ids, attach)
+			if err != nil {
+				return fmt.Errorf("error updating load balancer instances : %v", err)
+			}
+
+			action := "detached from"
+			if attach {
+				action = "attached to"
+			}
+
+			o.Base.Printer.Display(printer.Info(fmt.Sprintf("%d instance(s) %s load balancer", changed, action)), nil)
+
+			return nil
+		}
+	}
+
+	instanceArgs := func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("please provide a load balancer ID")
+		}
+		return nil
+	}
+
+	// Instance Attach
+	instanceAttach := &cobra.Command{
+		Use:   "attach <Load Balancer ID>",
+		Short: "Attach instances matched by tag, label or ID",
+		Args:  instanceArgs,
+		RunE:  instanceRun(true),
+	}
+
+	// Instance Detach
+	instanceDetach := &cobra.Command{
+		Use:   "detach <Load Balancer ID>",
+		Short: "Detach instances matched by tag, label or ID",
+		Args:  instanceArgs,
+		RunE:  instanceRun(false),
+	}
+
+	for _, c := range []*cobra.Command{instanceAttach, instanceDetach} {
+		c.Flags().String("tag", "", "match the instances with this tag")
+		c.Flags().String("label", "", "match the instances with this label")
+		c.Flags().StringSlice("instances", []string{}, "a comma-separated list of instance IDs")
+		c.MarkFlagsOneRequired("tag", "label", "instances")
+	}
+
+	instance.AddCommand(
+		instanceAttach,
+		instanceDetach,
 	)
 
 	cmd.AddCommand(
@@ -1334,8 +1719,12 @@ When not provided, load balancer defaults to public network.`,
 		forwarding,
 		firewall,
 		ssl,
+		instance,
+		health,
 	)
 
+	utils.RegisterCompletion(cmd, "<Load Balancer ID>", utils.CompleteLoadBalancers(o.Base))
+
 	return cmd
 }
 
@@ -1351,6 +1740,38 @@ func (o *options) list() ([]govultr.LoadBalancer, *govultr.Meta, error) {
 	return lbs, meta, err
 }
 
+// runCreate creates the load balancer from the create request, waiting for it
+// when --wait is given
+func (o *options) runCreate(cmd *cobra.Command) error {
+	wait, timeout, errWa := utils.GetWaitFlags(cmd)
+	if errWa != nil {
+		return errWa
+	}
+
+	lb, err := o.create()
+	if err != nil {
+		return fmt.Errorf("error creating load balancer : %v", err)
+	}
+
+	if wait {
+		id := lb.ID
+		err = o.Base.Wait("load balancer "+id, timeout, func() (string, bool, error) {
+			var errGe error
+			if lb, _, errGe = o.Base.Client.LoadBalancer.Get(o.Base.Context, id); errGe != nil {
+				return "", false, errGe
+			}
+			return lb.Status, lb.Status == "active", nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	o.Base.Printer.Display(&LBPrinter{LB: lb}, nil)
+
+	return nil
+}
+
 func (o *options) get() (*govultr.LoadBalancer, error) {
 	lb, _, err := o.Base.Client.LoadBalancer.Get(o.Base.Context, o.Base.Args[0])
 	return lb, err
@@ -1406,8 +1827,405 @@ func (o *options) getFirewallRule() (*govultr.LBFirewallRule, error) {
 	return r, err
 }
 
+// health collects the load balancer status along with the state of each
+// attached instance, optionally probing the instances with the health check
+func (o *options) health(probe bool) (*LBHealthPrinter, error) {
+	lb, err := o.get()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &LBHealthPrinter{LB: lb, Instances: []instanceHealth{}}
+	for i := range lb.Instances {
+		h := instanceHealth{ID: lb.Instances[i]}
+
+		inst, _, err := o.Base.Client.Instance.Get(o.Base.Context, lb.Instances[i])
+		if err != nil {
+			h.Error = err.Error()
+			data.Instances = append(data.Instances, h)
+			continue
+		}
+
+		h.Label = inst.Label
+		h.MainIP = inst.MainIP
+		h.Status = inst.Status
+		h.PowerStatus = inst.PowerStatus
+		h.ServerStatus = inst.ServerStatus
+
+		if probe && lb.HealthCheck != nil {
+			h.Probe = probeHealth(o.Base.Context, lb.HealthCheck, inst.MainIP)
+		}
+
+		data.Instances = append(data.Instances, h)
+	}
+
+	return data, nil
+}
+
+// probeHealth runs the health check against the address and returns "ok" or
+// the reason the check failed
+func probeHealth(ctx context.Context, hc *govultr.HealthCheck, ip string) string {
+	timeout := time.Duration(hc.ResponseTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = loadBalancerDefaultResponseTimeout * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(ip, strconv.Itoa(hc.Port))
+	protocol := strings.ToLower(hc.Protocol)
+
+	if protocol == "tcp" {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err.Error()
+		}
+		conn.Close()
+		return "ok"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s%s", protocol, addr, hc.Path), nil)
+	if err != nil {
+		return err.Error()
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err.Error()
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.Status
+	}
+
+	return "ok"
+}
+
+// createFirewallRule adds the rule to the load balancer firewall rules and
+// returns the resulting rules.  The API has no endpoint for single firewall
+// rules so the full set is written back
+func (o *options) createFirewallRule(rule *govultr.LBFirewallRule) ([]govultr.LBFirewallRule, error) {
+	lb, err := o.get()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := append(lb.FirewallRules, *rule)
+	if err := o.setFirewallRules(rules); err != nil {
+		return nil, err
+	}
+
+	lb, err = o.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lb.FirewallRules, nil
+}
+
+// deleteFirewallRule removes the rule from the load balancer firewall rules
+func (o *options) deleteFirewallRule() error {
+	lb, err := o.get()
+	if err != nil {
+		return err
+	}
+
+	rules := []govultr.LBFirewallRule{}
+	for i := range lb.FirewallRules {
+		if lb.FirewallRules[i].RuleID != o.Base.Args[1] {
+			rules = append(rules, lb.FirewallRules[i])
+		}
+	}
+
+	if len(rules) == len(lb.FirewallRules) {
+		return fmt.Errorf("firewall rule %s not found", o.Base.Args[1])
+	}
+
+	return o.setFirewallRules(rules)
+}
+
+// setFirewallRules replaces the load balancer firewall rules.  The request is
+// built here rather than through LoadBalancerReq since an empty rule list has
+// to be sent to remove the last rule
+func (o *options) setFirewallRules(rules []govultr.LBFirewallRule) error {
+	for i := range rules {
+		rules[i].RuleID = ""
+	}
+
+	body := struct {
+		FirewallRules []govultr.LBFirewallRule `json:"firewall_rules"`
+	}{FirewallRules: rules}
+
+	uri := fmt.Sprintf("/v2/load-balancers/%s", o.Base.Args[0])
+	req, err := o.Base.Client.NewRequest(o.Base.Context, http.MethodPatch, uri, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.Base.Client.DoWithContext(o.Base.Context, req, nil)
+	return err
+}
+
+// findInstances returns the IDs of the instances with the tag and label
+func (o *options) findInstances(tag, label string) ([]string, error) {
+	var ids []string
+	insts, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		opts.Tag = tag
+		opts.Label = label
+		insts, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return insts, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range insts {
+		ids = append(ids, insts[i].ID)
+	}
+
+	return ids, nil
+}
+
+// setInstances attaches the instances to, or detaches them from, the load
+// balancer and returns the number of instances which changed
+func (o *options) setInstances(ids []string, attach bool) (int, error) {
+	lb, err := o.get()
+	if err != nil {
+		return 0, err
+	}
+
+	attached := make(map[string]bool, len(lb.Instances))
+	for i := range lb.Instances {
+		attached[lb.Instances[i]] = true
+	}
+
+	changed := 0
+	for i := range ids {
+		if attached[ids[i]] != attach {
+			attached[ids[i]] = attach
+			changed++
+		}
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+
+	var instances []string
+	for i := range lb.Instances {
+		if attached[lb.Instances[i]] {
+			instances = append(instances, lb.Instances[i])
+		}
+	}
+
+	if attach {
+		for i := range ids {
+			if !slices.Contains(instances, ids[i]) {
+				instances = append(instances, ids[i])
+			}
+		}
+	}
+
+	if len(instances) == 0 {
+		return 0, errors.New("a load balancer must keep at least one attached instance")
+	}
+
+	o.UpdateReq = &govultr.LoadBalancerReq{Instances: instances}
+
+	return changed, o.update()
+}
+
+// validateCertificate checks the PEM certificate and private key form a valid
+// pair which has not expired
+func validateCertificate(cert, key []byte) error {
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return fmt.Errorf("invalid certificate and private key : %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("invalid certificate : %v", err)
+	}
+
+	if time.Now().After(leaf.NotAfter) {
+		return fmt.Errorf("certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 // ======================================
 
+// loadBalancerSpec is the declarative load balancer definition accepted by
+// the create command's --file option
+type loadBalancerSpec struct {
+	Region             string   `yaml:"region"`
+	Label              string   `yaml:"label"`
+	Instances          []string `yaml:"instances"`
+	Nodes              int      `yaml:"nodes"`
+	BalancingAlgorithm string   `yaml:"balancing_algorithm"`
+	SSLRedirect        *bool    `yaml:"ssl_redirect"`
+	HTTP2              *bool    `yaml:"http2"`
+	HTTP3              *bool    `yaml:"http3"`
+	ProxyProtocol      *bool    `yaml:"proxy_protocol"`
+	Timeout            int      `yaml:"timeout"`
+	VPC                string   `yaml:"vpc"`
+	GlobalRegions      []string `yaml:"global_regions"`
+	HealthCheck        *struct {
+		Protocol           string `yaml:"protocol"`
+		Port               int    `yaml:"port"`
+		Path               string `yaml:"path"`
+		CheckInterval      int    `yaml:"check_interval"`
+		ResponseTimeout    int    `yaml:"response_timeout"`
+		UnhealthyThreshold int    `yaml:"unhealthy_threshold"`
+		HealthyThreshold   int    `yaml:"healthy_threshold"`
+	} `yaml:"health_check"`
+	StickySession *struct {
+		CookieName string `yaml:"cookie_name"`
+	} `yaml:"sticky_session"`
+	ForwardingRules []struct {
+		FrontendProtocol string `yaml:"frontend_protocol"`
+		FrontendPort     int    `yaml:"frontend_port"`
+		BackendProtocol  string `yaml:"backend_protocol"`
+		BackendPort      int    `yaml:"backend_port"`
+	} `yaml:"forwarding_rules"`
+	FirewallRules []struct {
+		Port   int    `yaml:"port"`
+		IPType string `yaml:"ip_type"`
+		Source string `yaml:"source"`
+	} `yaml:"firewall_rules"`
+	SSL *struct {
+		Certificate     string `yaml:"certificate"`
+		PrivateKey      string `yaml:"private_key"`
+		Chain           string `yaml:"chain"`
+		CertificateFile string `yaml:"certificate_file"`
+		PrivateKeyFile  string `yaml:"private_key_file"`
+		ChainFile       string `yaml:"chain_file"`
+	} `yaml:"ssl"`
+	AutoSSL *struct {
+		DomainZone string `yaml:"domain_zone"`
+		DomainSub  string `yaml:"domain_sub"`
+	} `yaml:"auto_ssl"`
+}
+
+// toCreateReq validates the spec and converts it to a load balancer create
+// request, reading any SSL files it references
+func (l *loadBalancerSpec) toCreateReq() (*govultr.LoadBalancerReq, error) { //nolint:gocyclo
+	if l.Region == "" {
+		return nil, errors.New("region is required")
+	}
+
+	req := &govultr.LoadBalancerReq{
+		Region:             l.Region,
+		Label:              l.Label,
+		Instances:          l.Instances,
+		Nodes:              l.Nodes,
+		BalancingAlgorithm: l.BalancingAlgorithm,
+		SSLRedirect:        l.SSLRedirect,
+		HTTP2:              l.HTTP2,
+		HTTP3:              l.HTTP3,
+		ProxyProtocol:      l.ProxyProtocol,
+		Timeout:            l.Timeout,
+		GlobalRegions:      l.GlobalRegions,
+	}
+
+	if l.VPC != "" {
+		req.VPC = govultr.StringToStringPtr(l.VPC)
+	}
+
+	if l.HealthCheck != nil {
+		req.HealthCheck = &govultr.HealthCheck{
+			Protocol:           l.HealthCheck.Protocol,
+			Port:               l.HealthCheck.Port,
+			Path:               l.HealthCheck.Path,
+			CheckInterval:      l.HealthCheck.CheckInterval,
+			ResponseTimeout:    l.HealthCheck.ResponseTimeout,
+			UnhealthyThreshold: l.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   l.HealthCheck.HealthyThreshold,
+		}
+	}
+
+	if l.StickySession != nil {
+		req.StickySessions = &govultr.StickySessions{CookieName: l.StickySession.CookieName}
+	}
+
+	for i := range l.ForwardingRules {
+		r := l.ForwardingRules[i]
+		if r.FrontendProtocol == "" || r.FrontendPort == 0 || r.BackendProtocol == "" || r.BackendPort == 0 {
+			return nil, fmt.Errorf("forwarding rule %d must include frontend and backend ports and protocols", i+1)
+		}
+
+		req.ForwardingRules = append(req.ForwardingRules, govultr.ForwardingRule{
+			FrontendProtocol: r.FrontendProtocol,
+			FrontendPort:     r.FrontendPort,
+			BackendProtocol:  r.BackendProtocol,
+			BackendPort:      r.BackendPort,
+		})
+	}
+
+	for i := range l.FirewallRules {
+		r := l.FirewallRules[i]
+		if r.Port == 0 || r.IPType == "" || r.Source == "" {
+			return nil, fmt.Errorf("firewall rule %d must include port, ip_type and source", i+1)
+		}
+
+		req.FirewallRules = append(req.FirewallRules, govultr.LBFirewallRule{
+			Port:   r.Port,
+			IPType: r.IPType,
+			Source: r.Source,
+		})
+	}
+
+	if l.SSL != nil {
+		ssl := &govultr.SSL{Certificate: l.SSL.Certificate, PrivateKey: l.SSL.PrivateKey, Chain: l.SSL.Chain}
+		files := []struct {
+			path string
+			dest *string
+		}{
+			{l.SSL.CertificateFile, &ssl.Certificate},
+			{l.SSL.PrivateKeyFile, &ssl.PrivateKey},
+			{l.SSL.ChainFile, &ssl.Chain},
+		}
+
+		for _, f := range files {
+			if f.path == "" {
+				continue
+			}
+
+			raw, err := os.ReadFile(filepath.Clean(f.path))
+			if err != nil {
+				return nil, fmt.Errorf("error reading ssl file %s : %v", f.path, err)
+			}
+			*f.dest = string(raw)
+		}
+
+		if ssl.Certificate == "" || ssl.PrivateKey == "" {
+			return nil, errors.New("ssl requires a certificate and private key")
+		}
+
+		req.SSL = ssl
+	}
+
+	if l.AutoSSL != nil {
+		req.AutoSSL = &govultr.AutoSSL{DomainZone: l.AutoSSL.DomainZone, DomainSub: l.AutoSSL.DomainSub}
+	}
+
+	return req, nil
+}
+
 // formatFirewallRules parses forwarding rules into proper format
 func formatFirewallRules(rules []string) ([]govultr.LBFirewallRule, error) {
 	var formattedList []govultr.LBFirewallRule
@@ -1,6 +1,7 @@
 package loadbalancer
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -517,3 +518,90 @@ func (f *FWRulePrinter) Data() [][]string {
 func (f *FWRulePrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// instanceHealth is the state of an instance attached to a load balancer
+type instanceHealth struct {
+	ID           string `json:"id"`
+	Label        string `json:"label"`
+	MainIP       string `json:"main_ip"`
+	Status       string `json:"status"`
+	PowerStatus  string `json:"power_status"`
+	ServerStatus string `json:"server_status"`
+	Probe        string `json:"probe,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// LBHealthPrinter ...
+type LBHealthPrinter struct {
+	LB        *govultr.LoadBalancer `json:"load_balancer"`
+	Instances []instanceHealth      `json:"instances"`
+}
+
+// JSON ...
+func (l *LBHealthPrinter) JSON() []byte {
+	return printer.MarshalObject(l, "json")
+}
+
+// YAML ...
+func (l *LBHealthPrinter) YAML() []byte {
+	return printer.MarshalObject(l, "yaml")
+}
+
+// Columns ...
+func (l *LBHealthPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (l *LBHealthPrinter) Data() [][]string {
+	var data [][]string
+	data = append(data,
+		[]string{"ID", l.LB.ID},
+		[]string{"LABEL", l.LB.Label},
+		[]string{"STATUS", l.LB.Status},
+		[]string{"NODES", strconv.Itoa(l.LB.Nodes)},
+	)
+
+	if l.LB.HealthCheck != nil {
+		data = append(data, []string{
+			"HEALTH CHECK",
+			fmt.Sprintf("%s :%d%s", l.LB.HealthCheck.Protocol, l.LB.HealthCheck.Port, l.LB.HealthCheck.Path),
+		})
+	}
+
+	data = append(data,
+		[]string{" "},
+		[]string{"INSTANCES"},
+		[]string{"ID", "LABEL", "MAIN IP", "STATUS", "POWER STATUS", "SERVER STATUS", "PROBE"},
+	)
+
+	if len(l.Instances) == 0 {
+		data = append(data, []string{"---", "---", "---", "---", "---", "---", "---"})
+	}
+
+	for i := range l.Instances {
+		probe := l.Instances[i].Probe
+		if l.Instances[i].Error != "" {
+			probe = l.Instances[i].Error
+		}
+
+		data = append(data, []string{
+			l.Instances[i].ID,
+			l.Instances[i].Label,
+			l.Instances[i].MainIP,
+			l.Instances[i].Status,
+			l.Instances[i].PowerStatus,
+			l.Instances[i].ServerStatus,
+			probe,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (l *LBHealthPrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs the CLI instead of the tests when VULTR_CLI_TEST_MAIN is
+// set, so tests can run the test binary as vultr-cli, along with the
+// commands it starts itself such as those of a batch
+func TestMain(m *testing.M) {
+	if os.Getenv("VULTR_CLI_TEST_MAIN") != "" {
+		Execute()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,175 @@
+// Package manifest provides the apply and diff commands which converge the
+// resources of an account to a declarative file
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+var (
+	applyLong = `Converge the firewall groups, instances, DNS domains and load balancers of the account to a
+YAML or JSON manifest.
+
+Resources are matched by the description of a firewall group, the label of an instance or load
+balancer and the name of a domain.  Missing resources are created and those which differ are updated.
+The rules of each firewall group and the records of each domain are converged to exactly those in the
+manifest, so rules and records which are not in the file are deleted.  Resources which are not in the
+manifest are never touched.
+
+An instance may name its firewall group and a load balancer its instances by the names used in the
+manifest.  Fields which can only be chosen at creation, such as the region or operating system of an
+instance, are not compared once the resource exists.
+`
+	applyExample = `
+	# Full example
+	vultr-cli apply -f resources.yaml
+
+	# Example file
+	firewall_groups:
+	  - description: web
+	    rules:
+	      - protocol: tcp
+	        port: "443"
+	        subnet: 0.0.0.0/0
+	instances:
+	  - label: web-1
+	    region: ewr
+	    plan: vc2-1c-1gb
+	    os_id: 2284
+	    tags: [web]
+	    firewall_group: web
+	domains:
+	  - domain: example.com
+	    records:
+	      - name: www
+	        type: A
+	        data: 192.0.2.10
+	        ttl: 300
+	load_balancers:
+	  - label: web
+	    region: ewr
+	    instances: [web-1]
+	    forwarding_rules:
+	      - frontend_protocol: http
+	        frontend_port: 80
+	        backend_protocol: http
+	        backend_port: 80
+	`
+
+	diffLong = `Show the changes 'apply' would make to converge the account to a YAML or JSON manifest,
+without making them.  See 'vultr-cli apply --help' for the format of the manifest.
+`
+	diffExample = `
+	# Full example
+	vultr-cli diff -f resources.yaml
+
+	# Read the manifest from stdin
+	cat resources.yaml | vultr-cli diff -f -
+	`
+)
+
+// NewCmdApply provides the CLI command for applying a manifest
+func NewCmdApply(base *cli.Base) *cobra.Command {
+	o := &options{Base: base, apply: true}
+
+	cmd := &cobra.Command{
+		Use:               "apply",
+		Short:             "Converge resources to a manifest file",
+		Long:              applyLong,
+		Example:           applyExample,
+		PersistentPreRunE: o.preRun,
+		RunE:              o.run,
+	}
+
+	addFileFlag(cmd)
+
+	return cmd
+}
+
+// NewCmdDiff provides the CLI command for showing the changes of a manifest
+func NewCmdDiff(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:               "diff",
+		Short:             "Show the changes apply would make for a manifest file",
+		Long:              diffLong,
+		Example:           diffExample,
+		PersistentPreRunE: o.preRun,
+		RunE:              o.run,
+	}
+
+	addFileFlag(cmd)
+
+	return cmd
+}
+
+// addFileFlag adds the required manifest flag
+func addFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "path to a YAML or JSON manifest or - to read from stdin")
+	if err := cmd.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking %s 'file' flag required: %v", cmd.Name(), err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	Base  *cli.Base
+	apply bool
+}
+
+// preRun sets the options and checks for an API key
+func (o *options) preRun(cmd *cobra.Command, args []string) error {
+	utils.SetOptions(o.Base, cmd, args)
+	if !o.Base.HasAuth {
+		return errors.New(utils.APIKeyError)
+	}
+	return nil
+}
+
+// run plans the changes for the manifest and, for apply, makes them
+func (o *options) run(cmd *cobra.Command, args []string) error {
+	file, errFi := cmd.Flags().GetString("file")
+	if errFi != nil {
+		return fmt.Errorf("error parsing flag 'file' for %s : %v", cmd.Name(), errFi)
+	}
+
+	spec := &manifestSpec{}
+	if err := utils.ReadSpecFile(file, spec); err != nil {
+		return err
+	}
+
+	if err := spec.validate(); err != nil {
+		return fmt.Errorf("invalid manifest %s : %v", file, err)
+	}
+
+	e := newEngine(o.Base)
+	if err := e.plan(spec); err != nil {
+		return fmt.Errorf("error planning changes : %v", err)
+	}
+
+	if len(e.changes) == 0 {
+		o.Base.Printer.Display(printer.Info("resources are up to date"), nil)
+		return nil
+	}
+
+	if o.apply {
+		e.apply()
+	}
+
+	data := &ChangesPrinter{Changes: e.changes}
+	o.Base.Printer.Display(data, nil)
+
+	if failed := data.failed(); failed > 0 {
+		return fmt.Errorf("%d of %d changes failed to apply", failed, len(e.changes))
+	}
+
+	return nil
+}
@@ -0,0 +1,741 @@
+package manifest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/dns"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	kindFirewallGroup = "firewall group"
+	kindFirewallRule  = "firewall rule"
+	kindInstance      = "instance"
+	kindDomain        = "domain"
+	kindRecord        = "dns record"
+	kindLoadBalancer  = "load balancer"
+
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+
+	statusPlanned = "planned"
+	statusDone    = "done"
+	statusFailed  = "failed"
+)
+
+// idAmbiguous marks a name shared by more than one live resource
+const idAmbiguous = "?"
+
+// change is a single step of the plan which converges the live resources to
+// the manifest
+type change struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	ID     string `json:"id,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	// apply makes the change and returns the ID of any resource it created
+	apply func() (string, error)
+}
+
+// engine plans and applies the changes for a manifest
+type engine struct {
+	base *cli.Base
+	// ids maps the kind and name of every known resource to its ID.  The
+	// resources created by the plan map to an empty ID until they are created
+	ids           map[string]string
+	instances     map[string]govultr.Instance
+	domains       map[string]bool
+	loadBalancers map[string]govultr.LoadBalancer
+	changes       []*change
+}
+
+// newEngine returns an engine with an empty plan
+func newEngine(base *cli.Base) *engine {
+	return &engine{
+		base:          base,
+		ids:           map[string]string{},
+		instances:     map[string]govultr.Instance{},
+		domains:       map[string]bool{},
+		loadBalancers: map[string]govultr.LoadBalancer{},
+	}
+}
+
+// refKey is the key of a resource in the ids map
+func refKey(kind, name string) string {
+	return kind + "/" + strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// register records the ID of a live resource by its name
+func (e *engine) register(kind, name, id string) {
+	if name == "" {
+		return
+	}
+
+	key := refKey(kind, name)
+	if existing, ok := e.ids[key]; ok && existing != id {
+		e.ids[key] = idAmbiguous
+		return
+	}
+	e.ids[key] = id
+}
+
+// lookup returns the ID of the live resource with the name, if there is one
+func (e *engine) lookup(kind, name string) (string, bool, error) {
+	id, ok := e.ids[refKey(kind, name)]
+	if id == idAmbiguous {
+		return "", false, fmt.Errorf("more than one %s is named %q, rename them so they can be told apart", kind, name)
+	}
+	return id, ok, nil
+}
+
+// ref returns the ID of the resource referred to by the name.  Names which
+// are not known are assumed to be IDs already
+func (e *engine) ref(kind, name string) (string, error) {
+	id, ok, err := e.lookup(kind, name)
+	switch {
+	case err != nil:
+		return "", err
+	case !ok:
+		return name, nil
+	case id == "":
+		return "", fmt.Errorf("%s %q has not been created", kind, name)
+	}
+	return id, nil
+}
+
+// add appends a planned change
+func (e *engine) add(c *change) {
+	c.Status = statusPlanned
+	e.changes = append(e.changes, c)
+}
+
+// plan loads the live resources and works out the changes for the
+// manifest.  Resources which are not in the manifest are never touched, but
+// the records of a domain and the rules of a firewall group are converged
+// to exactly those in the manifest
+func (e *engine) plan(m *manifestSpec) error {
+	if err := e.load(m); err != nil {
+		return err
+	}
+
+	for i := range m.FirewallGroups {
+		if err := e.planFirewallGroup(&m.FirewallGroups[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range m.Instances {
+		if err := e.planInstance(&m.Instances[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range m.Domains {
+		if err := e.planDomain(&m.Domains[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range m.LoadBalancers {
+		if err := e.planLoadBalancer(&m.LoadBalancers[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply makes the planned changes in order.  A failed change does not stop
+// the rest, though changes which depend on a resource that could not be
+// created fail with it
+func (e *engine) apply() {
+	for _, c := range e.changes {
+		id, err := c.apply()
+		if err != nil {
+			c.Status = statusFailed
+			c.Error = err.Error()
+			continue
+		}
+
+		if id != "" {
+			c.ID = id
+		}
+		c.Status = statusDone
+	}
+}
+
+// load retrieves the live resources of the kinds used by the manifest
+func (e *engine) load(m *manifestSpec) error {
+	ctx := e.base.Context
+
+	if len(m.FirewallGroups) > 0 || len(m.Instances) > 0 {
+		groups, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.FirewallGroup.List(ctx, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving firewall groups : %v", err)
+		}
+		for i := range groups {
+			e.register(kindFirewallGroup, groups[i].Description, groups[i].ID)
+		}
+	}
+
+	if len(m.Instances) > 0 || len(m.LoadBalancers) > 0 {
+		instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.Instance.List(ctx, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving instances : %v", err)
+		}
+		for i := range instances {
+			e.register(kindInstance, instances[i].Label, instances[i].ID)
+			e.instances[instances[i].ID] = instances[i]
+		}
+	}
+
+	if len(m.Domains) > 0 {
+		domains, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Domain, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.Domain.List(ctx, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving domains : %v", err)
+		}
+		for i := range domains {
+			e.domains[refKey(kindDomain, domains[i].Domain)] = true
+		}
+	}
+
+	if len(m.LoadBalancers) > 0 {
+		lbs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.LoadBalancer.List(ctx, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving load balancers : %v", err)
+		}
+		for i := range lbs {
+			e.register(kindLoadBalancer, lbs[i].Label, lbs[i].ID)
+			e.loadBalancers[lbs[i].ID] = lbs[i]
+		}
+	}
+
+	return nil
+}
+
+// ======================================
+
+// planFirewallGroup plans the group, when it is missing, and the rules to
+// create and delete.  Rules are created before they are deleted so the
+// group is never left more closed than either the old or new rules
+func (e *engine) planFirewallGroup(spec *firewallGroupSpec) error {
+	name := spec.Description
+	id, found, err := e.lookup(kindFirewallGroup, name)
+	if err != nil {
+		return err
+	}
+
+	var live []govultr.FirewallRule
+	if found {
+		live, err = utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallRule, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.FirewallRule.List(e.base.Context, id, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving the rules of firewall group %q : %v", name, err)
+		}
+	} else {
+		e.ids[refKey(kindFirewallGroup, name)] = ""
+		e.add(&change{Kind: kindFirewallGroup, Name: name, Action: actionCreate, apply: func() (string, error) {
+			group, _, err := e.base.Client.FirewallGroup.Create(e.base.Context, &govultr.FirewallGroupReq{Description: name})
+			if err != nil {
+				return "", err
+			}
+			e.ids[refKey(kindFirewallGroup, name)] = group.ID
+			return group.ID, nil
+		}})
+	}
+
+	used := make([]bool, len(live))
+	for i := range spec.Rules {
+		req, err := spec.Rules[i].ToReq()
+		if err != nil {
+			return fmt.Errorf("firewall group %q rule %d : %v", name, i+1, err)
+		}
+		key := utils.FirewallRuleKey(req)
+
+		found := false
+		for j := range live {
+			if !used[j] && utils.FirewallRuleKey(utils.FirewallRuleToReq(&live[j])) == key {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		e.add(&change{Kind: kindFirewallRule, Name: name, Action: actionCreate, Detail: describeRule(req),
+			apply: func() (string, error) {
+				groupID, err := e.ref(kindFirewallGroup, name)
+				if err != nil {
+					return "", err
+				}
+				rule, _, err := e.base.Client.FirewallRule.Create(e.base.Context, groupID, req)
+				if err != nil {
+					return "", err
+				}
+				return strconv.Itoa(rule.ID), nil
+			}})
+	}
+
+	for i := range live {
+		if used[i] {
+			continue
+		}
+
+		ruleID := live[i].ID
+		e.add(&change{
+			Kind:   kindFirewallRule,
+			Name:   name,
+			Action: actionDelete,
+			ID:     strconv.Itoa(ruleID),
+			Detail: describeRule(utils.FirewallRuleToReq(&live[i])),
+			apply: func() (string, error) {
+				return "", e.base.Client.FirewallRule.Delete(e.base.Context, id, ruleID)
+			},
+		})
+	}
+
+	return nil
+}
+
+// describeRule summarizes the traffic a firewall rule allows
+func describeRule(req *govultr.FirewallRuleReq) string {
+	from := req.Source
+	if from == "" {
+		from = utils.FormatFirewallNetwork(req.Subnet, req.SubnetSize)
+	}
+	return strings.Join(strings.Fields(fmt.Sprintf("%s %s %s from %s", req.IPType, req.Protocol, req.Port, from)), " ")
+}
+
+// ======================================
+
+// planInstance plans the instance when it is missing, or the update of its
+// plan, tags and firewall group.  Fields which can only be chosen when the
+// instance is created, such as the region and operating system, are not
+// compared
+func (e *engine) planInstance(spec *instanceSpec) error {
+	id, found, err := e.lookup(kindInstance, spec.Label)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		e.ids[refKey(kindInstance, spec.Label)] = ""
+		e.add(&change{
+			Kind:   kindInstance,
+			Name:   spec.Label,
+			Action: actionCreate,
+			Detail: fmt.Sprintf("%s in %s", spec.Plan, spec.Region),
+			apply:  func() (string, error) { return e.createInstance(spec) },
+		})
+		return nil
+	}
+
+	live := e.instances[id]
+	var diffs []string
+
+	if spec.Plan != live.Plan {
+		diffs = append(diffs, fmt.Sprintf("plan %s -> %s", live.Plan, spec.Plan))
+	}
+
+	if spec.Tags != nil && !sameSet(spec.Tags, live.Tags) {
+		diffs = append(diffs, fmt.Sprintf("tags [%s] -> [%s]", strings.Join(live.Tags, ","), strings.Join(spec.Tags, ",")))
+	}
+
+	if spec.FirewallGroup != "" {
+		groupID, found, err := e.lookup(kindFirewallGroup, spec.FirewallGroup)
+		if err != nil {
+			return err
+		}
+		if !found {
+			groupID = spec.FirewallGroup
+		}
+		if groupID != live.FirewallGroupID {
+			diffs = append(diffs, fmt.Sprintf("firewall group -> %s", spec.FirewallGroup))
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	e.add(&change{
+		Kind:   kindInstance,
+		Name:   spec.Label,
+		Action: actionUpdate,
+		ID:     id,
+		Detail: strings.Join(diffs, ", "),
+		apply: func() (string, error) {
+			req := &govultr.InstanceUpdateReq{Tags: live.Tags}
+			if spec.Plan != live.Plan {
+				req.Plan = spec.Plan
+			}
+			if spec.Tags != nil {
+				req.Tags = spec.Tags
+			}
+			if spec.FirewallGroup != "" {
+				groupID, err := e.ref(kindFirewallGroup, spec.FirewallGroup)
+				if err != nil {
+					return "", err
+				}
+				req.FirewallGroupID = groupID
+			}
+
+			_, _, err := e.base.Client.Instance.Update(e.base.Context, id, req)
+			return "", err
+		},
+	})
+
+	return nil
+}
+
+// createInstance creates the instance, resolving its firewall group
+func (e *engine) createInstance(spec *instanceSpec) (string, error) {
+	req := &govultr.InstanceCreateReq{
+		Label:      spec.Label,
+		Region:     spec.Region,
+		Plan:       spec.Plan,
+		OsID:       spec.OsID,
+		AppID:      spec.AppID,
+		ImageID:    spec.ImageID,
+		SnapshotID: spec.SnapshotID,
+		Hostname:   spec.Hostname,
+		Tags:       spec.Tags,
+		SSHKeys:    spec.SSHKeys,
+		ScriptID:   spec.ScriptID,
+		EnableIPv6: spec.EnableIPv6,
+		UserData:   spec.UserData,
+	}
+
+	if spec.Backups != nil {
+		req.Backups = "disabled"
+		if *spec.Backups {
+			req.Backups = "enabled"
+		}
+	}
+
+	if spec.FirewallGroup != "" {
+		groupID, err := e.ref(kindFirewallGroup, spec.FirewallGroup)
+		if err != nil {
+			return "", err
+		}
+		req.FirewallGroupID = groupID
+	}
+
+	instance, _, err := e.base.Client.Instance.Create(e.base.Context, req)
+	if err != nil {
+		return "", err
+	}
+
+	e.ids[refKey(kindInstance, spec.Label)] = instance.ID
+	return instance.ID, nil
+}
+
+// sameSet reports whether the lists hold the same strings in any order
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// ======================================
+
+// planDomain plans the domain, when it is missing, and the records to
+// create, update and delete.  Records are matched the way dns record apply
+// matches them, so a record whose data changes is updated in place.  The SOA
+// record, and the NS records on the zone apex unless the manifest declares
+// its own, are left alone
+func (e *engine) planDomain(spec *domainSpec) error {
+	domain := strings.ToLower(strings.TrimSuffix(spec.Domain, "."))
+
+	var live []govultr.DomainRecord
+	if e.domains[refKey(kindDomain, domain)] {
+		var err error
+		live, err = utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.DomainRecord, *govultr.Meta, error) {
+			items, meta, _, err := e.base.Client.DomainRecord.List(e.base.Context, domain, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving the records of domain %q : %v", domain, err)
+		}
+	} else {
+		e.add(&change{Kind: kindDomain, Name: domain, Action: actionCreate, apply: func() (string, error) {
+			_, _, err := e.base.Client.Domain.Create(e.base.Context, &govultr.DomainReq{Domain: domain})
+			return "", err
+		}})
+	}
+
+	e.planRecords(domain, spec.Records, live)
+
+	return nil
+}
+
+// planRecords plans the creates, updates and deletes which converge the
+// live records of the domain to the desired records, as dns record apply does
+func (e *engine) planRecords(domain string, records []recordSpec, live []govultr.DomainRecord) {
+	desired := make([]govultr.DomainRecordReq, len(records))
+	for i := range records {
+		desired[i] = govultr.DomainRecordReq{
+			Name:     records[i].Name,
+			Type:     records[i].Type,
+			Data:     records[i].Data,
+			TTL:      records[i].TTL,
+			Priority: records[i].Priority,
+		}
+	}
+
+	for _, p := range dns.PlanRecords(desired, live) {
+		rec := recordSpec{
+			Name:     p.Record.Name,
+			Type:     p.Record.Type,
+			Data:     p.Record.Data,
+			TTL:      p.Record.TTL,
+			Priority: p.Record.Priority,
+		}
+		e.add(e.recordChange(domain, p.Action, p.ID, &rec))
+	}
+}
+
+// recordChange builds the change of a DNS record
+func (e *engine) recordChange(domain, action, id string, rec *recordSpec) *change {
+	name := rec.Name
+	if name == "" {
+		name = "@"
+	}
+
+	detail := fmt.Sprintf("%s %s %s", rec.Type, name, rec.Data)
+	if rec.TTL != 0 {
+		detail += fmt.Sprintf(" ttl %d", rec.TTL)
+	}
+	if rec.Priority != nil {
+		detail += fmt.Sprintf(" priority %d", *rec.Priority)
+	}
+
+	req := &govultr.DomainRecordReq{
+		Name:     rec.Name,
+		Type:     rec.Type,
+		Data:     rec.Data,
+		TTL:      rec.TTL,
+		Priority: rec.Priority,
+	}
+
+	c := &change{Kind: kindRecord, Name: domain, Action: action, ID: id, Detail: detail}
+	switch action {
+	case actionCreate:
+		c.apply = func() (string, error) {
+			record, _, err := e.base.Client.DomainRecord.Create(e.base.Context, domain, req)
+			if err != nil {
+				return "", err
+			}
+			return record.ID, nil
+		}
+	case actionUpdate:
+		c.apply = func() (string, error) {
+			return "", e.base.Client.DomainRecord.Update(e.base.Context, domain, id, req)
+		}
+	default:
+		c.apply = func() (string, error) {
+			return "", e.base.Client.DomainRecord.Delete(e.base.Context, domain, id)
+		}
+	}
+
+	return c
+}
+
+// ======================================
+
+// planLoadBalancer plans the load balancer when it is missing, or the
+// update of its forwarding rules, instances, nodes, balancing algorithm and
+// health check when they are given and differ
+func (e *engine) planLoadBalancer(spec *loadBalancerSpec) error {
+	id, found, err := e.lookup(kindLoadBalancer, spec.Label)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		e.ids[refKey(kindLoadBalancer, spec.Label)] = ""
+		e.add(&change{
+			Kind:   kindLoadBalancer,
+			Name:   spec.Label,
+			Action: actionCreate,
+			Detail: "in " + spec.Region,
+			apply: func() (string, error) {
+				req, err := e.loadBalancerReq(spec)
+				if err != nil {
+					return "", err
+				}
+				req.Region = spec.Region
+
+				lb, _, err := e.base.Client.LoadBalancer.Create(e.base.Context, req)
+				if err != nil {
+					return "", err
+				}
+				e.ids[refKey(kindLoadBalancer, spec.Label)] = lb.ID
+				return lb.ID, nil
+			},
+		})
+		return nil
+	}
+
+	live := e.loadBalancers[id]
+	diffs, err := e.loadBalancerDiffs(spec, &live)
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	e.add(&change{
+		Kind:   kindLoadBalancer,
+		Name:   spec.Label,
+		Action: actionUpdate,
+		ID:     id,
+		Detail: strings.Join(diffs, ", "),
+		apply: func() (string, error) {
+			req, err := e.loadBalancerReq(spec)
+			if err != nil {
+				return "", err
+			}
+			return "", e.base.Client.LoadBalancer.Update(e.base.Context, id, req)
+		},
+	})
+
+	return nil
+}
+
+// loadBalancerDiffs lists the managed fields of the load balancer which
+// differ from the spec
+func (e *engine) loadBalancerDiffs( //nolint:gocyclo
+	spec *loadBalancerSpec,
+	live *govultr.LoadBalancer,
+) ([]string, error) {
+	var diffs []string
+
+	if len(spec.ForwardingRules) > 0 {
+		var want, have []string
+		for _, r := range spec.ForwardingRules {
+			want = append(want, forwardingKey(r.FrontendProtocol, r.FrontendPort, r.BackendProtocol, r.BackendPort))
+		}
+		for _, r := range live.ForwardingRules {
+			have = append(have, forwardingKey(r.FrontendProtocol, r.FrontendPort, r.BackendProtocol, r.BackendPort))
+		}
+		if !sameSet(want, have) {
+			diffs = append(diffs, "forwarding rules")
+		}
+	}
+
+	if spec.Instances != nil {
+		var want []string
+		for _, name := range spec.Instances {
+			id, found, err := e.lookup(kindInstance, name)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				id = name
+			}
+			want = append(want, id)
+		}
+		if slices.Contains(want, "") || !sameSet(want, live.Instances) {
+			diffs = append(diffs, "instances")
+		}
+	}
+
+	if spec.Nodes != 0 && spec.Nodes != live.Nodes {
+		diffs = append(diffs, fmt.Sprintf("nodes %d -> %d", live.Nodes, spec.Nodes))
+	}
+
+	if spec.BalancingAlgorithm != "" && live.GenericInfo != nil &&
+		!strings.EqualFold(spec.BalancingAlgorithm, live.GenericInfo.BalancingAlgorithm) {
+		diffs = append(diffs, fmt.Sprintf("balancing algorithm %s -> %s",
+			live.GenericInfo.BalancingAlgorithm, spec.BalancingAlgorithm))
+	}
+
+	if spec.HealthCheck != nil && (live.HealthCheck == nil || *healthCheck(spec) != *live.HealthCheck) {
+		diffs = append(diffs, "health check")
+	}
+
+	return diffs, nil
+}
+
+// loadBalancerReq builds the create or update request of the load balancer,
+// resolving its instances
+func (e *engine) loadBalancerReq(spec *loadBalancerSpec) (*govultr.LoadBalancerReq, error) {
+	req := &govultr.LoadBalancerReq{
+		Label:              spec.Label,
+		Nodes:              spec.Nodes,
+		BalancingAlgorithm: spec.BalancingAlgorithm,
+	}
+
+	if spec.HealthCheck != nil {
+		req.HealthCheck = healthCheck(spec)
+	}
+
+	for _, r := range spec.ForwardingRules {
+		req.ForwardingRules = append(req.ForwardingRules, govultr.ForwardingRule{
+			FrontendProtocol: r.FrontendProtocol,
+			FrontendPort:     r.FrontendPort,
+			BackendProtocol:  r.BackendProtocol,
+			BackendPort:      r.BackendPort,
+		})
+	}
+
+	for _, name := range spec.Instances {
+		id, err := e.ref(kindInstance, name)
+		if err != nil {
+			return nil, err
+		}
+		req.Instances = append(req.Instances, id)
+	}
+
+	return req, nil
+}
+
+// healthCheck converts the health check of the spec to the API type
+func healthCheck(spec *loadBalancerSpec) *govultr.HealthCheck {
+	return &govultr.HealthCheck{
+		Protocol:           spec.HealthCheck.Protocol,
+		Port:               spec.HealthCheck.Port,
+		Path:               spec.HealthCheck.Path,
+		CheckInterval:      spec.HealthCheck.CheckInterval,
+		ResponseTimeout:    spec.HealthCheck.ResponseTimeout,
+		UnhealthyThreshold: spec.HealthCheck.UnhealthyThreshold,
+		HealthyThreshold:   spec.HealthCheck.HealthyThreshold,
+	}
+}
+
+// forwardingKey identifies a forwarding rule by its ports and protocols
+func forwardingKey(frontendProtocol string, frontendPort int, backendProtocol string, backendPort int) string {
+	return fmt.Sprintf("%s:%d->%s:%d",
+		strings.ToLower(frontendProtocol), frontendPort, strings.ToLower(backendProtocol), backendPort)
+}
@@ -0,0 +1,76 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// ChangesPrinter ...
+type ChangesPrinter struct {
+	Changes []*change `json:"changes"`
+}
+
+// JSON ...
+func (c *ChangesPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *ChangesPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *ChangesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"KIND",
+		"NAME",
+		"ACTION",
+		"ID",
+		"DETAIL",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (c *ChangesPrinter) Data() [][]string {
+	if len(c.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range c.Changes {
+		status := c.Changes[i].Status
+		if c.Changes[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, c.Changes[i].Error)
+		}
+
+		data = append(data, []string{
+			c.Changes[i].Kind,
+			c.Changes[i].Name,
+			c.Changes[i].Action,
+			c.Changes[i].ID,
+			c.Changes[i].Detail,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (c *ChangesPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of changes which could not be applied
+func (c *ChangesPrinter) failed() int {
+	n := 0
+	for i := range c.Changes {
+		if c.Changes[i].Status == statusFailed {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,157 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// manifestSpec is the declarative description of the resources converged by
+// apply.  Resources are identified by their label, domain name or
+// description, and may refer to the other resources of the manifest by the
+// same names
+type manifestSpec struct {
+	FirewallGroups []firewallGroupSpec `yaml:"firewall_groups"`
+	Instances      []instanceSpec      `yaml:"instances"`
+	Domains        []domainSpec        `yaml:"domains"`
+	LoadBalancers  []loadBalancerSpec  `yaml:"load_balancers"`
+}
+
+// firewallGroupSpec is a firewall group and the complete set of its rules
+type firewallGroupSpec struct {
+	Description string                   `yaml:"description"`
+	Rules       []utils.FirewallRuleSpec `yaml:"rules"`
+}
+
+// instanceSpec is an instance.  The tags and firewall group are only
+// managed when they are given
+type instanceSpec struct {
+	Label         string   `yaml:"label"`
+	Region        string   `yaml:"region"`
+	Plan          string   `yaml:"plan"`
+	OsID          int      `yaml:"os_id"`
+	AppID         int      `yaml:"app_id"`
+	ImageID       string   `yaml:"image_id"`
+	SnapshotID    string   `yaml:"snapshot_id"`
+	Hostname      string   `yaml:"hostname"`
+	Tags          []string `yaml:"tags"`
+	SSHKeys       []string `yaml:"ssh_keys"`
+	ScriptID      string   `yaml:"script_id"`
+	FirewallGroup string   `yaml:"firewall_group"`
+	EnableIPv6    *bool    `yaml:"enable_ipv6"`
+	Backups       *bool    `yaml:"backups"`
+	UserData      string   `yaml:"user_data"`
+}
+
+// domainSpec is a DNS domain and the complete set of its records
+type domainSpec struct {
+	Domain  string       `yaml:"domain"`
+	Records []recordSpec `yaml:"records"`
+}
+
+// recordSpec is a single DNS record of a domainSpec
+type recordSpec struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Data     string `yaml:"data"`
+	TTL      int    `yaml:"ttl"`
+	Priority *int   `yaml:"priority"`
+}
+
+// loadBalancerSpec is a load balancer.  The instances may be given by label
+// and the health check and balancing algorithm are only managed when they
+// are given
+type loadBalancerSpec struct {
+	Label              string   `yaml:"label"`
+	Region             string   `yaml:"region"`
+	Nodes              int      `yaml:"nodes"`
+	BalancingAlgorithm string   `yaml:"balancing_algorithm"`
+	Instances          []string `yaml:"instances"`
+	ForwardingRules    []struct {
+		FrontendProtocol string `yaml:"frontend_protocol"`
+		FrontendPort     int    `yaml:"frontend_port"`
+		BackendProtocol  string `yaml:"backend_protocol"`
+		BackendPort      int    `yaml:"backend_port"`
+	} `yaml:"forwarding_rules"`
+	HealthCheck *struct {
+		Protocol           string `yaml:"protocol"`
+		Port               int    `yaml:"port"`
+		Path               string `yaml:"path"`
+		CheckInterval      int    `yaml:"check_interval"`
+		ResponseTimeout    int    `yaml:"response_timeout"`
+		UnhealthyThreshold int    `yaml:"unhealthy_threshold"`
+		HealthyThreshold   int    `yaml:"healthy_threshold"`
+	} `yaml:"health_check"`
+}
+
+// validate checks the manifest before anything is planned, so that a
+// mistake in the file never leaves the resources half converged
+func (m *manifestSpec) validate() error { //nolint:gocyclo
+	seen := map[string]bool{}
+	unique := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("every %s needs a name", kind)
+		}
+		key := kind + "/" + strings.ToLower(name)
+		if seen[key] {
+			return fmt.Errorf("%s %q is declared more than once", kind, name)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for i := range m.FirewallGroups {
+		if err := unique(kindFirewallGroup, m.FirewallGroups[i].Description); err != nil {
+			return err
+		}
+		for j := range m.FirewallGroups[i].Rules {
+			if _, err := m.FirewallGroups[i].Rules[j].ToReq(); err != nil {
+				return fmt.Errorf("firewall group %q rule %d : %v", m.FirewallGroups[i].Description, j+1, err)
+			}
+		}
+	}
+
+	for i := range m.Instances {
+		inst := &m.Instances[i]
+		if err := unique(kindInstance, inst.Label); err != nil {
+			return err
+		}
+		if inst.Region == "" || inst.Plan == "" {
+			return fmt.Errorf("instance %q needs a region and plan", inst.Label)
+		}
+		if inst.OsID == 0 && inst.AppID == 0 && inst.ImageID == "" && inst.SnapshotID == "" {
+			return fmt.Errorf("instance %q needs an os_id, app_id, image_id or snapshot_id", inst.Label)
+		}
+	}
+
+	for i := range m.Domains {
+		if err := unique(kindDomain, m.Domains[i].Domain); err != nil {
+			return err
+		}
+		for j := range m.Domains[i].Records {
+			rec := &m.Domains[i].Records[j]
+			if rec.Type == "" || rec.Data == "" {
+				return fmt.Errorf("domain %q record %d needs a type and data", m.Domains[i].Domain, j+1)
+			}
+		}
+	}
+
+	for i := range m.LoadBalancers {
+		lb := &m.LoadBalancers[i]
+		if err := unique(kindLoadBalancer, lb.Label); err != nil {
+			return err
+		}
+		if lb.Region == "" {
+			return fmt.Errorf("load balancer %q needs a region", lb.Label)
+		}
+		for j, r := range lb.ForwardingRules {
+			if r.FrontendProtocol == "" || r.FrontendPort == 0 || r.BackendProtocol == "" || r.BackendPort == 0 {
+				return fmt.Errorf("load balancer %q forwarding rule %d needs frontend and backend ports and protocols",
+					lb.Label, j+1)
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ package marketplace
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -27,6 +28,11 @@ var (
 	listAppVariablesExample = `
 	# Full example
 	vultr-cli marketplace app list-variables drupal
+
+	# Shortened example with aliases
+	vultr-cli marketplace app variables drupal
+
+	Pass the variables to instance create or bare-metal create with --marketplace-variable="name=value"
 	`
 )
 
@@ -55,9 +61,9 @@ func NewCmdMarketplace(base *cli.Base) *cobra.Command {
 
 	// List Variables
 	listVariables := &cobra.Command{
-		Use:     "list-variables",
+		Use:     "list-variables <Image ID>",
 		Short:   "List all user-supplied variables for a marketplace app",
-		Aliases: []string{"l"},
+		Aliases: []string{"l", "variables"},
 		Long:    listAppVariablesLong,
 		Example: listAppVariablesExample,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -97,3 +103,37 @@ func (o *options) listVariables() ([]govultr.MarketplaceAppVariable, error) {
 	vars, _, err := o.Base.Client.Marketplace.ListAppVariables(o.Base.Context, o.Base.Args[0])
 	return vars, err
 }
+
+// CheckVariables makes sure every required variable of the marketplace app
+// image is given and that no unknown variables are passed
+func CheckVariables(base *cli.Base, image string, given map[string]string) error {
+	vars, _, err := base.Client.Marketplace.ListAppVariables(base.Context, image)
+	if err != nil {
+		return fmt.Errorf("error getting marketplace app variables : %v", err)
+	}
+
+	known := make(map[string]bool, len(vars))
+	var missing []string
+	for i := range vars {
+		known[vars[i].Name] = true
+		if _, ok := given[vars[i].Name]; !ok && vars[i].Required != nil && *vars[i].Required {
+			missing = append(missing, vars[i].Name)
+		}
+	}
+
+	for name := range given {
+		if !known[name] {
+			return fmt.Errorf("marketplace app %s has no variable %q", image, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"marketplace app %s requires the variables %s, pass them with --marketplace-variable",
+			image,
+			strings.Join(missing, ", "),
+		)
+	}
+
+	return nil
+}
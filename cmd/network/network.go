@@ -0,0 +1,531 @@
+// Package network provides functionality for the CLI to audit networking
+// settings across resources
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+var (
+	long    = `Get commands available to network`
+	example = `
+	# Full example
+	vultr-cli network
+	`
+	rdnsLong    = `Get commands to manage reverse DNS across all instances and reserved IPs`
+	rdnsExample = `
+	# Full example
+	vultr-cli network rdns
+	`
+	rdnsAuditLong = `Checks the reverse DNS of every instance IP and reserved IP in the account and lists those whose
+PTR record is missing or does not resolve back to the same IP.
+
+With --fix the reverse DNS of the listed IPs is set from the --domain template.  The template can use the
+placeholders {label}, {hostname}, {id} and {ip}, where {ip} is the address with dots and colons replaced by
+dashes.  Reserved IPs which are not attached to an instance are listed but cannot be fixed.`
+	rdnsAuditExample = `
+	# Full example
+	vultr-cli network rdns audit
+
+	# Set the reverse DNS of the failing IPs
+	vultr-cli network rdns audit --fix --domain="{hostname}.example.com"
+	`
+	ipsLong = `Lists every public IPv4 and IPv6 address of the account along with the resource which owns it, to audit
+the external surface of the account.  The addresses of instances, bare metal servers and load balancers are
+listed, and reserved IPs are shown on the address they are attached to or on their own when unattached.`
+	ipsExample = `
+	# Full example
+	vultr-cli network ips
+
+	# Export the list for an audit
+	vultr-cli network ips --output csv > ips.csv
+	`
+)
+
+// NewCmdNetwork provides the CLI command for network functions
+func NewCmdNetwork(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "network",
+		Aliases: []string{"net"},
+		Short:   "Commands to audit networking across resources",
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+	}
+
+	// Reverse DNS
+	rdns := &cobra.Command{
+		Use:     "rdns",
+		Short:   "Commands to manage reverse DNS",
+		Long:    rdnsLong,
+		Example: rdnsExample,
+	}
+
+	// Reverse DNS Audit
+	audit := &cobra.Command{
+		Use:     "audit",
+		Short:   "List IPs whose reverse DNS does not resolve back",
+		Long:    rdnsAuditLong,
+		Example: rdnsAuditExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fix, errFi := cmd.Flags().GetBool("fix")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'fix' for rdns audit : %v", errFi)
+			}
+
+			domain, errDo := cmd.Flags().GetString("domain")
+			if errDo != nil {
+				return fmt.Errorf("error parsing flag 'domain' for rdns audit : %v", errDo)
+			}
+
+			entries, err := o.audit()
+			if err != nil {
+				return fmt.Errorf("error auditing reverse dns : %v", err)
+			}
+
+			if fix {
+				o.fix(entries, domain)
+			}
+
+			data := &RDNSAuditPrinter{Entries: entries}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d reverse dns updates failed", failed, len(entries))
+			}
+
+			return nil
+		},
+	}
+
+	audit.Flags().Bool("fix", false, "(optional) set the reverse DNS of the listed IPs from the domain template")
+	audit.Flags().String("domain", "", "(optional) template for the reverse DNS, such as {hostname}.example.com")
+	audit.MarkFlagsRequiredTogether("fix", "domain")
+
+	rdns.AddCommand(
+		audit,
+	)
+
+	// IPs
+	ips := &cobra.Command{
+		Use:     "ips",
+		Short:   "List the public IPs of all resources",
+		Long:    ipsLong,
+		Example: ipsExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := o.ips()
+			if err != nil {
+				return fmt.Errorf("error listing ips : %v", err)
+			}
+
+			data := &IPsPrinter{IPs: entries}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		rdns,
+		ips,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// rdnsEntry is an IP whose reverse DNS failed the audit
+type rdnsEntry struct {
+	IP         string `json:"ip"`
+	Source     string `json:"source"`
+	ReservedIP string `json:"reserved_ip_id,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	Label      string `json:"label"`
+	Hostname   string `json:"hostname,omitempty"`
+	Reverse    string `json:"reverse"`
+	Status     string `json:"status"`
+	Fixed      string `json:"fixed,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// audit returns the instance and reserved IPs whose reverse DNS is missing or
+// does not resolve back to the IP
+func (o *options) audit() ([]rdnsEntry, error) {
+	reserved, err := o.listReservedIPs()
+	if err != nil {
+		return nil, fmt.Errorf("error listing reserved ips : %v", err)
+	}
+
+	instances, err := o.listInstances()
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances : %v", err)
+	}
+
+	var entries []rdnsEntry
+	for i := range instances {
+		ips, err := o.instanceReverse(&instances[i])
+		if err != nil {
+			return nil, fmt.Errorf("error listing reverse dns for instance %s : %v", instances[i].ID, err)
+		}
+
+		for j := range ips {
+			entry := rdnsEntry{
+				IP:         ips[j].IP,
+				Source:     "instance",
+				InstanceID: instances[i].ID,
+				Label:      instances[i].Label,
+				Hostname:   instances[i].Hostname,
+				Reverse:    ips[j].Reverse,
+			}
+
+			if r := reservedFor(reserved, ips[j].IP); r != nil {
+				entry.Source = "reserved-ip"
+				entry.ReservedIP = r.ID
+			}
+
+			if entry.Status = o.check(entry.IP, entry.Reverse); entry.Status != "ok" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	for i := range reserved {
+		if reserved[i].InstanceID == "" {
+			entries = append(entries, rdnsEntry{
+				IP:         reserved[i].Subnet,
+				Source:     "reserved-ip",
+				ReservedIP: reserved[i].ID,
+				Label:      reserved[i].Label,
+				Status:     "unattached",
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// check looks up the reverse DNS name and reports whether it resolves back
+// to the IP
+func (o *options) check(ip, reverse string) string {
+	if reverse == "" {
+		return "missing"
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(o.Base.Context, reverse)
+	if err != nil {
+		return "unresolved"
+	}
+
+	want, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "mismatch"
+	}
+
+	for i := range addrs {
+		if got, err := netip.ParseAddr(addrs[i]); err == nil && got == want {
+			return "ok"
+		}
+	}
+
+	return "mismatch"
+}
+
+// fix sets the reverse DNS of the entries which belong to an instance from
+// the domain template
+func (o *options) fix(entries []rdnsEntry, domain string) {
+	for i := range entries {
+		if entries[i].InstanceID == "" {
+			continue
+		}
+
+		name := expandDomain(domain, &entries[i])
+		req := &govultr.ReverseIP{IP: entries[i].IP, Reverse: name}
+
+		var err error
+		if strings.Contains(entries[i].IP, ":") {
+			err = o.Base.Client.Instance.CreateReverseIPv6(o.Base.Context, entries[i].InstanceID, req)
+		} else {
+			err = o.Base.Client.Instance.CreateReverseIPv4(o.Base.Context, entries[i].InstanceID, req)
+		}
+
+		if err != nil {
+			entries[i].Error = err.Error()
+			continue
+		}
+		entries[i].Fixed = name
+	}
+}
+
+// ipEntry is a public IP of the account and the resource which owns it
+type ipEntry struct {
+	IP         string `json:"ip"`
+	Version    int    `json:"version"`
+	Resource   string `json:"resource"`
+	ResourceID string `json:"resource_id"`
+	Label      string `json:"label"`
+	Region     string `json:"region"`
+	ReservedIP string `json:"reserved_ip_id,omitempty"`
+}
+
+// ips returns the public IPs of the instances, bare metal servers, load
+// balancers and reserved IPs of the account
+func (o *options) ips() ([]ipEntry, error) { //nolint:gocyclo
+	reserved, err := o.listReservedIPs()
+	if err != nil {
+		return nil, fmt.Errorf("error listing reserved ips : %v", err)
+	}
+
+	instances, err := o.listInstances()
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances : %v", err)
+	}
+
+	servers, err := o.listBareMetal()
+	if err != nil {
+		return nil, fmt.Errorf("error listing bare metal servers : %v", err)
+	}
+
+	lbs, err := o.listLoadBalancers()
+	if err != nil {
+		return nil, fmt.Errorf("error listing load balancers : %v", err)
+	}
+
+	var entries []ipEntry
+	matched := map[string]bool{}
+	add := func(ip string, entry ipEntry) {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+			return
+		}
+
+		entry.IP = addr.String()
+		entry.Version = 4
+		if addr.Is6() {
+			entry.Version = 6
+		}
+
+		if r := reservedFor(reserved, entry.IP); r != nil {
+			entry.ReservedIP = r.ID
+			matched[r.ID] = true
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for i := range instances {
+		v4, err := o.listInstanceIPv4(instances[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing ips for instance %s : %v", instances[i].ID, err)
+		}
+
+		entry := ipEntry{
+			Resource:   "instance",
+			ResourceID: instances[i].ID,
+			Label:      instances[i].Label,
+			Region:     instances[i].Region,
+		}
+		for j := range v4 {
+			add(v4[j].IP, entry)
+		}
+		add(instances[i].V6MainIP, entry)
+	}
+
+	for i := range servers {
+		v4, err := o.listBareMetalIPv4(servers[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing ips for bare metal server %s : %v", servers[i].ID, err)
+		}
+
+		entry := ipEntry{
+			Resource:   "bare-metal",
+			ResourceID: servers[i].ID,
+			Label:      servers[i].Label,
+			Region:     servers[i].Region,
+		}
+		for j := range v4 {
+			add(v4[j].IP, entry)
+		}
+		add(servers[i].V6MainIP, entry)
+	}
+
+	for i := range lbs {
+		entry := ipEntry{
+			Resource:   "load-balancer",
+			ResourceID: lbs[i].ID,
+			Label:      lbs[i].Label,
+			Region:     lbs[i].Region,
+		}
+		add(lbs[i].IPV4, entry)
+		add(lbs[i].IPV6, entry)
+	}
+
+	return append(entries, unmatchedReserved(reserved, matched)...), nil
+}
+
+// unmatchedReserved returns the entries of the reserved IPs which are
+// unattached, or whose address didn't show up on the resource they are
+// attached to
+func unmatchedReserved(reserved []govultr.ReservedIP, matched map[string]bool) []ipEntry {
+	var entries []ipEntry
+	for i := range reserved {
+		if matched[reserved[i].ID] {
+			continue
+		}
+
+		entry := ipEntry{
+			IP:         reserved[i].Subnet,
+			Version:    4,
+			Resource:   "reserved-ip",
+			ResourceID: reserved[i].ID,
+			Label:      reserved[i].Label,
+			Region:     reserved[i].Region,
+			ReservedIP: reserved[i].ID,
+		}
+
+		if strings.Contains(reserved[i].Subnet, ":") {
+			entry.IP = fmt.Sprintf("%s/%d", reserved[i].Subnet, reserved[i].SubnetSize)
+			entry.Version = 6
+		}
+
+		if reserved[i].InstanceID != "" {
+			entry.Resource = "instance"
+			entry.ResourceID = reserved[i].InstanceID
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// instanceReverse returns the IPv4 and IPv6 addresses of the instance along
+// with their reverse DNS
+func (o *options) instanceReverse(instance *govultr.Instance) ([]govultr.ReverseIP, error) {
+	v4, err := o.listInstanceIPv4(instance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []govultr.ReverseIP
+	for i := range v4 {
+		ips = append(ips, govultr.ReverseIP{IP: v4[i].IP, Reverse: v4[i].Reverse})
+	}
+
+	if instance.V6MainIP == "" {
+		return ips, nil
+	}
+
+	v6, _, err := o.Base.Client.Instance.ListReverseIPv6(o.Base.Context, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the main IPv6 address only shows up in the list once a reverse entry
+	// has been set for it
+	found := false
+	for i := range v6 {
+		found = found || v6[i].IP == instance.V6MainIP
+	}
+	if !found {
+		ips = append(ips, govultr.ReverseIP{IP: instance.V6MainIP})
+	}
+
+	return append(ips, v6...), nil
+}
+
+func (o *options) listInstances() ([]govultr.Instance, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+}
+
+func (o *options) listInstanceIPv4(id string) ([]govultr.IPv4, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.IPv4, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.Instance.ListIPv4(o.Base.Context, id, opts)
+		return items, meta, err
+	})
+}
+
+func (o *options) listBareMetal() ([]govultr.BareMetalServer, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.BareMetalServer.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+}
+
+func (o *options) listBareMetalIPv4(id string) ([]govultr.IPv4, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.IPv4, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.BareMetalServer.ListIPv4s(o.Base.Context, id, opts)
+		return items, meta, err
+	})
+}
+
+func (o *options) listLoadBalancers() ([]govultr.LoadBalancer, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.LoadBalancer.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+}
+
+func (o *options) listReservedIPs() ([]govultr.ReservedIP, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.ReservedIP, *govultr.Meta, error) {
+		items, meta, _, err := o.Base.Client.ReservedIP.List(o.Base.Context, opts)
+		return items, meta, err
+	})
+}
+
+// reservedFor returns the reserved IP which covers the address or nil
+func reservedFor(reserved []govultr.ReservedIP, ip string) *govultr.ReservedIP {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+
+	for i := range reserved {
+		prefix, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", reserved[i].Subnet, reserved[i].SubnetSize))
+		if err == nil && prefix.Contains(addr) {
+			return &reserved[i]
+		}
+	}
+
+	return nil
+}
+
+var invalidHostChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// expandDomain fills in the placeholders of the domain template for the
+// entry
+func expandDomain(domain string, entry *rdnsEntry) string {
+	host := func(s string) string {
+		return strings.Trim(invalidHostChars.ReplaceAllString(strings.ToLower(s), "-"), "-.")
+	}
+
+	return strings.NewReplacer(
+		"{label}", host(entry.Label),
+		"{hostname}", host(entry.Hostname),
+		"{id}", entry.InstanceID,
+		"{ip}", strings.NewReplacer(".", "-", ":", "-").Replace(entry.IP),
+	).Replace(domain)
+}
@@ -0,0 +1,139 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// RDNSAuditPrinter ...
+type RDNSAuditPrinter struct {
+	Entries []rdnsEntry `json:"ips"`
+}
+
+// JSON ...
+func (r *RDNSAuditPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *RDNSAuditPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *RDNSAuditPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"IP",
+		"SOURCE",
+		"LABEL",
+		"REVERSE",
+		"STATUS",
+		"FIXED",
+	}}
+}
+
+// Data ...
+func (r *RDNSAuditPrinter) Data() [][]string {
+	if len(r.Entries) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Entries {
+		source := r.Entries[i].InstanceID
+		if r.Entries[i].ReservedIP != "" {
+			source = r.Entries[i].ReservedIP
+		}
+
+		fixed := r.Entries[i].Fixed
+		if r.Entries[i].Error != "" {
+			fixed = fmt.Sprintf("failed: %s", r.Entries[i].Error)
+		}
+
+		data = append(data, []string{
+			r.Entries[i].IP,
+			fmt.Sprintf("%s %s", r.Entries[i].Source, source),
+			r.Entries[i].Label,
+			r.Entries[i].Reverse,
+			r.Entries[i].Status,
+			fixed,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *RDNSAuditPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of reverse DNS updates which failed
+func (r *RDNSAuditPrinter) failed() int {
+	n := 0
+	for i := range r.Entries {
+		if r.Entries[i].Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// ======================================
+
+// IPsPrinter ...
+type IPsPrinter struct {
+	IPs []ipEntry `json:"ips"`
+}
+
+// JSON ...
+func (i *IPsPrinter) JSON() []byte {
+	return printer.MarshalObject(i, "json")
+}
+
+// YAML ...
+func (i *IPsPrinter) YAML() []byte {
+	return printer.MarshalObject(i, "yaml")
+}
+
+// Columns ...
+func (i *IPsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"IP",
+		"VERSION",
+		"RESOURCE",
+		"RESOURCE ID",
+		"LABEL",
+		"REGION",
+		"RESERVED IP",
+	}}
+}
+
+// Data ...
+func (i *IPsPrinter) Data() [][]string {
+	if len(i.IPs) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for j := range i.IPs {
+		data = append(data, []string{
+			i.IPs[j].IP,
+			strconv.Itoa(i.IPs[j].Version),
+			i.IPs[j].Resource,
+			i.IPs[j].ResourceID,
+			i.IPs[j].Label,
+			i.IPs[j].Region,
+			i.IPs[j].ReservedIP,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (i *IPsPrinter) Paging() [][]string {
+	return nil
+}
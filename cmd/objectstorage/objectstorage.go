@@ -4,7 +4,10 @@ package objectstorage
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -38,12 +41,12 @@ func NewCmdObjectStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			oss, meta, err := o.list()
+			oss, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving object storage list : %v", err)
 			}
 
-			data := &ObjectStoragesPrinter{ObjectStorages: oss, Meta: meta}
+			data := &ObjectStoragesPrinter{ObjectStorages: oss, Meta: meta, Tiers: o.tierNames()}
 			o.Base.Printer.Display(data, nil)
 
 			return nil
@@ -60,6 +63,7 @@ func NewCmdObjectStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -219,7 +223,7 @@ func NewCmdObjectStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			clusters, meta, err := o.listClusters()
+			clusters, meta, err := utils.ListAll(cmd, o.Base.Options, o.listClusters)
 			if err != nil {
 				return fmt.Errorf("error retrieving object storage cluster list : %v", err)
 			}
@@ -231,6 +235,8 @@ func NewCmdObjectStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	utils.AddListAllFlag(clusterList)
+
 	// List Cluster Tiers
 	clusterTierList := &cobra.Command{
 		Use:   "tiers",
@@ -318,9 +324,56 @@ type options struct {
 	ClusterID        int
 }
 
-func (o *options) list() ([]govultr.ObjectStorage, *govultr.Meta, error) {
-	oss, meta, _, err := o.Base.Client.ObjectStorage.List(o.Base.Context, o.Base.Options)
-	return oss, meta, err
+// objectStorage is an object storage with the tier it was deployed on, which
+// govultr does not decode
+type objectStorage struct {
+	govultr.ObjectStorage `yaml:",inline"`
+	TierID                int `json:"tier_id"`
+}
+
+// list lists the object storages.  The request is built here so the tier of
+// each is decoded as well.
+func (o *options) list() ([]objectStorage, *govultr.Meta, error) {
+	req, err := o.Base.Client.NewRequest(o.Base.Context, http.MethodGet, "/v2/object-storage", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := url.Values{}
+	if o.Base.Options.PerPage != 0 {
+		query.Set("per_page", strconv.Itoa(o.Base.Options.PerPage))
+	}
+	if o.Base.Options.Cursor != "" {
+		query.Set("cursor", o.Base.Options.Cursor)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	oss := struct {
+		ObjectStorages []objectStorage `json:"object_storages"`
+		Meta           *govultr.Meta   `json:"meta"`
+	}{}
+	if _, err := o.Base.Client.DoWithContext(o.Base.Context, req, &oss); err != nil {
+		return nil, nil, err
+	}
+
+	return oss.ObjectStorages, oss.Meta, nil
+}
+
+// tierNames returns the names of the tiers by ID.  The tier list is cached
+// like the other catalogs, and when it can't be read the tier IDs are
+// printed instead.
+func (o *options) tierNames() map[int]string {
+	tiers, err := o.listTiers()
+	if err != nil {
+		return nil
+	}
+
+	names := make(map[int]string, len(tiers))
+	for i := range tiers {
+		names[tiers[i].ID] = tiers[i].Name
+	}
+
+	return names
 }
 
 func (o *options) get() (*govultr.ObjectStorage, error) {
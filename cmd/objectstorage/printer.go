@@ -10,8 +10,9 @@ import (
 
 // ObjectStoragesPrinter ...
 type ObjectStoragesPrinter struct {
-	ObjectStorages []govultr.ObjectStorage `json:"object_storages"`
-	Meta           *govultr.Meta           `json:"meta"`
+	ObjectStorages []objectStorage `json:"object_storages"`
+	Meta           *govultr.Meta   `json:"meta"`
+	Tiers          map[int]string  `json:"-" yaml:"-"`
 }
 
 // JSON ...
@@ -30,6 +31,7 @@ func (o *ObjectStoragesPrinter) Columns() [][]string {
 		"ID",
 		"REGION",
 		"CLUSTER ID",
+		"TIER",
 		"STATUS",
 		"LABEL",
 		"DATE CREATED",
@@ -42,7 +44,7 @@ func (o *ObjectStoragesPrinter) Columns() [][]string {
 // Data ...
 func (o *ObjectStoragesPrinter) Data() [][]string {
 	if len(o.ObjectStorages) == 0 {
-		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---", "---"}}
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---", "---", "---"}}
 	}
 
 	var data [][]string
@@ -51,6 +53,7 @@ func (o *ObjectStoragesPrinter) Data() [][]string {
 			o.ObjectStorages[i].ID,
 			o.ObjectStorages[i].Region,
 			strconv.Itoa(o.ObjectStorages[i].ObjectStoreClusterID),
+			o.tierName(o.ObjectStorages[i].TierID),
 			o.ObjectStorages[i].Status,
 			o.ObjectStorages[i].Label,
 			o.ObjectStorages[i].DateCreated,
@@ -68,6 +71,19 @@ func (o *ObjectStoragesPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(o.Meta).Compose()
 }
 
+// tierName returns the name of the tier, or its ID when the name is unknown
+func (o *ObjectStoragesPrinter) tierName(id int) string {
+	if id == 0 {
+		return "---"
+	}
+
+	if name, ok := o.Tiers[id]; ok {
+		return name
+	}
+
+	return strconv.Itoa(id)
+}
+
 // ======================================
 
 // ObjectStoragePrinter ...
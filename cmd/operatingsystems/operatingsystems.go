@@ -3,8 +3,9 @@
 package operatingsystems
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -30,6 +31,17 @@ var (
 	# Shortened with alias commands
 	vultr-cli o l
 	`
+
+	findLong = `Search the operating systems by name, printing the IDs to use with --os on
+instance create. Every search term has to match, either as part of the name or as
+letters appearing in the same order. The best matches are listed first.`
+	findExample = `
+	# Full example
+	vultr-cli os find ubuntu 22
+
+	# Shortened with alias commands
+	vultr-cli o f debian
+	`
 )
 
 // NewCmdOS provides the command for operating systems to the CLI
@@ -56,7 +68,9 @@ func NewCmdOS(base *cli.Base) *cobra.Command {
 		Long:    listLong,
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			os, meta, err := o.list()
+			o.Base.Options = utils.GetPaging(cmd)
+
+			os, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error getting operating systems : %v", err)
 			}
@@ -78,8 +92,35 @@ func NewCmdOS(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
+
+	// Find
+	find := &cobra.Command{
+		Use:     "find <search terms>",
+		Short:   "Search operating systems by name",
+		Aliases: []string{"f", "search"},
+		Long:    findLong,
+		Example: findExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a search term")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			os, err := o.find(args)
+			if err != nil {
+				return fmt.Errorf("error getting operating systems : %v", err)
+			}
+
+			data := &OSPrinter{OperatingSystems: os}
+			o.Base.Printer.Display(data, nil)
 
-	cmd.AddCommand(list)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(list, find)
 	return cmd
 }
 
@@ -87,7 +128,36 @@ type options struct {
 	Base *cli.Base
 }
 
+// find returns the operating systems matching every term, best match first
+func (o *options) find(terms []string) ([]govultr.OS, error) {
+	all, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.OS, *govultr.Meta, error) {
+		all, meta, _, err := o.Base.Client.OS.List(o.Base.Context, opts)
+		return all, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := map[int]int{}
+	var found []govultr.OS
+	for i := range all {
+		if score, ok := utils.FuzzyScore(terms, all[i].Name); ok {
+			scores[all[i].ID] = score
+			found = append(found, all[i])
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if scores[found[i].ID] != scores[found[j].ID] {
+			return scores[found[i].ID] > scores[found[j].ID]
+		}
+		return found[i].Name < found[j].Name
+	})
+
+	return found, nil
+}
+
 func (o *options) list() ([]govultr.OS, *govultr.Meta, error) {
-	list, meta, _, err := o.Base.Client.OS.List(context.Background(), o.Base.Options)
+	list, meta, _, err := o.Base.Client.OS.List(o.Base.Context, o.Base.Options)
 	return list, meta, err
 }
@@ -0,0 +1,183 @@
+package plans
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	sortPrice = "price"
+	sortVCPU  = "vcpu"
+	sortRAM   = "ram"
+	sortDisk  = "disk"
+)
+
+var sortFields = []string{sortPrice, sortVCPU, sortRAM, sortDisk}
+
+// plan holds the fields shared by instance and bare metal plans so both can
+// be searched and compared together.  The vCPU count of a bare metal plan is
+// its number of CPU threads.
+type plan struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	VCPUCount   int      `json:"vcpu_count"`
+	CPUModel    string   `json:"cpu_model,omitempty"`
+	RAM         int      `json:"ram"`
+	Disk        int      `json:"disk"`
+	DiskCount   int      `json:"disk_count"`
+	Bandwidth   int      `json:"bandwidth"`
+	MonthlyCost float32  `json:"monthly_cost"`
+	GPUVRAM     int      `json:"gpu_vram_gb,omitempty"`
+	GPUType     string   `json:"gpu_type,omitempty"`
+	Locations   []string `json:"locations"`
+}
+
+// planFilter holds the plans find criteria, zero values are not checked
+type planFilter struct {
+	MinVCPU  int
+	MinRAM   int
+	MinDisk  int
+	MaxPrice float32
+	Region   string
+	Type     string
+	Sort     string
+}
+
+// matches reports whether the plan meets every criteria of the filter.  The
+// type matches either the plan type or the prefix of the plan ID so that
+// 'vbm' selects bare metal plans.
+func (f *planFilter) matches(p *plan) bool {
+	if p.VCPUCount < f.MinVCPU || p.RAM < f.MinRAM || p.Disk < f.MinDisk {
+		return false
+	}
+
+	if f.MaxPrice > 0 && p.MonthlyCost > f.MaxPrice {
+		return false
+	}
+
+	if f.Region != "" && !slices.Contains(p.Locations, f.Region) {
+		return false
+	}
+
+	if f.Type != "" && !strings.EqualFold(p.Type, f.Type) && !strings.HasPrefix(p.ID, f.Type+"-") {
+		return false
+	}
+
+	return true
+}
+
+// find returns the instance and bare metal plans matching the filter,
+// ordered by the sort field and then by price
+func (o *options) find(f *planFilter) ([]plan, error) {
+	all, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []plan
+	for i := range all {
+		if f.matches(&all[i]) {
+			plans = append(plans, all[i])
+		}
+	}
+
+	key := func(p *plan) float32 {
+		switch f.Sort {
+		case sortVCPU:
+			return float32(p.VCPUCount)
+		case sortRAM:
+			return float32(p.RAM)
+		case sortDisk:
+			return float32(p.Disk)
+		default:
+			return p.MonthlyCost
+		}
+	}
+
+	sort.SliceStable(plans, func(i, j int) bool {
+		if ki, kj := key(&plans[i]), key(&plans[j]); ki != kj {
+			return ki < kj
+		}
+		return plans[i].MonthlyCost < plans[j].MonthlyCost
+	})
+
+	return plans, nil
+}
+
+// compare returns the plans with the given IDs in the same order
+func (o *options) compare(ids []string) ([]plan, error) {
+	all, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []plan
+	for i := range ids {
+		idx := slices.IndexFunc(all, func(p plan) bool { return p.ID == ids[i] })
+		if idx < 0 {
+			return nil, fmt.Errorf("plan %s not found", ids[i])
+		}
+		plans = append(plans, all[idx])
+	}
+
+	return plans, nil
+}
+
+// listAll retrieves every instance and bare metal plan
+func (o *options) listAll() ([]plan, error) {
+	var plans []plan
+
+	vpsPlans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+		vpsPlans, meta, _, err := o.Base.Client.Plan.List(o.Base.Context, "", opts)
+		return vpsPlans, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting plans : %v", err)
+	}
+
+	for i := range vpsPlans {
+		plans = append(plans, plan{
+			ID:          vpsPlans[i].ID,
+			Type:        vpsPlans[i].Type,
+			VCPUCount:   vpsPlans[i].VCPUCount,
+			RAM:         vpsPlans[i].RAM,
+			Disk:        vpsPlans[i].Disk,
+			DiskCount:   vpsPlans[i].DiskCount,
+			Bandwidth:   vpsPlans[i].Bandwidth,
+			MonthlyCost: vpsPlans[i].MonthlyCost,
+			GPUVRAM:     vpsPlans[i].GPUVRAM,
+			GPUType:     vpsPlans[i].GPUType,
+			Locations:   vpsPlans[i].Locations,
+		})
+	}
+
+	metalPlans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, error) {
+		metalPlans, meta, _, err := o.Base.Client.Plan.ListBareMetal(o.Base.Context, opts)
+		return metalPlans, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting bare metal plans : %v", err)
+	}
+
+	for i := range metalPlans {
+		plans = append(plans, plan{
+			ID:          metalPlans[i].ID,
+			Type:        metalPlans[i].Type,
+			VCPUCount:   metalPlans[i].CPUThreads,
+			CPUModel:    metalPlans[i].CPUModel,
+			RAM:         metalPlans[i].RAM,
+			Disk:        metalPlans[i].Disk,
+			DiskCount:   metalPlans[i].DiskCount,
+			Bandwidth:   metalPlans[i].Bandwidth,
+			MonthlyCost: metalPlans[i].MonthlyCost,
+			Locations:   metalPlans[i].Locations,
+		})
+	}
+
+	return plans, nil
+}
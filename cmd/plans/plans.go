@@ -2,8 +2,10 @@
 package plans
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -41,6 +43,28 @@ var (
 	# Shortened with alias commands
 	vultr-cli p m
 	`
+
+	findLong = `Search instance and bare metal plans by their resources, price, type and region.
+Matching plans are sorted by price unless --sort is given.`
+	findExample = `
+	# Full example
+	vultr-cli plans find --min-vcpu=4 --min-ram=8192 --max-price=60 --region="fra" --type="vhf"
+
+	# Bare metal plans are selected with the vbm type
+	vultr-cli plans find --type="vbm" --sort="vcpu"
+
+	# Shortened with alias commands
+	vultr-cli p f --min-vcpu=2 -r="ewr"
+	`
+
+	compareLong    = `Show two or more instance or bare metal plans side by side`
+	compareExample = `
+	# Full example
+	vultr-cli plans compare vc2-2c-4gb vhf-2c-4gb
+
+	# Shortened with alias commands
+	vultr-cli p cmp vc2-2c-4gb vhf-2c-4gb vdc-4c-16gb
+	`
 )
 
 // NewCmdPlan returns the cobra command for Plans
@@ -75,7 +99,7 @@ func NewCmdPlan(base *cli.Base) *cobra.Command {
 
 			o.PlanType = planType
 
-			plans, meta, err := o.list()
+			plans, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error getting plans : %v", err)
 			}
@@ -97,6 +121,7 @@ func NewCmdPlan(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 	list.Flags().StringP(
 		"type",
 		"t",
@@ -114,7 +139,7 @@ Defaults to all Instances plans.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			m, meta, err := o.metalList()
+			m, meta, err := utils.ListAll(cmd, o.Base.Options, o.metalList)
 			if err != nil {
 				return fmt.Errorf("error getting bare metal plans : %v", err)
 			}
@@ -136,8 +161,78 @@ Defaults to all Instances plans.`,
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(metal)
+
+	// Find
+	find := &cobra.Command{
+		Use:     "find",
+		Short:   "Search plans by resources, price and region",
+		Aliases: []string{"f", "search"},
+		Long:    findLong,
+		Example: findExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := parseFindFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			plans, err := o.find(f)
+			if err != nil {
+				return err
+			}
+
+			data := &PlanFindPrinter{Plans: plans}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	find.Flags().Int("min-vcpu", 0, "(optional) minimum number of vCPUs")
+	find.Flags().Int("min-ram", 0, "(optional) minimum amount of RAM in MB")
+	find.Flags().Int("min-disk", 0, "(optional) minimum disk size in GB")
+	find.Flags().Float32("max-price", 0, "(optional) maximum price per month")
+	find.Flags().StringP("region", "r", "", "(optional) only plans available in this region ID")
+	find.Flags().StringP(
+		"type",
+		"t",
+		"",
+		"(optional) plan type or ID prefix, for example 'vc2', 'vhf', 'vdc', 'voc-g' or 'vbm' for bare metal",
+	)
+	find.Flags().StringP(
+		"sort",
+		"s",
+		sortPrice,
+		fmt.Sprintf("(optional) field to sort by. Possible values: %s", strings.Join(sortFields, ", ")),
+	)
 
-	cmd.AddCommand(list, metal)
+	// Compare
+	compare := &cobra.Command{
+		Use:     "compare <Plan ID> <Plan ID>...",
+		Short:   "Compare plans side by side",
+		Aliases: []string{"cmp"},
+		Long:    compareLong,
+		Example: compareExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide at least two plan IDs")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plans, err := o.compare(args)
+			if err != nil {
+				return err
+			}
+
+			data := &PlanComparePrinter{Plans: plans}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(list, metal, find, compare)
 	return cmd
 }
 
@@ -146,12 +241,64 @@ type options struct {
 	PlanType string
 }
 
+// parseFindFlags reads the plans find flags into a filter
+func parseFindFlags(cmd *cobra.Command) (*planFilter, error) {
+	minVCPU, errVC := cmd.Flags().GetInt("min-vcpu")
+	if errVC != nil {
+		return nil, fmt.Errorf("error parsing flag 'min-vcpu' for plan find : %v", errVC)
+	}
+
+	minRAM, errRA := cmd.Flags().GetInt("min-ram")
+	if errRA != nil {
+		return nil, fmt.Errorf("error parsing flag 'min-ram' for plan find : %v", errRA)
+	}
+
+	minDisk, errDi := cmd.Flags().GetInt("min-disk")
+	if errDi != nil {
+		return nil, fmt.Errorf("error parsing flag 'min-disk' for plan find : %v", errDi)
+	}
+
+	maxPrice, errPr := cmd.Flags().GetFloat32("max-price")
+	if errPr != nil {
+		return nil, fmt.Errorf("error parsing flag 'max-price' for plan find : %v", errPr)
+	}
+
+	region, errRe := cmd.Flags().GetString("region")
+	if errRe != nil {
+		return nil, fmt.Errorf("error parsing flag 'region' for plan find : %v", errRe)
+	}
+
+	planType, errTy := cmd.Flags().GetString("type")
+	if errTy != nil {
+		return nil, fmt.Errorf("error parsing flag 'type' for plan find : %v", errTy)
+	}
+
+	sortBy, errSo := cmd.Flags().GetString("sort")
+	if errSo != nil {
+		return nil, fmt.Errorf("error parsing flag 'sort' for plan find : %v", errSo)
+	}
+
+	if !slices.Contains(sortFields, sortBy) {
+		return nil, fmt.Errorf("invalid sort %q, possible values are %s", sortBy, strings.Join(sortFields, ", "))
+	}
+
+	return &planFilter{
+		MinVCPU:  minVCPU,
+		MinRAM:   minRAM,
+		MinDisk:  minDisk,
+		MaxPrice: maxPrice,
+		Region:   region,
+		Type:     planType,
+		Sort:     sortBy,
+	}, nil
+}
+
 func (o *options) list() ([]govultr.Plan, *govultr.Meta, error) {
-	plans, meta, _, err := o.Base.Client.Plan.List(context.Background(), o.PlanType, o.Base.Options)
+	plans, meta, _, err := o.Base.Client.Plan.List(o.Base.Context, o.PlanType, o.Base.Options)
 	return plans, meta, err
 }
 
 func (o *options) metalList() ([]govultr.BareMetalPlan, *govultr.Meta, error) {
-	plans, meta, _, err := o.Base.Client.Plan.ListBareMetal(context.Background(), o.Base.Options)
+	plans, meta, _, err := o.Base.Client.Plan.ListBareMetal(o.Base.Context, o.Base.Options)
 	return plans, meta, err
 }
@@ -1,6 +1,7 @@
 package plans
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -141,3 +142,137 @@ func (m *MetalPlansPrinter) Data() [][]string {
 func (m *MetalPlansPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(m.Meta).Compose()
 }
+
+// ======================================
+
+// PlanFindPrinter represents the plans matching a search
+type PlanFindPrinter struct {
+	Plans []plan `json:"plans"`
+}
+
+// JSON provides the JSON formatted byte data
+func (p *PlanFindPrinter) JSON() []byte {
+	return printer.MarshalObject(p, "json")
+}
+
+// YAML provides the YAML formatted byte data
+func (p *PlanFindPrinter) YAML() []byte {
+	return printer.MarshalObject(p, "yaml")
+}
+
+// Columns provides the plan columns for the printer
+func (p *PlanFindPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"TYPE",
+		"VCPU COUNT",
+		"RAM",
+		"DISK",
+		"BANDWIDTH GB",
+		"PRICE PER MONTH",
+		"GPU",
+		"REGIONS",
+	}}
+}
+
+// Data provides the plan data for the printer
+func (p *PlanFindPrinter) Data() [][]string {
+	data := [][]string{}
+
+	if len(p.Plans) == 0 {
+		data = append(data, []string{"---", "---", "---", "---", "---", "---", "---", "---", "---"})
+		return data
+	}
+
+	for i := range p.Plans {
+		data = append(data, []string{
+			p.Plans[i].ID,
+			p.Plans[i].Type,
+			strconv.Itoa(p.Plans[i].VCPUCount),
+			strconv.Itoa(p.Plans[i].RAM),
+			strconv.Itoa(p.Plans[i].Disk),
+			strconv.Itoa(p.Plans[i].Bandwidth),
+			strconv.FormatFloat(float64(p.Plans[i].MonthlyCost), 'f', utils.FloatPrecision, 32),
+			planGPU(&p.Plans[i]),
+			strconv.Itoa(len(p.Plans[i].Locations)),
+		})
+	}
+
+	return data
+}
+
+// Paging validates and forms the paging data for output
+func (p *PlanFindPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// PlanComparePrinter represents plans shown side by side
+type PlanComparePrinter struct {
+	Plans []plan `json:"plans"`
+}
+
+// JSON provides the JSON formatted byte data
+func (p *PlanComparePrinter) JSON() []byte {
+	return printer.MarshalObject(p, "json")
+}
+
+// YAML provides the YAML formatted byte data
+func (p *PlanComparePrinter) YAML() []byte {
+	return printer.MarshalObject(p, "yaml")
+}
+
+// Columns provides a column for each plan
+func (p *PlanComparePrinter) Columns() [][]string {
+	columns := []string{"PLAN"}
+	for i := range p.Plans {
+		columns = append(columns, p.Plans[i].ID)
+	}
+	return [][]string{0: columns}
+}
+
+// Data provides a row for each plan field
+func (p *PlanComparePrinter) Data() [][]string {
+	fields := []struct {
+		name  string
+		value func(*plan) string
+	}{
+		{"TYPE", func(pl *plan) string { return pl.Type }},
+		{"VCPU COUNT", func(pl *plan) string { return strconv.Itoa(pl.VCPUCount) }},
+		{"CPU MODEL", func(pl *plan) string { return pl.CPUModel }},
+		{"RAM", func(pl *plan) string { return strconv.Itoa(pl.RAM) }},
+		{"DISK", func(pl *plan) string { return strconv.Itoa(pl.Disk) }},
+		{"DISK COUNT", func(pl *plan) string { return strconv.Itoa(pl.DiskCount) }},
+		{"BANDWIDTH GB", func(pl *plan) string { return strconv.Itoa(pl.Bandwidth) }},
+		{"PRICE PER MONTH", func(pl *plan) string {
+			return strconv.FormatFloat(float64(pl.MonthlyCost), 'f', utils.FloatPrecision, 32)
+		}},
+		{"GPU", planGPU},
+		{"REGIONS", func(pl *plan) string { return strconv.Itoa(len(pl.Locations)) }},
+	}
+
+	data := [][]string{}
+	for i := range fields {
+		row := []string{fields[i].name}
+		for j := range p.Plans {
+			row = append(row, fields[i].value(&p.Plans[j]))
+		}
+		data = append(data, row)
+	}
+
+	return data
+}
+
+// Paging validates and forms the paging data for output
+func (p *PlanComparePrinter) Paging() [][]string {
+	return nil
+}
+
+// planGPU describes the GPU of a plan, if any
+func planGPU(p *plan) string {
+	if p.GPUType == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %dGB", p.GPUType, p.GPUVRAM)
+}
@@ -0,0 +1,225 @@
+// Package plugin provides the discovery and dispatch of third-party
+// vultr-cli-<name> executables found on the PATH
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/cmd/version"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// Prefix is the start of the name of every plugin executable
+const Prefix = "vultr-cli-"
+
+var (
+	pluginLong = `Plugins add subcommands to the CLI without changing it.  Any executable on the PATH
+named vultr-cli-<name> runs as 'vultr-cli <name>', with dashes in the name standing for spaces,
+so vultr-cli-foo-bar runs as 'vultr-cli foo bar'.  Built-in commands always take precedence.
+
+The plugin receives the remaining arguments along with these environment variables:
+
+  VULTR_API_KEY      the API key of the active profile or keyring, if any
+  VULTR_CLI_CONFIG   the config file in use
+  VULTR_PROFILE      the active profile, if any
+  VULTR_CLI_OUTPUT   the output format of the config file or profile
+  VULTR_CLI_VERSION  the version of vultr-cli
+`
+	pluginExample = `
+	# Full example
+	vultr-cli plugin
+	`
+	listLong    = `List the plugins found on the PATH`
+	listExample = `
+	# Full example
+	vultr-cli plugin list
+	`
+)
+
+// NewCmdPlugin provides the CLI command for plugins
+func NewCmdPlugin(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "plugin",
+		Short:   "Commands to inspect plugins",
+		Long:    pluginLong,
+		Example: pluginExample,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.SetOptions(o.Base, cmd, args)
+		},
+	}
+
+	// List
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List the plugins found on the PATH",
+		Aliases: []string{"l"},
+		Long:    listLong,
+		Example: listExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			o.Base.Printer.Display(&PluginsPrinter{Plugins: discover(cmd.Root())}, nil)
+		},
+	}
+
+	cmd.AddCommand(
+		list,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// Lookup finds the plugin for the arguments, trying the longest name made
+// of the leading arguments first.  Arguments which match a built-in
+// command are never looked up
+func Lookup(root *cobra.Command, args []string) (string, []string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", nil, false
+	}
+
+	if cmd, _, err := root.Find(args); err == nil && cmd != root {
+		return "", nil, false
+	}
+
+	var words []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+
+	for n := len(words); n > 0; n-- {
+		if path, err := exec.LookPath(Prefix + strings.Join(words[:n], "-")); err == nil {
+			return path, args[n:], true
+		}
+	}
+
+	return "", nil, false
+}
+
+// Env returns the environment of a plugin, which passes on the settings of
+// the CLI
+func Env(b *cli.Base) []string {
+	env := os.Environ()
+	if key := b.APIKey(); key != "" {
+		env = append(env, "VULTR_API_KEY="+key)
+	}
+
+	env = append(env,
+		"VULTR_CLI_CONFIG="+viper.ConfigFileUsed(),
+		"VULTR_PROFILE="+viper.GetString("profile"),
+		"VULTR_API_ENDPOINT="+b.Endpoint(),
+		"VULTR_CLI_OUTPUT="+b.Printer.Output,
+		"VULTR_CLI_VERSION="+version.Version,
+	)
+
+	return env
+}
+
+// Run runs the plugin and returns its exit code.  Interrupts are left to
+// the plugin, which shares the terminal
+func Run(ctx context.Context, path string, args, env []string) (int, error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = env
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, fmt.Errorf("error running plugin %s : %v", path, err)
+	}
+
+	return 0, nil
+}
+
+// plugin is an executable found on the PATH
+type plugin struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+// discover lists the plugins on the PATH in the order they are searched,
+// noting those which can't run because a built-in command or an earlier
+// plugin has the same name
+func discover(root *cobra.Command) []plugin {
+	var plugins []plugin
+	seen := map[string]string{}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if !executable(path) {
+				continue
+			}
+
+			p := plugin{Name: strings.ReplaceAll(name, "-", " "), Path: path, Status: "ok"}
+			args := strings.Split(name, "-")
+			if cmd, _, err := root.Find(args); err == nil && cmd != root {
+				p.Status = "shadowed by the built-in command " + cmd.CommandPath()
+			} else if first, ok := seen[name]; ok {
+				p.Status = "shadowed by " + first
+			} else {
+				seen[name] = path
+			}
+
+			plugins = append(plugins, p)
+		}
+	}
+
+	slices.SortStableFunc(plugins, func(a, b plugin) int { return strings.Compare(a.Name, b.Name) })
+
+	return plugins
+}
+
+// executable reports whether the file can be run by the current user
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		return slices.Contains([]string{".exe", ".bat", ".cmd", ".com"}, strings.ToLower(filepath.Ext(path)))
+	}
+
+	return info.Mode()&0o111 != 0
+}
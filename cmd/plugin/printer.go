@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// PluginsPrinter ...
+type PluginsPrinter struct {
+	Plugins []plugin `json:"plugins"`
+}
+
+// JSON ...
+func (p *PluginsPrinter) JSON() []byte {
+	return printer.MarshalObject(p, "json")
+}
+
+// YAML ...
+func (p *PluginsPrinter) YAML() []byte {
+	return printer.MarshalObject(p, "yaml")
+}
+
+// Columns ...
+func (p *PluginsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"NAME",
+		"PATH",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (p *PluginsPrinter) Data() [][]string {
+	if len(p.Plugins) == 0 {
+		return [][]string{0: {"---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range p.Plugins {
+		data = append(data, []string{
+			p.Plugins[i].Name,
+			p.Plugins[i].Path,
+			p.Plugins[i].Status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (p *PluginsPrinter) Paging() [][]string {
+	return nil
+}
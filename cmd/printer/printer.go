@@ -2,12 +2,14 @@
 package printer
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 
 	"github.com/vultr/govultr/v3"
 	"gopkg.in/yaml.v3"
@@ -21,6 +23,7 @@ const (
 	twFlags          uint   = 0
 	emptyPlaceholder string = "---"
 	JSONIndent       string = "    "
+	TemplatePrefix   string = "go-template="
 )
 
 type ResourceOutput interface {
@@ -31,6 +34,13 @@ type ResourceOutput interface {
 	Paging() [][]string
 }
 
+// WideOutput is implemented by resources with more columns to print with
+// --wide
+type WideOutput interface {
+	WideColumns() [][]string
+	WideData() [][]string
+}
+
 type Printer interface {
 	display(values columns, lengths []int)
 	flush()
@@ -40,6 +50,8 @@ type Output struct {
 	Type     string
 	Resource ResourceOutput
 	Output   string
+	Query    string
+	Table    TableOptions
 }
 
 type columns []interface{}
@@ -58,7 +70,9 @@ func init() {
 }
 
 // Display confirms the output format then displays the ResourceOutput data to
-// the CLI.  If there is an error, that is displayed instead via Error
+// the CLI.  If there is an error, that is displayed instead via Error.  It
+// returns once the data is displayed, so a command can still fail afterwards
+// such as when some of the changes it reports didn't succeed
 func (o *Output) Display(r ResourceOutput, err error) {
 	defer o.flush()
 
@@ -67,16 +81,44 @@ func (o *Output) Display(r ResourceOutput, err error) {
 		Error(err)
 	}
 
+	if o.Table.Short {
+		if errSh := o.displayShort(r); errSh != nil {
+			Error(errSh)
+		}
+		return
+	}
+
+	if o.Query != "" {
+		o.displayQuery(r)
+		return
+	}
+
 	if strings.ToLower(o.Output) == "json" {
 		o.displayNonText(r.JSON())
-		os.Exit(0)
+		return
 	} else if strings.ToLower(o.Output) == "yaml" {
 		o.displayNonText(r.YAML())
-		os.Exit(0)
+		return
+	} else if strings.ToLower(o.Output) == "csv" {
+		o.displayCSV(r)
+		return
+	} else if tmpl, ok := o.template(); ok {
+		o.displayTemplate(r, tmpl)
+		return
 	}
 
-	o.display(r.Columns())
-	o.display(r.Data())
+	header, rows, errTa := o.tableData(r)
+	if errTa != nil {
+		Error(errTa)
+	}
+
+	header, rows, errTa = o.shapeTable(header, rows, true)
+	if errTa != nil {
+		Error(errTa)
+	}
+
+	o.display(header)
+	o.display(rows)
 	if r.Paging() != nil {
 		o.display(r.Paging())
 	}
@@ -105,6 +147,64 @@ func (o *Output) displayNonText(data []byte) {
 	fmt.Printf("%s\n", string(data))
 }
 
+// displayCSV writes the columns and data as comma separated values, leaving
+// out the paging details
+func (o *Output) displayCSV(r ResourceOutput) {
+	header, rows, err := o.tableData(r)
+	if err != nil {
+		Error(err)
+	}
+
+	header, rows, err = o.shapeTable(header, rows, false)
+	if err != nil {
+		Error(err)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	if err := w.WriteAll(append(header, rows...)); err != nil {
+		Error(fmt.Errorf("error writing CSV : %v", err))
+	}
+}
+
+// template returns the template of a go-template output format with any
+// quotes around it removed
+func (o *Output) template() (string, bool) {
+	if !strings.HasPrefix(strings.ToLower(o.Output), TemplatePrefix) {
+		return "", false
+	}
+
+	tmpl := o.Output[len(TemplatePrefix):]
+	if len(tmpl) > 1 && (tmpl[0] == '\'' || tmpl[0] == '"') && tmpl[len(tmpl)-1] == tmpl[0] {
+		tmpl = tmpl[1 : len(tmpl)-1]
+	}
+
+	return tmpl, true
+}
+
+// displayTemplate executes the go template against the JSON form of the
+// resource so the fields are referenced by their JSON names
+func (o *Output) displayTemplate(r ResourceOutput, tmpl string) {
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		Error(fmt.Errorf("error parsing output template : %v", err))
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(r.JSON(), &data); err != nil {
+		Error(fmt.Errorf("error preparing output template data : %v", err))
+	}
+
+	var out strings.Builder
+	if err := t.Execute(&out, data); err != nil {
+		Error(fmt.Errorf("error executing output template : %v", err))
+	}
+
+	if !strings.HasSuffix(out.String(), "\n") {
+		out.WriteString("\n")
+	}
+	fmt.Print(out.String())
+}
+
 // Paging struct holds the values used by the Meta section in the printer
 // output
 type Paging struct {
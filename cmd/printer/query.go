@@ -0,0 +1,122 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// queryRoot unwraps the single resource key of the printer JSON, such as
+// "instances", so queries run directly against the resource.  The meta key
+// holding the paging details is ignored.
+func queryRoot(data interface{}) interface{} {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+
+	var root interface{}
+	keys := 0
+	for k, v := range m {
+		if k == "meta" || k == "Meta" {
+			continue
+		}
+		root = v
+		keys++
+	}
+
+	if keys != 1 {
+		return data
+	}
+	return root
+}
+
+// runQuery runs the JMESPath expression against the resource JSON
+func runQuery(expr string, raw []byte) (interface{}, error) {
+	q, err := jmespath.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query : %v", err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("error preparing query data : %v", err)
+	}
+
+	result, err := q.Search(queryRoot(data))
+	if err != nil {
+		return nil, fmt.Errorf("error running query : %v", err)
+	}
+
+	return result, nil
+}
+
+// displayQuery prints the result of the query against the resource as JSON
+// or YAML when requested, otherwise as plain text
+func (o *Output) displayQuery(r ResourceOutput) {
+	result, err := runQuery(o.Query, r.JSON())
+	if err != nil {
+		Error(err)
+	}
+
+	switch strings.ToLower(o.Output) {
+	case "json":
+		o.displayNonText(MarshalObject(result, "json"))
+	case "yaml":
+		o.displayNonText(MarshalObject(result, "yaml"))
+	default:
+		if text := queryText(result); text != "" {
+			fmt.Println(text)
+		}
+	}
+}
+
+// queryText renders a query result as text.  Lists are printed one element
+// per line with nested lists separated by tabs, objects are printed as JSON.
+func queryText(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		lines := make([]string, 0, len(t))
+		for i := range t {
+			if row, ok := t[i].([]interface{}); ok {
+				fields := make([]string, 0, len(row))
+				for j := range row {
+					fields = append(fields, queryScalar(row[j]))
+				}
+				lines = append(lines, strings.Join(fields, "\t"))
+				continue
+			}
+			lines = append(lines, queryScalar(t[i]))
+		}
+		return strings.Join(lines, "\n")
+	case map[string]interface{}:
+		return string(MarshalObject(t, "json"))
+	default:
+		return queryScalar(t)
+	}
+}
+
+// queryScalar renders a single value, using compact JSON for objects
+func queryScalar(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprintf("%v", t)
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,93 @@
+package printer
+
+import "testing"
+
+const queryInstances = `{
+	"instances": [
+		{"id": "a", "label": "web", "region": "ewr", "ram": 2048, "tags": ["prod", "web"], "enabled": true},
+		{"id": "b", "label": "db", "region": "ams", "ram": 4096, "tags": ["prod"], "enabled": false},
+		{"id": "c", "label": "dev", "region": "ewr", "ram": 1024, "tags": [], "enabled": true}
+	],
+	"meta": {"total": 3, "links": {"next": "", "prev": ""}}
+}`
+
+func TestRunQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+		err  bool
+	}{
+		{name: "field projection", expr: "[*].id", want: "a\nb\nc"},
+		{name: "index", expr: "[0].label", want: "web"},
+		{name: "negative index", expr: "[-1].label", want: "dev"},
+		{name: "slice", expr: "[1:].id", want: "b\nc"},
+		{name: "filter", expr: "[?region=='ewr'].label", want: "web\ndev"},
+		{name: "numeric filter", expr: "[?ram > `2048`].id", want: "b"},
+		{name: "boolean filter", expr: "[?enabled && region=='ewr'].id", want: "a\nc"},
+		{name: "negated filter", expr: "[?!enabled].id", want: "b"},
+		{name: "multi-select list", expr: "[*].[id, ram]", want: "a\t2048\nb\t4096\nc\t1024"},
+		{name: "multi-select hash", expr: "[0].{name: label, where: region}", want: `{
+    "name": "web",
+    "where": "ewr"
+}`},
+		{name: "flatten", expr: "[].tags[]", want: "prod\nweb\nprod"},
+		{name: "pipe", expr: "[?region=='ewr'] | [0].id", want: "a"},
+		{name: "length", expr: "length(@)", want: "3"},
+		{name: "sort_by", expr: "sort_by(@, &ram)[].id", want: "c\na\nb"},
+		{name: "max_by", expr: "max_by(@, &ram).label", want: "db"},
+		{name: "contains", expr: "[?contains(tags, 'web')].id", want: "a"},
+		{name: "join", expr: "join(',', [*].id)", want: "a,b,c"},
+		{name: "sum", expr: "sum([*].ram)", want: "7168"},
+		{name: "missing field", expr: "[0].missing", want: ""},
+		{name: "meta is not the root", expr: "meta", want: ""},
+		{name: "unterminated filter", expr: "[?region=='ewr'", err: true},
+		{name: "unknown function", expr: "nope(@)", err: true},
+		{name: "wrong argument type", expr: "length(`1`)", err: true},
+		{name: "empty", expr: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runQuery(tt.expr, []byte(queryInstances))
+			if tt.err {
+				if err == nil {
+					t.Fatalf("runQuery(%q) = %v, want an error", tt.expr, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runQuery(%q) returned error %v", tt.expr, err)
+			}
+			if got := queryText(result); got != tt.want {
+				t.Errorf("runQuery(%q) printed %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryRoot(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		expr string
+		want string
+	}{
+		{name: "single resource key", json: `{"instance": {"id": "a"}}`, expr: "id", want: "a"},
+		{name: "resource key and meta", json: `{"ssh_keys": [{"id": "a"}], "meta": {}}`, expr: "[0].id", want: "a"},
+		{name: "several keys are kept", json: `{"id": "a", "label": "web"}`, expr: "label", want: "web"},
+		{name: "list at the top", json: `[{"id": "a"}]`, expr: "[0].id", want: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runQuery(tt.expr, []byte(tt.json))
+			if err != nil {
+				t.Fatalf("runQuery(%q) returned error %v", tt.expr, err)
+			}
+			if got := queryText(result); got != tt.want {
+				t.Errorf("runQuery(%q) printed %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
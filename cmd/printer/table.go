@@ -0,0 +1,348 @@
+package printer
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// truncateWidth is the widest a cell is printed on a terminal unless
+	// truncation is turned off.  It fits a UUID.
+	truncateWidth = 40
+	truncateMark  = "…"
+
+	// the color codes have the same length so colored columns stay aligned
+	colorDefault = "\x1b[39m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorRed     = "\x1b[31m"
+	colorReset   = "\x1b[0m"
+)
+
+// statusColors are the colors of the statuses reported by the API.  Statuses
+// which are not listed are printed without a color.
+var statusColors = map[string]string{
+	"active":            colorGreen,
+	"running":           colorGreen,
+	"ok":                colorGreen,
+	"complete":          colorGreen,
+	"healthy":           colorGreen,
+	"ready":             colorGreen,
+	"online":            colorGreen,
+	"enabled":           colorGreen,
+	"deleted":           colorGreen,
+	"pending":           colorYellow,
+	"planned":           colorYellow,
+	"locked":            colorYellow,
+	"resizing":          colorYellow,
+	"rebuilding":        colorYellow,
+	"installing":        colorYellow,
+	"installingbooting": colorYellow,
+	"rebalancing":       colorYellow,
+	"migrating":         colorYellow,
+	"stopped":           colorRed,
+	"failed":            colorRed,
+	"error":             colorRed,
+	"suspended":         colorRed,
+	"down":              colorRed,
+	"unhealthy":         colorRed,
+	"offline":           colorRed,
+	"disabled":          colorRed,
+}
+
+// TableOptions changes how the columns of a table are printed as text
+type TableOptions struct {
+	// Columns limits the output to these columns, in this order
+	Columns []string
+	// SortBy sorts the rows on the column, descending when it starts with -
+	SortBy string
+	// NoTrunc prints long cells in full on a terminal
+	NoTrunc bool
+	// NoColor turns off the status colors, as does the NO_COLOR variable
+	NoColor bool
+	// Short prints only the IDs, one per line
+	Short bool
+	// Wide prints the extra columns of resources implementing WideOutput
+	Wide bool
+}
+
+// tableData returns the columns and data of the resource, the wide ones when
+// they were asked for
+func (o *Output) tableData(r ResourceOutput) ([][]string, [][]string, error) {
+	if !o.Table.Wide {
+		return r.Columns(), r.Data(), nil
+	}
+
+	w, ok := r.(WideOutput)
+	if !ok {
+		return nil, nil, errors.New("--wide is not supported by this command")
+	}
+
+	return w.WideColumns(), w.WideData(), nil
+}
+
+// displayShort prints the ID column of the table, one per line, for use in
+// pipelines such as xargs.  Rows are still sorted by --sort-by.
+func (o *Output) displayShort(r ResourceOutput) error {
+	if o.Table.Wide {
+		return errors.New("--short and --wide can't be used together")
+	}
+
+	header, rows, err := o.shapeTable(r.Columns(), r.Data(), false)
+	if err != nil {
+		return err
+	}
+
+	if !isTable(header, rows) {
+		return errors.New("--short is only supported by output with a table")
+	}
+
+	idx, err := columnIndex(header[0], "id")
+	if err != nil {
+		return errors.New("--short is only supported by output with an ID column")
+	}
+
+	for i := range rows {
+		// empty lists print a row of placeholders
+		if rows[i][idx] != emptyPlaceholder {
+			fmt.Println(rows[i][idx])
+		}
+	}
+
+	return nil
+}
+
+// isTable reports whether the output is a single header row followed by
+// rows of the same width, which is the shape of the list commands
+func isTable(header, rows [][]string) bool {
+	if len(header) != 1 || len(header[0]) == 0 {
+		return false
+	}
+
+	for i := range rows {
+		if len(rows[i]) != len(header[0]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shapeTable applies the table options to the header and rows.  Cells are
+// only truncated and colored for text output.
+func (o *Output) shapeTable(header, rows [][]string, text bool) ([][]string, [][]string, error) {
+	truncate := text && o.truncate()
+	color := text && o.color()
+	if len(o.Table.Columns) == 0 && o.Table.SortBy == "" && !truncate && !color {
+		return header, rows, nil
+	}
+
+	if !isTable(header, rows) {
+		if len(o.Table.Columns) > 0 || o.Table.SortBy != "" {
+			return nil, nil, errors.New("--columns and --sort-by are only supported by output with a table")
+		}
+		return header, rows, nil
+	}
+
+	// work on copies since printers may return their own slices
+	names := slices.Clone(header[0])
+	table := make([][]string, len(rows))
+	for i := range rows {
+		table[i] = slices.Clone(rows[i])
+	}
+
+	names, table, err := o.arrangeTable(names, table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if truncate {
+		truncateTable(table)
+	}
+
+	if color {
+		colorStatuses(names, table)
+	}
+
+	return [][]string{0: names}, table, nil
+}
+
+// arrangeTable sorts the rows and selects the columns
+func (o *Output) arrangeTable(names []string, rows [][]string) ([]string, [][]string, error) {
+	if o.Table.SortBy != "" {
+		if err := sortTable(names, rows, o.Table.SortBy); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(o.Table.Columns) > 0 {
+		return selectColumns(names, rows, o.Table.Columns)
+	}
+
+	return names, rows, nil
+}
+
+// truncate reports whether long cells are shortened, which is only done on
+// a terminal so piped output is complete
+func (o *Output) truncate() bool {
+	return !o.Table.NoTrunc && isTerminal(os.Stdout)
+}
+
+// color reports whether statuses are colored
+func (o *Output) color() bool {
+	return !o.Table.NoColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+}
+
+// columnIndex returns the index of the column named by name.  Names match
+// regardless of case, and dashes or underscores match the spaces of the
+// header, so main-ip matches MAIN IP.
+func columnIndex(names []string, name string) (int, error) {
+	normalize := func(s string) string {
+		return strings.ToUpper(strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimSpace(s)))
+	}
+
+	want := normalize(name)
+	for i := range names {
+		if normalize(names[i]) == want {
+			return i, nil
+		}
+	}
+
+	available := make([]string, len(names))
+	for i := range names {
+		available[i] = strings.ToLower(strings.ReplaceAll(names[i], " ", "-"))
+	}
+
+	return 0, fmt.Errorf("unknown column %q, must be one of %s", name, strings.Join(available, ", "))
+}
+
+// selectColumns returns the header and rows with only the columns, in the
+// order given
+func selectColumns(names []string, rows [][]string, columns []string) ([]string, [][]string, error) {
+	indexes := make([]int, len(columns))
+	for i := range columns {
+		idx, err := columnIndex(names, columns[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		indexes[i] = idx
+	}
+
+	pick := func(row []string) []string {
+		picked := make([]string, len(indexes))
+		for i, idx := range indexes {
+			picked[i] = row[idx]
+		}
+		return picked
+	}
+
+	selected := make([][]string, len(rows))
+	for i := range rows {
+		selected[i] = pick(rows[i])
+	}
+
+	return pick(names), selected, nil
+}
+
+// sortTable sorts the rows on the column.  Cells which are all numbers,
+// including prices and sizes such as $5.00 or 25 GB, sort as numbers.
+func sortTable(names []string, rows [][]string, sortBy string) error {
+	column, desc := strings.CutPrefix(sortBy, "-")
+	idx, err := columnIndex(names, column)
+	if err != nil {
+		return err
+	}
+
+	numeric := true
+	for i := range rows {
+		if _, ok := cellNumber(rows[i][idx]); !ok {
+			numeric = false
+			break
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b []string) int {
+		var c int
+		if numeric {
+			x, _ := cellNumber(a[idx])
+			y, _ := cellNumber(b[idx])
+			c = cmp.Compare(x, y)
+		} else {
+			c = strings.Compare(strings.ToLower(a[idx]), strings.ToLower(b[idx]))
+		}
+
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	return nil
+}
+
+// cellNumber parses the number in a cell, ignoring a leading $ and a
+// trailing unit
+func cellNumber(cell string) (float64, bool) {
+	s := strings.TrimPrefix(strings.TrimSpace(cell), "$")
+	if fields := strings.Fields(s); len(fields) == 2 {
+		s = fields[0]
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+// truncateTable shortens the long cells of the rows
+func truncateTable(rows [][]string) {
+	for i := range rows {
+		for j := range rows[i] {
+			rows[i][j] = truncateCell(rows[i][j])
+		}
+	}
+}
+
+// truncateCell shortens the cell to truncateWidth characters
+func truncateCell(cell string) string {
+	if utf8.RuneCountInString(cell) <= truncateWidth {
+		return cell
+	}
+
+	runes := []rune(cell)
+	return string(runes[:truncateWidth-1]) + truncateMark
+}
+
+// colorStatuses colors the cells of the status columns.  Every cell of a
+// status column is wrapped in codes of the same length, the default color
+// for the others, since the tabwriter counts the codes as text.
+func colorStatuses(names []string, rows [][]string) {
+	for col := range names {
+		if !strings.Contains(strings.ToUpper(names[col]), "STATUS") {
+			continue
+		}
+
+		names[col] = colorDefault + names[col] + colorReset
+		for i := range rows {
+			color, ok := statusColors[strings.ToLower(rows[i][col])]
+			if !ok {
+				color = colorDefault
+			}
+			rows[i][col] = color + rows[i][col] + colorReset
+		}
+	}
+}
+
+// isTerminal reports whether the file is a terminal rather than a pipe or a
+// file
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,147 @@
+package regions
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// region options as reported by the API
+const (
+	optionBlockHDD      = "block_storage_storage_opt"
+	optionBlockNVMe     = "block_storage_high_perf"
+	optionKubernetes    = "kubernetes"
+	optionLoadBalancers = "load_balancers"
+	optionDDoS          = "ddos_protection"
+)
+
+// regionCapabilities lists the products available in a region
+type regionCapabilities struct {
+	ID             string   `json:"id"`
+	City           string   `json:"city"`
+	Country        string   `json:"country"`
+	BlockStorage   []string `json:"block_storage"`
+	ObjectStorage  []string `json:"object_storage"`
+	BareMetal      bool     `json:"bare_metal"`
+	GPU            bool     `json:"gpu"`
+	Kubernetes     bool     `json:"kubernetes"`
+	LoadBalancers  bool     `json:"load_balancers"`
+	DDoSProtection bool     `json:"ddos_protection"`
+}
+
+// capabilities combines the region options with the locations of the plans
+// and object storage tiers into the products available per region
+func (o *options) capabilities() ([]regionCapabilities, error) {
+	regions, err := o.listAll()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving region list : %v", err)
+	}
+
+	gpu, err := o.gpuRegions()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving plans : %v", err)
+	}
+
+	metal, err := o.metalRegions()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bare metal plans : %v", err)
+	}
+
+	tiers, _, err := o.Base.Client.ObjectStorage.ListTiers(o.Base.Context)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving object storage tiers : %v", err)
+	}
+
+	objectStorage := map[string][]string{}
+	for i := range tiers {
+		for j := range tiers[i].Locations {
+			region := tiers[i].Locations[j].Region
+			if !slices.Contains(objectStorage[region], tiers[i].Slug) {
+				objectStorage[region] = append(objectStorage[region], tiers[i].Slug)
+			}
+		}
+	}
+
+	var caps []regionCapabilities
+	for i := range regions {
+		r := regionCapabilities{
+			ID:             regions[i].ID,
+			City:           regions[i].City,
+			Country:        regions[i].Country,
+			ObjectStorage:  objectStorage[regions[i].ID],
+			BareMetal:      metal[regions[i].ID],
+			GPU:            gpu[regions[i].ID],
+			Kubernetes:     slices.Contains(regions[i].Options, optionKubernetes),
+			LoadBalancers:  slices.Contains(regions[i].Options, optionLoadBalancers),
+			DDoSProtection: slices.Contains(regions[i].Options, optionDDoS),
+		}
+
+		if slices.Contains(regions[i].Options, optionBlockHDD) {
+			r.BlockStorage = append(r.BlockStorage, "hdd")
+		}
+		if slices.Contains(regions[i].Options, optionBlockNVMe) {
+			r.BlockStorage = append(r.BlockStorage, "nvme")
+		}
+
+		caps = append(caps, r)
+	}
+
+	sort.Slice(caps, func(i, j int) bool { return caps[i].ID < caps[j].ID })
+
+	return caps, nil
+}
+
+// listAll retrieves every region
+func (o *options) listAll() ([]govultr.Region, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Region, *govultr.Meta, error) {
+		regions, meta, _, err := o.Base.Client.Region.List(o.Base.Context, opts)
+		return regions, meta, err
+	})
+}
+
+// gpuRegions returns the regions where at least one GPU plan is available
+func (o *options) gpuRegions() (map[string]bool, error) {
+	regions := map[string]bool{}
+	plans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+		plans, meta, _, err := o.Base.Client.Plan.List(o.Base.Context, "", opts)
+		return plans, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range plans {
+		if plans[i].GPUType == "" {
+			continue
+		}
+		for j := range plans[i].Locations {
+			regions[plans[i].Locations[j]] = true
+		}
+	}
+
+	return regions, nil
+}
+
+// metalRegions returns the regions where at least one bare metal plan is
+// available
+func (o *options) metalRegions() (map[string]bool, error) {
+	regions := map[string]bool{}
+	plans, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, error) {
+		plans, meta, _, err := o.Base.Client.Plan.ListBareMetal(o.Base.Context, opts)
+		return plans, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range plans {
+		for j := range plans[i].Locations {
+			regions[plans[i].Locations[j]] = true
+		}
+	}
+
+	return regions, nil
+}
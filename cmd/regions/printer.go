@@ -1,6 +1,8 @@
 package regions
 
 import (
+	"strconv"
+
 	"github.com/vultr/govultr/v3"
 
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -109,3 +111,70 @@ func (r *RegionsAvailabilityPrinter) Data() [][]string {
 func (r *RegionsAvailabilityPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// RegionsCapabilitiesPrinter represents the products available per region
+// and contains the methods to format and print the data via the
+// ResourceOutput interface
+type RegionsCapabilitiesPrinter struct {
+	Regions []regionCapabilities `json:"regions"`
+}
+
+// JSON provides the JSON formatted byte data
+func (r *RegionsCapabilitiesPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML provides the YAML formatted byte data
+func (r *RegionsCapabilitiesPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns provides the capabilities columns for the printer
+func (r *RegionsCapabilitiesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"CITY",
+		"COUNTRY",
+		"BLOCK STORAGE",
+		"OBJECT STORAGE",
+		"BARE METAL",
+		"GPU",
+		"KUBERNETES",
+		"LOAD BALANCERS",
+		"DDOS PROTECTION",
+	}}
+}
+
+// Data provides the capabilities data for the printer
+func (r *RegionsCapabilitiesPrinter) Data() [][]string {
+	data := [][]string{}
+
+	if len(r.Regions) == 0 {
+		data = append(data, []string{"---", "---", "---", "---", "---", "---", "---", "---", "---", "---"})
+		return data
+	}
+
+	for i := range r.Regions {
+		data = append(data, []string{
+			r.Regions[i].ID,
+			r.Regions[i].City,
+			r.Regions[i].Country,
+			printer.ArrayOfStringsToString(r.Regions[i].BlockStorage),
+			printer.ArrayOfStringsToString(r.Regions[i].ObjectStorage),
+			strconv.FormatBool(r.Regions[i].BareMetal),
+			strconv.FormatBool(r.Regions[i].GPU),
+			strconv.FormatBool(r.Regions[i].Kubernetes),
+			strconv.FormatBool(r.Regions[i].LoadBalancers),
+			strconv.FormatBool(r.Regions[i].DDoSProtection),
+		})
+	}
+
+	return data
+}
+
+// Paging validates and forms the paging data for output
+func (r *RegionsCapabilitiesPrinter) Paging() [][]string {
+	return nil
+}
@@ -2,7 +2,6 @@
 package regions
 
 import (
-	"context"
 	"errors"
 	"fmt"
 
@@ -42,6 +41,19 @@ var (
 	# Shortened with alias commands
 	vultr-cli r a ewr
 	`
+
+	capabilitiesLong = `Show which products are available in each region: block storage types, object
+storage tiers, bare metal, GPU plans, Kubernetes, load balancers and DDoS protection.`
+	capabilitiesExample = `
+	# Full example
+	vultr-cli regions capabilities
+
+	# As JSON for use in scripts
+	vultr-cli regions capabilities --output json
+
+	# Shortened with alias commands
+	vultr-cli r caps
+	`
 )
 
 // NewCmdRegion creates a cobra command for Regions
@@ -69,7 +81,7 @@ func NewCmdRegion(base *cli.Base) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			regions, meta, err := o.list()
+			regions, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving region list : %v", err)
 			}
@@ -90,6 +102,7 @@ func NewCmdRegion(base *cli.Base) *cobra.Command {
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	availability := &cobra.Command{
 		Use:     "availability <Region ID>",
@@ -123,9 +136,29 @@ func NewCmdRegion(base *cli.Base) *cobra.Command {
 'vc2', 'vdc, 'vhf', 'vbm'. Defaults to all Instances plans.`,
 	)
 
+	capabilities := &cobra.Command{
+		Use:     "capabilities",
+		Short:   "Show the products available per region",
+		Aliases: []string{"caps", "c"},
+		Long:    capabilitiesLong,
+		Example: capabilitiesExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			caps, err := o.capabilities()
+			if err != nil {
+				return err
+			}
+
+			data := &RegionsCapabilitiesPrinter{Regions: caps}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
 	cmd.AddCommand(
 		list,
 		availability,
+		capabilities,
 	)
 
 	return cmd
@@ -137,11 +170,11 @@ type options struct {
 }
 
 func (o *options) list() ([]govultr.Region, *govultr.Meta, error) {
-	list, meta, _, err := o.Base.Client.Region.List(context.Background(), o.Base.Options)
+	list, meta, _, err := o.Base.Client.Region.List(o.Base.Context, o.Base.Options)
 	return list, meta, err
 }
 
 func (o *options) availability() (*govultr.PlanAvailability, error) {
-	avail, _, err := o.Base.Client.Region.Availability(context.Background(), o.Base.Args[0], o.PlanType)
+	avail, _, err := o.Base.Client.Region.Availability(o.Base.Context, o.Base.Args[0], o.PlanType)
 	return avail, err
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -69,15 +70,30 @@ var (
 	vultr-cli rip d 6a31648d-ebfa-4d43-9a00-9c9f0e5048f5
 	`
 
-	convertLong    = `Convert an instance IP to a reserved IP on your Vultr account`
+	convertLong = `Convert an instance IP to a reserved IP on your Vultr account
+
+Use --instance instead of --ip to convert the main IP of an instance.`
 	convertExample = `
 	# Full example
 	vultr-cli reserved-ip convert --ip="192.0.2.123" --label="new label converted"
 
+	# Convert the main IP of an instance
+	vultr-cli reserved-ip convert --instance="2b9bf5fb-1644-4e0a-b706-1116ab64d783" --label="web"
+
 	# Shortened with alias commands
 	vultr-cli rip v -i="192.0.2.123" -l="new label converted"
 	`
 
+	moveLong = `Move a reserved IP to another instance in one step.  The reserved IP is
+detached from its current instance, if any, and attached to the new one.
+
+Use --wait to block until the API reports the reserved IP on the new instance.`
+	moveExample = `
+	# Full example
+	vultr-cli reserved-ip move 6a31648d-ebfa-4d43-9a00-9c9f0e5048f5 \
+		--to-instance="2b9bf5fb-1644-4e0a-b706-1116ab64d783" --wait
+	`
+
 	updateLong    = `Update a reserved IP on your Vultr account`
 	updateExample = `
 	# Full example
@@ -123,7 +139,7 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			ips, meta, err := o.list()
+			ips, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving reserved IP list : %v", err)
 			}
@@ -145,6 +161,7 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -218,6 +235,7 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		fmt.Printf("error marking reserved-ip create 'region' flag required: %v", err)
 		os.Exit(1)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
 	create.Flags().StringP("type", "t", "", "type of IP : v4 or v6")
 	if err := create.MarkFlagRequired("type"); err != nil {
 		fmt.Printf("error marking reserved-ip create 'type' flag required: %v", err)
@@ -345,6 +363,19 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'label' for reserved-ip convert : %v", errLa)
 			}
 
+			instanceID, errIn := cmd.Flags().GetString("instance")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'instance' for reserved-ip convert : %v", errIn)
+			}
+
+			if instanceID != "" {
+				ins, _, err := o.Base.Client.Instance.Get(o.Base.Context, instanceID)
+				if err != nil {
+					return fmt.Errorf("error retrieving instance : %v", err)
+				}
+				ip = ins.MainIP
+			}
+
 			o.ConvertReq = &govultr.ReservedIPConvertReq{
 				IPAddress: ip,
 				Label:     label,
@@ -363,11 +394,53 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	}
 
 	convert.Flags().StringP("ip", "i", "", "ip you wish to convert")
-	if err := convert.MarkFlagRequired("ip"); err != nil {
-		fmt.Printf("error marking reserved-ip convert 'ip' flag required: %v", err)
+	convert.Flags().String("instance", "", "id of the instance whose main ip you wish to convert")
+	convert.MarkFlagsOneRequired("ip", "instance")
+	convert.MarkFlagsMutuallyExclusive("ip", "instance")
+	convert.Flags().StringP("label", "l", "", "label")
+
+	// Move
+	move := &cobra.Command{
+		Use:     "move <Reserved IP ID>",
+		Short:   "Move a reserved IP to another instance",
+		Aliases: []string{"m"},
+		Long:    moveLong,
+		Example: moveExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a reserved IP ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceID, errIn := cmd.Flags().GetString("to-instance")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'to-instance' for reserved-ip move : %v", errIn)
+			}
+
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
+			rip, err := Move(o.Base, args[0], instanceID, wait, timeout)
+			if err != nil {
+				return fmt.Errorf("error moving reserved IP : %v", err)
+			}
+
+			data := &ReservedIPPrinter{IP: rip}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	move.Flags().StringP("to-instance", "i", "", "id of the instance you want to move the reserved IP to")
+	if err := move.MarkFlagRequired("to-instance"); err != nil {
+		fmt.Printf("error marking reserved-ip move 'to-instance' flag required: %v", err)
 		os.Exit(1)
 	}
-	convert.Flags().StringP("label", "l", "", "label")
+	utils.AddWaitFlags(move, "the reserved IP is attached to the instance")
 
 	// Delete
 	del := &cobra.Command{
@@ -401,12 +474,16 @@ func NewCmdReservedIP(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		attach,
 		detach,
 		convert,
+		move,
 		del,
 	)
 
 	return cmd
 }
 
+// moveInterval is the delay between polls while moving a reserved IP
+const moveInterval = 2 * time.Second
+
 type options struct {
 	Base       *cli.Base
 	CreateReq  *govultr.ReservedIPReq
@@ -448,6 +525,56 @@ func (o *options) convert() (*govultr.ReservedIP, error) {
 	return ip, err
 }
 
+// Move detaches the reserved IP from its instance, if any, and attaches it to
+// the instance.  With wait it returns once the API reports the reserved IP on
+// the instance
+func Move(base *cli.Base, id, instanceID string, wait bool, timeout time.Duration) (*govultr.ReservedIP, error) {
+	rip, _, err := base.Client.ReservedIP.Get(base.Context, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rip.InstanceID == instanceID {
+		return rip, nil
+	}
+
+	if rip.InstanceID != "" {
+		if err := base.Client.ReservedIP.Detach(base.Context, id); err != nil {
+			return nil, fmt.Errorf("error detaching reserved IP : %v", err)
+		}
+
+		// the attach is refused while the detach is still in progress
+		if err := waitInstance(base, id, "", timeout); err != nil {
+			return nil, fmt.Errorf("error waiting for reserved IP to detach : %v", err)
+		}
+	}
+
+	if err := base.Client.ReservedIP.Attach(base.Context, id, instanceID); err != nil {
+		return nil, fmt.Errorf("error attaching reserved IP : %v", err)
+	}
+
+	if wait {
+		if err := waitInstance(base, id, instanceID, timeout); err != nil {
+			return nil, fmt.Errorf("error waiting for reserved IP to attach : %v", err)
+		}
+	}
+
+	rip, _, err = base.Client.ReservedIP.Get(base.Context, id)
+	return rip, err
+}
+
+// waitInstance polls the reserved IP until it reports the instance ID.  The
+// interval is short since a move is usually a failover.
+func waitInstance(base *cli.Base, id, instanceID string, timeout time.Duration) error {
+	return cli.Poll(base.Context, moveInterval, timeout, func() (bool, error) {
+		rip, _, err := base.Client.ReservedIP.Get(base.Context, id)
+		if err != nil {
+			return false, err
+		}
+		return rip.InstanceID == instanceID, nil
+	})
+}
+
 func (o *options) del() error { //nolint:unused
 	return o.Base.Client.ReservedIP.Delete(o.Base.Context, o.Base.Args[0])
 }
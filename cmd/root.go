@@ -3,37 +3,54 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/vultr/vultr-cli/v3/cmd/account"
+	"github.com/vultr/vultr-cli/v3/cmd/api"
 	"github.com/vultr/vultr-cli/v3/cmd/applications"
+	"github.com/vultr/vultr-cli/v3/cmd/audit"
 	"github.com/vultr/vultr-cli/v3/cmd/backups"
 	"github.com/vultr/vultr-cli/v3/cmd/baremetal"
+	"github.com/vultr/vultr-cli/v3/cmd/batch"
 	"github.com/vultr/vultr-cli/v3/cmd/billing"
 	"github.com/vultr/vultr-cli/v3/cmd/blockstorage"
 	"github.com/vultr/vultr-cli/v3/cmd/cdn"
+	"github.com/vultr/vultr-cli/v3/cmd/cleanup"
+	"github.com/vultr/vultr-cli/v3/cmd/config"
 	"github.com/vultr/vultr-cli/v3/cmd/containerregistry"
+	"github.com/vultr/vultr-cli/v3/cmd/dashboard"
 	"github.com/vultr/vultr-cli/v3/cmd/database"
+	"github.com/vultr/vultr-cli/v3/cmd/deploy"
 	"github.com/vultr/vultr-cli/v3/cmd/dns"
+	"github.com/vultr/vultr-cli/v3/cmd/export"
 	"github.com/vultr/vultr-cli/v3/cmd/firewall"
 	"github.com/vultr/vultr-cli/v3/cmd/inference"
 	"github.com/vultr/vultr-cli/v3/cmd/instance"
 	"github.com/vultr/vultr-cli/v3/cmd/iso"
 	"github.com/vultr/vultr-cli/v3/cmd/kubernetes"
 	"github.com/vultr/vultr-cli/v3/cmd/loadbalancer"
+	"github.com/vultr/vultr-cli/v3/cmd/manifest"
 	"github.com/vultr/vultr-cli/v3/cmd/marketplace"
+	"github.com/vultr/vultr-cli/v3/cmd/network"
 	"github.com/vultr/vultr-cli/v3/cmd/objectstorage"
 	"github.com/vultr/vultr-cli/v3/cmd/operatingsystems"
 	"github.com/vultr/vultr-cli/v3/cmd/plans"
+	"github.com/vultr/vultr-cli/v3/cmd/plugin"
 	"github.com/vultr/vultr-cli/v3/cmd/regions"
 	"github.com/vultr/vultr-cli/v3/cmd/reservedip"
+	"github.com/vultr/vultr-cli/v3/cmd/schedule"
 	"github.com/vultr/vultr-cli/v3/cmd/script"
+	"github.com/vultr/vultr-cli/v3/cmd/selfupdate"
 	"github.com/vultr/vultr-cli/v3/cmd/snapshot"
 	"github.com/vultr/vultr-cli/v3/cmd/sshkeys"
+	"github.com/vultr/vultr-cli/v3/cmd/subaccount"
 	"github.com/vultr/vultr-cli/v3/cmd/users"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/cmd/version"
 	"github.com/vultr/vultr-cli/v3/cmd/vpc"
 	"github.com/vultr/vultr-cli/v3/cmd/vpc2"
@@ -48,6 +65,7 @@ const (
 var (
 	cfgFile string
 	output  string
+	base    *cli.Base
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -56,13 +74,67 @@ var rootCmd = &cobra.Command{
 	Short:        "vultr-cli is a command line interface for the Vultr API",
 	Long:         ``,
 	SilenceUsage: true,
+	// errors are printed by Execute along with their exit code
+	SilenceErrors: true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	args := os.Args[1:]
+	if !ownWatch(args) {
+		rest, interval, ok, err := watchArgs(args)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if ok {
+			if err := watch(rest, interval); err != nil {
+				exitWithError(err)
+			}
+			return
+		}
+	}
+
+	// unknown commands may be plugins on the PATH
+	if path, rest, ok := plugin.Lookup(rootCmd, args); ok {
+		utils.SetOptions(base, rootCmd, nil)
+		code, err := plugin.Run(base.Context, path, rest, plugin.Env(base))
+		if err != nil {
+			exitWithError(err)
+		}
+		os.Exit(code)
+	}
+
+	base.CancelOnInterrupt()
+	trackRun(rootCmd)
+	start := time.Now()
+	err := rootCmd.Execute()
+
+	// on a dry run the command fails at the first change it would make,
+	// which is the expected outcome
+	if changes := base.DryRunChanges(); len(changes) > 0 {
+		printDryRunSummary(changes)
+	} else if err != nil {
+		exitWithError(err)
+	}
+
+	base.Logger().Info("command finished",
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		slog.Int("exit_code", 0),
+	)
+}
+
+// printDryRunSummary lists the changes which were not sent on a dry run
+func printDryRunSummary(changes []string) {
+	noun := "changes were"
+	if len(changes) == 1 {
+		noun = "change was"
+	}
+
+	fmt.Fprintf(os.Stderr, "Dry run, %d %s not sent:\n", len(changes), noun)
+	for _, c := range changes {
+		fmt.Fprintf(os.Stderr, "  %s\n", c)
 	}
 }
 
@@ -77,12 +149,94 @@ func init() {
 		fmt.Printf("error binding root pflag 'config': %v\n", err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "output format [ text | json | yaml ]")
+	rootCmd.PersistentFlags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"text",
+		"output format [ text | json | yaml | csv | go-template='{{...}}' ]",
+	)
 	if err := viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")); err != nil {
 		fmt.Printf("error binding root pflag 'output': %v\n", err)
 	}
 
-	base := cli.NewCLIBase(
+	rootCmd.PersistentFlags().String(
+		"query",
+		"",
+		"JMESPath query applied to the output, for example \"[?region=='ewr'].main_ip\"",
+	)
+	if err := viper.BindPFlag("query", rootCmd.PersistentFlags().Lookup("query")); err != nil {
+		fmt.Printf("error binding root pflag 'query': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().String("profile", "", "config file profile to use, also read from VULTR_PROFILE")
+	if err := viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		fmt.Printf("error binding root pflag 'profile': %v\n", err)
+	}
+	if err := viper.BindEnv("profile", "VULTR_PROFILE"); err != nil {
+		fmt.Printf("error binding env 'VULTR_PROFILE': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Int("retries", cli.DefaultRetries, "times a rate limited or failed request is retried")
+	if err := viper.BindPFlag("retries", rootCmd.PersistentFlags().Lookup("retries")); err != nil {
+		fmt.Printf("error binding root pflag 'retries': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Duration("retry-max-wait", cli.DefaultRetryMaxWait, "longest wait between retries")
+	if err := viper.BindPFlag("retry-max-wait", rootCmd.PersistentFlags().Lookup("retry-max-wait")); err != nil {
+		fmt.Printf("error binding root pflag 'retry-max-wait': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Duration(
+		"cache-ttl",
+		cli.DefaultCacheTTL,
+		"how long region, plan, OS and app lists are cached",
+	)
+	if err := viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl")); err != nil {
+		fmt.Printf("error binding root pflag 'cache-ttl': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("no-cache", false, "fetch region, plan, OS and app lists instead of using the cache")
+	if err := viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache")); err != nil {
+		fmt.Printf("error binding root pflag 'no-cache': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("debug", false, "log the API requests and responses to stderr")
+	if err := viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug")); err != nil {
+		fmt.Printf("error binding root pflag 'debug': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("debug-body", false, "log the API requests and responses with headers and payloads")
+	if err := viper.BindPFlag("debug-body", rootCmd.PersistentFlags().Lookup("debug-body")); err != nil {
+		fmt.Printf("error binding root pflag 'debug-body': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("dry-run", false, "print the API call of a change instead of making it")
+	if err := viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run")); err != nil {
+		fmt.Printf("error binding root pflag 'dry-run': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("exact-id", false, "treat arguments as IDs instead of resolving labels and names")
+	if err := viper.BindPFlag("exact-id", rootCmd.PersistentFlags().Lookup("exact-id")); err != nil {
+		fmt.Printf("error binding root pflag 'exact-id': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("quiet", false, "hide the API deprecation and newer version warnings")
+	if err := viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet")); err != nil {
+		fmt.Printf("error binding root pflag 'quiet': %v\n", err)
+	}
+
+	addTableFlags()
+	addTransportFlags()
+	addLogFlags()
+
+	// --watch is handled by Execute before the arguments are parsed, unless
+	// the command has a watch flag of its own, the flag is only defined for
+	// the help
+	rootCmd.PersistentFlags().String("watch", "", "re-run a list or get command every interval, 2s by default")
+	rootCmd.PersistentFlags().Lookup("watch").NoOptDefVal = defaultWatchInterval
+
+	base = cli.NewCLIBase(
 		os.Getenv("VULTR_API_KEY"),
 		userAgent,
 		output,
@@ -90,30 +244,45 @@ func init() {
 
 	rootCmd.AddCommand(
 		account.NewCmdAccount(base),
+		api.NewCmdAPI(base),
 		applications.NewCmdApplications(base),
+		manifest.NewCmdApply(base),
+		audit.NewCmdAudit(base),
 		backups.NewCmdBackups(base),
 		baremetal.NewCmdBareMetal(base),
+		batch.NewCmdBatch(base),
 		billing.NewCmdBilling(base),
 		blockstorage.NewCmdBlockStorage(base),
 		containerregistry.NewCmdContainerRegistry(base),
 		cdn.NewCmdCDN(base),
+		cleanup.NewCmdCleanup(base),
+		config.NewCmdConfig(base),
+		dashboard.NewCmdDashboard(base),
 		database.NewCmdDatabase(base),
+		deploy.NewCmdDeploy(base),
+		manifest.NewCmdDiff(base),
 		dns.NewCmdDNS(base),
+		export.NewCmdExport(base),
 		firewall.NewCmdFirewall(base),
 		inference.NewCmdInference(base),
 		iso.NewCmdISO(base),
 		kubernetes.NewCmdKubernetes(base),
 		loadbalancer.NewCmdLoadBalancer(base),
 		marketplace.NewCmdMarketplace(base),
+		network.NewCmdNetwork(base),
 		operatingsystems.NewCmdOS(base),
 		objectstorage.NewCmdObjectStorage(base),
 		plans.NewCmdPlan(base),
+		plugin.NewCmdPlugin(base),
 		regions.NewCmdRegion(base),
 		reservedip.NewCmdReservedIP(base),
+		schedule.NewCmdSchedule(base),
 		script.NewCmdScript(base),
 		instance.NewCmdInstance(base),
 		snapshot.NewCmdSnapshot(base),
 		sshkeys.NewCmdSSHKey(base),
+		subaccount.NewCmdSubAccount(base),
+		selfupdate.NewCmdUpdate(base),
 		users.NewCmdUser(base),
 		version.NewCmdVersion(base),
 		vpc.NewCmdVPC(base),
@@ -181,3 +350,103 @@ func configHome() string {
 
 	return configFile
 }
+
+// addTableFlags adds the flags changing how tables are printed
+func addTableFlags() {
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "comma separated columns of the table to print, in order")
+	if err := viper.BindPFlag("columns", rootCmd.PersistentFlags().Lookup("columns")); err != nil {
+		fmt.Printf("error binding root pflag 'columns': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().String("sort-by", "", "column to sort the table on, prefix it with - to sort descending")
+	if err := viper.BindPFlag("sort-by", rootCmd.PersistentFlags().Lookup("sort-by")); err != nil {
+		fmt.Printf("error binding root pflag 'sort-by': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("no-trunc", false, "print long table cells in full on a terminal")
+	if err := viper.BindPFlag("no-trunc", rootCmd.PersistentFlags().Lookup("no-trunc")); err != nil {
+		fmt.Printf("error binding root pflag 'no-trunc': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("no-color", false, "print statuses without color, also set by NO_COLOR")
+	if err := viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color")); err != nil {
+		fmt.Printf("error binding root pflag 'no-color': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("short", false, "print only the IDs, one per line, for pipelines such as xargs")
+	if err := viper.BindPFlag("short", rootCmd.PersistentFlags().Lookup("short")); err != nil {
+		fmt.Printf("error binding root pflag 'short': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("wide", false, "print extra columns, for commands which have them")
+	if err := viper.BindPFlag("wide", rootCmd.PersistentFlags().Lookup("wide")); err != nil {
+		fmt.Printf("error binding root pflag 'wide': %v\n", err)
+	}
+}
+
+// addTransportFlags adds the flags changing how the API is connected to
+func addTransportFlags() {
+	rootCmd.PersistentFlags().String(
+		"api-endpoint",
+		"",
+		"API server to use instead of https://api.vultr.com, also read from VULTR_API_ENDPOINT",
+	)
+	if err := viper.BindPFlag("api-endpoint", rootCmd.PersistentFlags().Lookup("api-endpoint")); err != nil {
+		fmt.Printf("error binding root pflag 'api-endpoint': %v\n", err)
+	}
+	if err := viper.BindEnv("api-endpoint", "VULTR_API_ENDPOINT"); err != nil {
+		fmt.Printf("error binding env 'VULTR_API_ENDPOINT': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().String("proxy", "", "HTTP, HTTPS or SOCKS5 proxy URL, also read from VULTR_PROXY")
+	if err := viper.BindPFlag("proxy", rootCmd.PersistentFlags().Lookup("proxy")); err != nil {
+		fmt.Printf("error binding root pflag 'proxy': %v\n", err)
+	}
+	if err := viper.BindEnv("proxy", "VULTR_PROXY"); err != nil {
+		fmt.Printf("error binding env 'VULTR_PROXY': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().String("cacert", "", "PEM file of additional CA certificates, also read from VULTR_CACERT")
+	if err := viper.BindPFlag("cacert", rootCmd.PersistentFlags().Lookup("cacert")); err != nil {
+		fmt.Printf("error binding root pflag 'cacert': %v\n", err)
+	}
+	if err := viper.BindEnv("cacert", "VULTR_CACERT"); err != nil {
+		fmt.Printf("error binding env 'VULTR_CACERT': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().Bool("insecure-skip-verify", false, "don't verify the API certificate, for testing only")
+	insecure := rootCmd.PersistentFlags().Lookup("insecure-skip-verify")
+	if err := viper.BindPFlag("insecure-skip-verify", insecure); err != nil {
+		fmt.Printf("error binding root pflag 'insecure-skip-verify': %v\n", err)
+	}
+	if err := viper.BindEnv("insecure-skip-verify", "VULTR_INSECURE_SKIP_VERIFY"); err != nil {
+		fmt.Printf("error binding env 'VULTR_INSECURE_SKIP_VERIFY': %v\n", err)
+	}
+}
+
+// addLogFlags adds the flags writing structured logs of the command
+func addLogFlags() {
+	rootCmd.PersistentFlags().String(
+		"log-file",
+		"",
+		"append JSON logs of the command and its API requests to the file, - for stderr",
+	)
+	if err := viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file")); err != nil {
+		fmt.Printf("error binding root pflag 'log-file': %v\n", err)
+	}
+	if err := viper.BindEnv("log-file", "VULTR_LOG_FILE"); err != nil {
+		fmt.Printf("error binding env 'VULTR_LOG_FILE': %v\n", err)
+	}
+
+	rootCmd.PersistentFlags().String(
+		"log-level",
+		cli.DefaultLogLevel,
+		"lowest level logged [ debug | info | warn | error ]",
+	)
+	if err := viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level")); err != nil {
+		fmt.Printf("error binding root pflag 'log-level': %v\n", err)
+	}
+	if err := viper.BindEnv("log-level", "VULTR_LOG_LEVEL"); err != nil {
+		fmt.Printf("error binding env 'VULTR_LOG_LEVEL': %v\n", err)
+	}
+}
@@ -0,0 +1,138 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// SchedulesPrinter ...
+type SchedulesPrinter struct {
+	Schedules []schedule `json:"schedules"`
+}
+
+// JSON ...
+func (s *SchedulesPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SchedulesPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SchedulesPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"COMMAND",
+		"AT",
+		"REPEAT",
+		"NEXT RUN",
+		"LAST RUN",
+		"LAST STATUS",
+	}}
+}
+
+// Data ...
+func (s *SchedulesPrinter) Data() [][]string {
+	if len(s.Schedules) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Schedules {
+		lastRun := "---"
+		if s.Schedules[i].LastRun != nil {
+			lastRun = s.Schedules[i].LastRun.Local().Format(time.DateTime)
+		}
+
+		status := s.Schedules[i].LastStatus
+		if s.Schedules[i].LastError != "" {
+			status = fmt.Sprintf("%s: %s", status, s.Schedules[i].LastError)
+		}
+
+		data = append(data, []string{
+			s.Schedules[i].ID,
+			s.Schedules[i].Command,
+			s.Schedules[i].At,
+			s.Schedules[i].repeat(),
+			s.Schedules[i].Next.Local().Format(time.DateTime),
+			lastRun,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *SchedulesPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// ScheduleRunPrinter ...
+type ScheduleRunPrinter struct {
+	Results []runResult `json:"results"`
+}
+
+// JSON ...
+func (s *ScheduleRunPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *ScheduleRunPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *ScheduleRunPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"COMMAND",
+		"SCHEDULED",
+		"STATUS",
+		"EXIT CODE",
+		"DURATION",
+		"ERROR",
+	}}
+}
+
+// Data ...
+func (s *ScheduleRunPrinter) Data() [][]string {
+	var data [][]string
+	for i := range s.Results {
+		data = append(data, []string{
+			s.Results[i].ID,
+			s.Results[i].Command,
+			s.Results[i].Scheduled.Local().Format(time.DateTime),
+			s.Results[i].Status,
+			strconv.Itoa(s.Results[i].ExitCode),
+			s.Results[i].Duration,
+			s.Results[i].Error,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *ScheduleRunPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of commands which failed
+func (s *ScheduleRunPrinter) failed() int {
+	n := 0
+	for i := range s.Results {
+		if s.Results[i].Status == statusFailed {
+			n++
+		}
+	}
+	return n
+}
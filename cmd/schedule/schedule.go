@@ -0,0 +1,424 @@
+// Package schedule provides the functionality for running CLI commands at set
+// times from a local schedule
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// defaultGrace is how late a schedule may run before it is skipped
+const defaultGrace = time.Hour
+
+var (
+	scheduleLong = `Run vultr-cli commands at a time of day, such as stopping development instances
+every night, without any other tooling.
+
+Schedules are kept in ~/.vultr-cli/schedules.json and run by 'schedule run', which
+runs the commands which are due and is meant to be started every minute by cron or
+a systemd timer.  Times are in the local time zone of the machine.
+`
+	scheduleExample = `
+	# Stop an instance every night and start it on weekday mornings
+	vultr-cli schedule add "instance stop 704ac064-4ff2-49ca-a6e6-88262cca8f8a" --at 22:00 --daily
+	vultr-cli schedule add "instance start 704ac064-4ff2-49ca-a6e6-88262cca8f8a" --at 08:00 --days mon-fri
+
+	# crontab entry
+	* * * * * vultr-cli schedule run
+	`
+	addLong = `Add a command to the schedule.  The command is given as a single quoted
+argument, or as the arguments after --, without the leading vultr-cli.  Without
+--daily or --days the command runs once, the next time the clock reaches --at.
+`
+	addExample = `
+	# Stop an instance every night
+	vultr-cli schedule add "instance stop 704ac064-4ff2-49ca-a6e6-88262cca8f8a" --at 22:00 --daily
+
+	# Start it on weekday mornings
+	vultr-cli schedule add --at 08:00 --days mon,tue,wed,thu,fri -- instance start 704ac064-4ff2-49ca-a6e6-88262cca8f8a
+
+	# Take a snapshot once tonight
+	vultr-cli schedule add "snapshot create -i 704ac064-4ff2-49ca-a6e6-88262cca8f8a" --at 23:30
+	`
+	runLong = `Run the scheduled commands which are due, one after another, and move each
+schedule on to its next run.  Nothing is printed when no schedule is due, so the
+command can be started every minute by cron or a systemd timer without noise.
+
+A schedule which is more than --grace late, such as when the machine was asleep,
+is skipped and reported as missed rather than run at the wrong time.
+`
+	runExample = `
+	# crontab entry
+	* * * * * vultr-cli schedule run >> ~/.vultr-cli/schedule.log 2>&1
+
+	# systemd timer, with a matching vultr-cli-schedule.service running
+	# 'vultr-cli schedule run'
+	[Timer]
+	OnCalendar=minutely
+	`
+)
+
+// NewCmdSchedule provides the CLI command for scheduled commands
+func NewCmdSchedule(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "schedule",
+		Short:   "Run commands at set times",
+		Long:    scheduleLong,
+		Example: scheduleExample,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.SetOptions(o.Base, cmd, args)
+		},
+	}
+
+	// Add
+	add := &cobra.Command{
+		Use:     "add <Command>",
+		Short:   "Add a command to the schedule",
+		Aliases: []string{"create"},
+		Long:    addLong,
+		Example: addExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide the command to schedule")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			at, errAt := cmd.Flags().GetString("at")
+			if errAt != nil {
+				return fmt.Errorf("error parsing flag 'at' for schedule add : %v", errAt)
+			}
+
+			daily, errDa := cmd.Flags().GetBool("daily")
+			if errDa != nil {
+				return fmt.Errorf("error parsing flag 'daily' for schedule add : %v", errDa)
+			}
+
+			days, errDy := cmd.Flags().GetStringSlice("days")
+			if errDy != nil {
+				return fmt.Errorf("error parsing flag 'days' for schedule add : %v", errDy)
+			}
+
+			if daily {
+				days = weekdays
+			}
+
+			s, err := o.add(cmd, args, at, days)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&SchedulesPrinter{Schedules: []schedule{*s}}, nil)
+
+			return nil
+		},
+	}
+
+	add.Flags().String("at", "", "time of day to run the command, as HH:MM in the local time zone")
+	if err := add.MarkFlagRequired("at"); err != nil {
+		fmt.Printf("error marking schedule add 'at' flag required: %v", err)
+		os.Exit(1)
+	}
+	add.Flags().Bool("daily", false, "(optional) run the command every day")
+	add.Flags().StringSlice(
+		"days",
+		[]string{},
+		"(optional) days of the week to run the command on, such as mon,wed,fri or mon-fri",
+	)
+	add.MarkFlagsMutuallyExclusive("daily", "days")
+
+	// List
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List the scheduled commands",
+		Aliases: []string{"l"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schedules, err := loadSchedules()
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&SchedulesPrinter{Schedules: schedules}, nil)
+
+			return nil
+		},
+	}
+
+	// Delete
+	del := &cobra.Command{
+		Use:     "delete <Schedule ID>",
+		Short:   "Remove a command from the schedule",
+		Aliases: []string{"destroy", "d"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a schedule ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.delete(args[0]); err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(printer.Info("schedule has been deleted"), nil)
+
+			return nil
+		},
+	}
+
+	// Run
+	run := &cobra.Command{
+		Use:     "run",
+		Short:   "Run the scheduled commands which are due",
+		Long:    runLong,
+		Example: runExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grace, errGr := cmd.Flags().GetDuration("grace")
+			if errGr != nil {
+				return fmt.Errorf("error parsing flag 'grace' for schedule run : %v", errGr)
+			}
+
+			results, err := o.run(grace)
+			if err != nil {
+				return err
+			}
+
+			if len(results) == 0 {
+				return nil
+			}
+
+			data := &ScheduleRunPrinter{Results: results}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d scheduled commands failed", failed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	run.Flags().Duration("grace", defaultGrace, "(optional) how late a schedule may run before it is skipped")
+
+	cmd.AddCommand(
+		add,
+		list,
+		del,
+		run,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// runResult is the outcome of a scheduled command which was due
+type runResult struct {
+	ID        string    `json:"id"`
+	Command   string    `json:"command"`
+	Scheduled time.Time `json:"scheduled"`
+	Status    string    `json:"status"`
+	ExitCode  int       `json:"exit_code"`
+	Duration  string    `json:"duration,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// add validates the command and saves it to the schedule
+func (o *options) add(cmd *cobra.Command, args []string, at string, days []string) (*schedule, error) {
+	text := strings.Join(args, " ")
+	if len(args) == 1 {
+		var err error
+		if args, err = utils.SplitArgs(args[0]); err != nil {
+			return nil, fmt.Errorf("unable to parse command : %v", err)
+		}
+	}
+
+	if len(args) > 0 && args[0] == "vultr-cli" {
+		args = args[1:]
+		text = strings.TrimSpace(strings.TrimPrefix(text, "vultr-cli"))
+	}
+
+	if len(args) > 0 && args[0] == "schedule" {
+		return nil, errors.New("schedule can't run itself")
+	}
+
+	target, _, err := cmd.Root().Find(args)
+	if err != nil || target == cmd.Root() || !target.Runnable() {
+		return nil, fmt.Errorf("unknown command %q", text)
+	}
+
+	days, err = parseDays(days)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := nextRun(time.Now(), at, days)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newScheduleID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := schedule{ID: id, Command: text, Args: args, At: at, Days: days, Next: next, LastStatus: statusPending}
+	err = updateSchedules(func(schedules []schedule) ([]schedule, error) {
+		return append(schedules, s), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// delete removes the schedule with the ID
+func (o *options) delete(id string) error {
+	return updateSchedules(func(schedules []schedule) ([]schedule, error) {
+		i := slices.IndexFunc(schedules, func(s schedule) bool { return s.ID == id })
+		if i < 0 {
+			return nil, fmt.Errorf("schedule %s not found", id)
+		}
+
+		return slices.Delete(schedules, i, i+1), nil
+	})
+}
+
+// run runs the due schedules.  Each schedule is moved on to its next run and
+// saved under the lock of the schedules before its command starts, so a run
+// started by cron while another one is still going doesn't run the same
+// command twice.  Schedules which only run once are removed
+func (o *options) run(grace time.Duration) ([]runResult, error) {
+	now := time.Now()
+	var due []schedule
+	err := updateSchedules(func(schedules []schedule) ([]schedule, error) {
+		var err error
+		due, schedules, err = takeDue(schedules, now)
+		return schedules, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error finding the vultr-cli executable : %v", err)
+	}
+
+	env := os.Environ()
+	if key := o.Base.APIKey(); key != "" {
+		env = append(env, "VULTR_API_KEY="+key)
+	}
+	if profile := viper.GetString("profile"); profile != "" {
+		env = append(env, "VULTR_PROFILE="+profile)
+	}
+
+	results := make([]runResult, len(due))
+	for i := range due {
+		results[i] = runResult{ID: due[i].ID, Command: due[i].Command, Scheduled: due[i].Next, Status: statusMissed}
+		if now.Sub(due[i].Next) > grace || o.Base.Context.Err() != nil {
+			continue
+		}
+
+		runCommand(o.Base.Context, exe, env, due[i].Args, &results[i])
+	}
+
+	return results, recordResults(results)
+}
+
+// takeDue splits off the schedules which are due at the time, returning the
+// rest along with those of the due schedules which run again, moved on to
+// their next run
+func takeDue(schedules []schedule, now time.Time) (due, kept []schedule, err error) {
+	for i := range schedules {
+		if schedules[i].Next.After(now) {
+			kept = append(kept, schedules[i])
+			continue
+		}
+
+		due = append(due, schedules[i])
+		if len(schedules[i].Days) == 0 {
+			continue
+		}
+
+		if schedules[i].Next, err = nextRun(now, schedules[i].At, schedules[i].Days); err != nil {
+			return nil, nil, err
+		}
+		kept = append(kept, schedules[i])
+	}
+
+	return due, kept, nil
+}
+
+// runCommand runs the scheduled command as a separate vultr-cli process
+func runCommand(ctx context.Context, exe string, env, args []string, r *runResult) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Env = env
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	r.Duration = time.Since(start).Round(time.Millisecond).String()
+	r.Status = statusOK
+
+	if err != nil {
+		r.Status = statusFailed
+		r.ExitCode = 1
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			r.ExitCode = exitErr.ExitCode()
+		}
+
+		r.Error = strings.TrimPrefix(strings.TrimSpace(stderr.String()), "Error: ")
+		if r.Error == "" {
+			r.Error = err.Error()
+		}
+	}
+}
+
+// recordResults saves the outcome of the last run of the schedules which
+// run again.  Missed runs keep the time of the last run which did happen
+func recordResults(results []runResult) error {
+	return updateSchedules(func(schedules []schedule) ([]schedule, error) {
+		for i := range results {
+			j := slices.IndexFunc(schedules, func(s schedule) bool { return s.ID == results[i].ID })
+			if j < 0 {
+				continue
+			}
+
+			if results[i].Status != statusMissed {
+				ran := results[i].Scheduled
+				schedules[j].LastRun = &ran
+			}
+			schedules[j].LastStatus = results[i].Status
+			schedules[j].LastError = results[i].Error
+		}
+
+		return schedules, nil
+	})
+}
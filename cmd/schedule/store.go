@@ -0,0 +1,272 @@
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	scheduleIDBytes = 4
+
+	schedulesDirPermission  = 0o700
+	schedulesFilePermission = 0o600
+
+	// lockWait is how long to wait for another vultr-cli to release the lock
+	// of the schedules, which it only holds while loading and saving them
+	lockWait  = 10 * time.Second
+	lockRetry = 50 * time.Millisecond
+	// lockStale is the age at which a lock is taken to be left over from a
+	// vultr-cli which was killed while holding it
+	lockStale = time.Minute
+
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusMissed  = "missed"
+	statusPending = "pending"
+)
+
+// weekdays are the day names accepted by --days, in the order of time.Weekday
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// schedule is a command run by schedule run at a time of day, once or on
+// some days of the week
+type schedule struct {
+	ID         string     `json:"id"`
+	Command    string     `json:"command"`
+	Args       []string   `json:"args"`
+	At         string     `json:"at"`
+	Days       []string   `json:"days,omitempty"`
+	Next       time.Time  `json:"next"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	LastStatus string     `json:"last_status,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+}
+
+// repeat describes when the schedule runs again
+func (s *schedule) repeat() string {
+	switch len(s.Days) {
+	case 0:
+		return "once"
+	case len(weekdays):
+		return "daily"
+	default:
+		return strings.Join(s.Days, ",")
+	}
+}
+
+// parseAt validates a time of day given as HH:MM
+func parseAt(at string) (int, int, error) {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q, must be HH:MM such as 22:00", at)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// parseDays validates the day names and ranges such as mon-fri and returns
+// the days in week order
+func parseDays(days []string) ([]string, error) {
+	var parsed []string
+	for i := range days {
+		from, to, isRange := strings.Cut(days[i], "-")
+		if !isRange {
+			to = from
+		}
+
+		start, errFr := dayIndex(from)
+		end, errTo := dayIndex(to)
+		if errFr != nil || errTo != nil || start > end {
+			return nil, fmt.Errorf("invalid day %q, must be one of %s or a range such as mon-fri",
+				days[i], strings.Join(weekdays, ", "))
+		}
+
+		for _, day := range weekdays[start : end+1] {
+			if !slices.Contains(parsed, day) {
+				parsed = append(parsed, day)
+			}
+		}
+	}
+
+	slices.SortFunc(parsed, func(a, b string) int {
+		return slices.Index(weekdays, a) - slices.Index(weekdays, b)
+	})
+
+	return parsed, nil
+}
+
+// dayIndex returns the position in the week of a day name such as mon or
+// monday
+func dayIndex(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i := range weekdays {
+		if strings.HasPrefix(name, weekdays[i]) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q", name)
+}
+
+// nextRun returns the first time after the given time which is at the time
+// of day on one of the days, or on any day when no days are given
+func nextRun(after time.Time, at string, days []string) (time.Time, error) {
+	hour, minute, err := parseAt(at)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	after = after.In(time.Local)
+	for i := 0; i <= len(weekdays); i++ {
+		next := time.Date(after.Year(), after.Month(), after.Day()+i, hour, minute, 0, 0, time.Local)
+		if !next.After(after) {
+			continue
+		}
+
+		if len(days) == 0 || slices.Contains(days, weekdays[next.Weekday()]) {
+			return next, nil
+		}
+	}
+
+	return time.Time{}, errors.New("no day to run the schedule on")
+}
+
+// newScheduleID returns a random ID for a new schedule
+func newScheduleID() (string, error) {
+	id := make([]byte, scheduleIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("error creating schedule ID : %v", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// schedulesPath returns the file the schedules are kept in
+func schedulesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the home directory : %v", err)
+	}
+	return filepath.Join(home, ".vultr-cli", "schedules.json"), nil
+}
+
+// loadSchedules reads the schedules, which are empty when none were added yet
+func loadSchedules() ([]schedule, error) {
+	path, err := schedulesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading schedules : %v", err)
+	}
+
+	var schedules []schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("error reading schedules %s : %v", path, err)
+	}
+
+	return schedules, nil
+}
+
+// updateSchedules loads the schedules, changes them with update and saves
+// them, holding the lock of the schedules throughout so commands changing
+// them side by side, such as overlapping runs, don't undo or repeat each
+// other's changes
+func updateSchedules(update func([]schedule) ([]schedule, error)) error {
+	unlock, err := lockSchedules()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	schedules, err := loadSchedules()
+	if err != nil {
+		return err
+	}
+
+	schedules, err = update(schedules)
+	if err != nil {
+		return err
+	}
+
+	return saveSchedules(schedules)
+}
+
+// lockSchedules takes the lock of the schedules, a file next to them which
+// only one vultr-cli can create, and returns the function releasing it
+func lockSchedules() (func(), error) {
+	path, err := schedulesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), schedulesDirPermission); err != nil {
+		return nil, fmt.Errorf("error locking schedules : %v", err)
+	}
+
+	lock := path + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(filepath.Clean(lock), os.O_CREATE|os.O_EXCL|os.O_WRONLY, schedulesFilePermission)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("error locking schedules : %v", err)
+		}
+
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(lock)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("schedules are locked by another vultr-cli, remove %s if none is running", lock)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// saveSchedules writes the schedules through a temporary file so an
+// interrupted write doesn't lose them
+func saveSchedules(schedules []schedule) error {
+	path, err := schedulesPath()
+	if err != nil {
+		return err
+	}
+
+	if schedules == nil {
+		schedules = []schedule{}
+	}
+
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error saving schedules : %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), schedulesDirPermission); err != nil {
+		return fmt.Errorf("error saving schedules : %v", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, schedulesFilePermission); err != nil {
+		return fmt.Errorf("error saving schedules : %v", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error saving schedules : %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const defaultEditor = "vi"
+
+// errNoChanges is returned when the editor is closed without changing the script
+var errNoChanges = errors.New("startup script was not changed")
+
+// edit opens the decoded script in the local editor and uploads it again
+// once the editor exits with changes
+func (o *options) edit() error {
+	script, err := o.get()
+	if err != nil {
+		return fmt.Errorf("error getting startup script : %v", err)
+	}
+
+	contents, err := decodeScript(script.Script)
+	if err != nil {
+		return err
+	}
+
+	edited, err := runEditor(o.Base.Context, contents)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(edited, contents) {
+		return errNoChanges
+	}
+
+	o.ScriptReq = &govultr.StartupScriptReq{Script: base64.StdEncoding.EncodeToString(edited)}
+	return o.update()
+}
+
+// runEditor writes the contents to a temporary file, opens it with $VISUAL
+// or $EDITOR and returns the saved contents
+func runEditor(ctx context.Context, contents []byte) ([]byte, error) {
+	f, err := os.CreateTemp("", "vultr-script-*.sh")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+
+	// the editor may carry its own arguments such as "code --wait"
+	args := append(strings.Fields(editor), f.Name())
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running editor %s : %v", args[0], err)
+	}
+
+	return os.ReadFile(f.Name())
+}
+
+// decodeScript returns the plain contents of a script as returned by the API
+func decodeScript(script string) ([]byte, error) {
+	contents, err := base64.StdEncoding.DecodeString(script)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding startup script : %v", err)
+	}
+	return contents, nil
+}
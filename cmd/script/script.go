@@ -2,6 +2,7 @@
 package script
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
@@ -37,7 +38,7 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			scripts, meta, err := o.list()
+			scripts, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving startup script list : %v", err)
 			}
@@ -59,6 +60,7 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -103,6 +105,19 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'type' for script create : %v", errST)
 			}
 
+			file, errFi := cmd.Flags().GetString("from-file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'from-file' for script create : %v", errFi)
+			}
+
+			if file != "" {
+				contents, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("error reading script file : %v", err)
+				}
+				script = base64.StdEncoding.EncodeToString(contents)
+			}
+
 			o.ScriptReq = &govultr.StartupScriptReq{
 				Name:   name,
 				Script: script,
@@ -123,6 +138,7 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 
 	create.Flags().StringP("name", "n", "", "Name of the newly created startup script.")
 	create.Flags().StringP("script", "s", "", "Startup script contents.")
+	create.Flags().StringP("from-file", "f", "", "Path to a local file with the startup script contents.")
 	create.Flags().StringP(
 		"type",
 		"t",
@@ -135,10 +151,8 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		os.Exit(1)
 	}
 
-	if err := create.MarkFlagRequired("script"); err != nil {
-		fmt.Printf("error marking script create 'script' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.MarkFlagsOneRequired("script", "from-file")
+	create.MarkFlagsMutuallyExclusive("script", "from-file")
 
 	// Update
 	update := &cobra.Command{
@@ -206,6 +220,34 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	// Edit
+	edit := &cobra.Command{
+		Use:   "edit <Script ID>",
+		Short: "Edit a startup script in your local editor",
+		Long: `Download a startup script, open it in $VISUAL or $EDITOR and upload it again once
+the editor exits. The script is left untouched when it was not changed.`,
+		Aliases: []string{"e"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a script ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.edit(); err != nil {
+				if errors.Is(err, errNoChanges) {
+					o.Base.Printer.Display(printer.Info("startup script was not changed"), nil)
+					return nil
+				}
+				return fmt.Errorf("error editing startup script : %v", err)
+			}
+
+			o.Base.Printer.Display(printer.Info("startup script has been updated"), nil)
+
+			return nil
+		},
+	}
+
 	// Delete
 	del := &cobra.Command{
 		Use:     "delete <Script ID>",
@@ -233,9 +275,12 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		get,
 		create,
 		update,
+		edit,
 		del,
 	)
 
+	utils.RegisterCompletion(cmd, "<Script ID>", utils.CompleteScripts(o.Base))
+
 	return cmd
 }
 
@@ -0,0 +1,48 @@
+package selfupdate
+
+import (
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// UpdatePrinter ...
+type UpdatePrinter struct {
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+	URL     string `json:"url"`
+	Status  string `json:"status"`
+}
+
+// JSON ...
+func (u *UpdatePrinter) JSON() []byte {
+	return printer.MarshalObject(u, "json")
+}
+
+// YAML ...
+func (u *UpdatePrinter) YAML() []byte {
+	return printer.MarshalObject(u, "yaml")
+}
+
+// Columns ...
+func (u *UpdatePrinter) Columns() [][]string {
+	return [][]string{0: {
+		"CURRENT",
+		"LATEST",
+		"STATUS",
+		"RELEASE NOTES",
+	}}
+}
+
+// Data ...
+func (u *UpdatePrinter) Data() [][]string {
+	return [][]string{0: {
+		u.Current,
+		u.Latest,
+		u.Status,
+		u.URL,
+	}}
+}
+
+// Paging ...
+func (u *UpdatePrinter) Paging() [][]string {
+	return nil
+}
@@ -0,0 +1,234 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	// maxDownloadSize caps the size of a release archive and of the binary in
+	// it
+	maxDownloadSize = 200 << 20
+
+	binaryName = "vultr-cli"
+)
+
+// releasesURL is the GitHub API endpoint of the vultr-cli releases
+var releasesURL = "https://api.github.com/repos/vultr/vultr-cli/releases"
+
+// release is a GitHub release
+type release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []asset `json:"assets"`
+}
+
+// asset is a file attached to a GitHub release
+type asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// fetch downloads the URL, at most maxDownloadSize bytes of it
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// a token raises the GitHub API rate limit
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && strings.HasPrefix(url, releasesURL) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", url, maxDownloadSize)
+	}
+
+	return data, nil
+}
+
+// getRelease returns the release with the tag, or the latest release when
+// the tag is empty
+func getRelease(ctx context.Context, client *http.Client, tag string) (*release, error) {
+	url := releasesURL + "/latest"
+	if tag != "" {
+		url = releasesURL + "/tags/" + tag
+	}
+
+	data, err := fetch(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &release{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("error parsing release : %v", err)
+	}
+
+	return r, nil
+}
+
+// find returns the asset with the name
+func (r *release) find(name string) (*asset, error) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %s has no file %s", r.TagName, name)
+}
+
+// archiveName returns the name of the release archive for this OS and
+// architecture, following the names in .goreleaser.yml
+func archiveName(version string) string {
+	goos := runtime.GOOS
+	if goos == "darwin" {
+		goos = "macOs"
+	}
+
+	arch := runtime.GOARCH
+	if arch == "arm" {
+		arch = "arm32-v" + goarm()
+	}
+
+	ext := "tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = "zip"
+	}
+
+	return fmt.Sprintf("%s_v%s_%s_%s.%s", binaryName, strings.TrimPrefix(version, "v"), goos, arch, ext)
+}
+
+// goarm returns the ARM version the binary was built for
+func goarm() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "GOARM" && s.Value != "" {
+				return strings.TrimSuffix(s.Value, ",softfloat")
+			}
+		}
+	}
+
+	return "7"
+}
+
+// verifyChecksum checks the archive against its entry in the checksums file
+func verifyChecksum(checksums []byte, name string, archive []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+
+		sum := sha256.Sum256(archive)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s : expected sha256 %s, got %s", name, fields[0], got)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no checksum for %s in the release", name)
+}
+
+// extractBinary returns the vultr-cli binary in the archive
+func extractBinary(name string, archive []byte) ([]byte, error) {
+	if strings.HasSuffix(name, ".zip") {
+		return extractZip(archive)
+	}
+	return extractTarGz(archive)
+}
+
+func extractTarGz(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == binaryName {
+			return readBinary(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("no %s binary in the archive", binaryName)
+}
+
+func extractZip(archive []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range zr.File {
+		if path.Base(f.Name) != binaryName+".exe" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close() //nolint:errcheck
+
+		return readBinary(rc)
+	}
+
+	return nil, fmt.Errorf("no %s.exe binary in the archive", binaryName)
+}
+
+// readBinary reads the binary, refusing one larger than maxDownloadSize
+func readBinary(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("binary is larger than %d bytes", maxDownloadSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,194 @@
+// Package selfupdate provides the functionality for updating the CLI to the
+// latest release
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/cmd/version"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	statusUpToDate  = "up to date"
+	statusAvailable = "update available"
+	statusUpdated   = "updated"
+)
+
+var (
+	updateLong = `Update vultr-cli to the latest GitHub release.
+
+The release archive for this OS and architecture is downloaded, checked against the SHA256
+checksums published with the release and the binary replaces the one running.  Use --check to only
+report whether a newer release exists.
+
+Set GITHUB_TOKEN to raise the GitHub API rate limit.  Installs managed by a package manager, such as
+Homebrew, should be updated with it instead.`
+	updateExample = `
+	# Update to the latest release
+	vultr-cli update
+
+	# Only check for a newer release
+	vultr-cli update --check
+
+	# Install a given release, including an older one
+	vultr-cli update --version v3.3.0
+	`
+)
+
+// NewCmdUpdate provides the CLI command for updating the CLI
+func NewCmdUpdate(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update vultr-cli to the latest release",
+		Long:    updateLong,
+		Example: updateExample,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.SetOptions(o.Base, cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			check, errCh := cmd.Flags().GetBool("check")
+			if errCh != nil {
+				return fmt.Errorf("error parsing flag 'check' for update : %v", errCh)
+			}
+
+			tag, errVe := cmd.Flags().GetString("version")
+			if errVe != nil {
+				return fmt.Errorf("error parsing flag 'version' for update : %v", errVe)
+			}
+
+			// downloads go through the same proxy as the API requests
+			client := &http.Client{Transport: o.Base.Transport()}
+
+			r, err := getRelease(o.Base.Context, client, tag)
+			if err != nil {
+				return fmt.Errorf("error retrieving release : %v", err)
+			}
+
+			data := &UpdatePrinter{
+				Current: version.Version,
+				Latest:  r.TagName,
+				URL:     r.HTMLURL,
+				Status:  statusUpToDate,
+			}
+
+			if r.TagName == version.Version || (tag == "" && !cli.NewerVersion(r.TagName, version.Version)) {
+				o.Base.Printer.Display(data, nil)
+				return nil
+			}
+
+			data.Status = statusAvailable
+			if check {
+				o.Base.Printer.Display(data, nil)
+				return nil
+			}
+
+			if err := install(o.Base.Context, client, r); err != nil {
+				return fmt.Errorf("error updating to %s : %v", r.TagName, err)
+			}
+
+			data.Status = statusUpdated
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("check", false, "(optional) only report whether a newer release exists")
+	cmd.Flags().String("version", "", "(optional) release tag to install instead of the latest, such as v3.3.0")
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// install downloads the release for this platform, verifies its checksum and
+// replaces the running binary with it
+func install(ctx context.Context, client *http.Client, r *release) error {
+	name := archiveName(r.TagName)
+	archiveAsset, err := r.find(name)
+	if err != nil {
+		return err
+	}
+
+	checksumsAsset, err := r.find(fmt.Sprintf("%s_v%s_checksums.txt", binaryName, strings.TrimPrefix(r.TagName, "v")))
+	if err != nil {
+		return err
+	}
+
+	checksums, err := fetch(ctx, client, checksumsAsset.URL)
+	if err != nil {
+		return fmt.Errorf("error downloading checksums : %v", err)
+	}
+
+	archive, err := fetch(ctx, client, archiveAsset.URL)
+	if err != nil {
+		return fmt.Errorf("error downloading %s : %v", name, err)
+	}
+
+	if err := verifyChecksum(checksums, name, archive); err != nil {
+		return err
+	}
+
+	binary, err := extractBinary(name, archive)
+	if err != nil {
+		return fmt.Errorf("error extracting %s : %v", name, err)
+	}
+
+	return replaceExecutable(binary)
+}
+
+// replaceExecutable writes the binary next to the running executable and
+// renames it over it, so the executable is never left half written
+func replaceExecutable(binary []byte) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the running executable : %v", err)
+	}
+
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("unable to resolve the running executable : %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".vultr-cli-update-*")
+	if err != nil {
+		return fmt.Errorf("unable to write next to %s, it may need to be updated as root : %v", exe, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil { //nolint:gosec,mnd
+		return err
+	}
+
+	// a running executable can't be replaced on Windows but it can be renamed
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		os.Remove(old) //nolint:errcheck,gosec
+		if err := os.Rename(exe, old); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmp.Name(), exe)
+}
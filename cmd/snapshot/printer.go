@@ -1,6 +1,7 @@
 package snapshot
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -114,3 +115,77 @@ func (s *SnapshotPrinter) Data() [][]string {
 func (s *SnapshotPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// pruneResult is a snapshot selected for deletion by prune
+type pruneResult struct {
+	Snapshot govultr.Snapshot `json:"snapshot"`
+	Status   string           `json:"status"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// SnapshotPrunePrinter ...
+type SnapshotPrunePrinter struct {
+	Results []pruneResult `json:"results"`
+}
+
+// JSON ...
+func (s *SnapshotPrunePrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SnapshotPrunePrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SnapshotPrunePrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"DATE CREATED",
+		"DESCRIPTION",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (s *SnapshotPrunePrinter) Data() [][]string {
+	if len(s.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Results {
+		status := s.Results[i].Status
+		if s.Results[i].Error != "" {
+			status = fmt.Sprintf("%s: %s", status, s.Results[i].Error)
+		}
+
+		data = append(data, []string{
+			s.Results[i].Snapshot.ID,
+			s.Results[i].Snapshot.DateCreated,
+			s.Results[i].Snapshot.Description,
+			status,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *SnapshotPrunePrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of snapshots which could not be deleted
+func (s *SnapshotPrunePrinter) failed() int {
+	n := 0
+	for i := range s.Results {
+		if s.Results[i].Status == "failed" {
+			n++
+		}
+	}
+	return n
+}
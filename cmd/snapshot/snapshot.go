@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -14,7 +17,7 @@ import (
 )
 
 // NewCmdSnapshot provides the CLI command for snapshot functions
-func NewCmdSnapshot(base *cli.Base) *cobra.Command {
+func NewCmdSnapshot(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
 
 	cmd := &cobra.Command{
@@ -37,7 +40,7 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			snaps, meta, err := o.list()
+			snaps, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving snapshot list : %v", err)
 			}
@@ -49,6 +52,8 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 		},
 	}
 
+	utils.AddListAllFlag(list)
+
 	// Get
 	get := &cobra.Command{
 		Use:   "get <Snapshot ID>",
@@ -76,6 +81,13 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 	create := &cobra.Command{
 		Use:   "create",
 		Short: "Create a snapshot",
+		Long: `Create a snapshot of an instance.
+
+Use --wait to follow the snapshot, printing its status until it is complete.`,
+		Example: `
+	# Full example
+	vultr-cli snapshot create --id="cb676a46-66fd-4dfb-b839-443f2e6c0b60" --description="before upgrade" --wait
+	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, errID := cmd.Flags().GetString("id")
 			if errID != nil {
@@ -87,6 +99,11 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 				return fmt.Errorf("error parsing flag 'description' for create : %v", errDe)
 			}
 
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
 			o.Req = &govultr.SnapshotReq{
 				InstanceID:  id,
 				Description: desc,
@@ -97,6 +114,12 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 				return fmt.Errorf("error creating snapshot : %v", err)
 			}
 
+			if wait {
+				if snapshot, err = o.waitComplete(snapshot.ID, timeout); err != nil {
+					return err
+				}
+			}
+
 			data := &SnapshotPrinter{Snapshot: snapshot}
 			o.Base.Printer.Display(data, nil)
 
@@ -111,19 +134,40 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 	}
 
 	create.Flags().StringP("description", "d", "", "(optional) Description of snapshot contents")
+	utils.AddWaitFlags(create, "the snapshot is complete")
 
 	// Create URL
 	createURL := &cobra.Command{
-		Use:   "create-url",
-		Short: "Create a snapshot from a URL",
+		Use:     "create-url",
+		Short:   "Create a snapshot from a URL",
+		Aliases: []string{"create-from-url"},
+		Long: `Create a snapshot from a raw or qcow2 image at a remote URL.
+
+Use --wait to follow the import, printing the status and size of the snapshot
+until it is complete.`,
+		Example: `
+	# Full example
+	vultr-cli snapshot create-url --url="https://example.com/image.qcow2" --description="base image" --wait
+	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			url, errUR := cmd.Flags().GetString("url")
 			if errUR != nil {
 				return fmt.Errorf("error parsing flag 'url' for createURL : %v", errUR)
 			}
 
+			desc, errDe := cmd.Flags().GetString("description")
+			if errDe != nil {
+				return fmt.Errorf("error parsing flag 'description' for createURL : %v", errDe)
+			}
+
+			wait, timeout, errWa := utils.GetWaitFlags(cmd)
+			if errWa != nil {
+				return errWa
+			}
+
 			o.URLReq = &govultr.SnapshotURLReq{
-				URL: url,
+				URL:         url,
+				Description: desc,
 			}
 
 			snapshot, err := o.createURL()
@@ -131,6 +175,12 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 				return fmt.Errorf("error creating snapshot from URL : %v", err)
 			}
 
+			if wait {
+				if snapshot, err = o.waitComplete(snapshot.ID, timeout); err != nil {
+					return err
+				}
+			}
+
 			data := &SnapshotPrinter{Snapshot: snapshot}
 			o.Base.Printer.Display(data, nil)
 
@@ -144,6 +194,9 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 		os.Exit(1)
 	}
 
+	createURL.Flags().StringP("description", "d", "", "(optional) Description of snapshot contents")
+	utils.AddWaitFlags(createURL, "the snapshot is complete")
+
 	// Delete
 	del := &cobra.Command{
 		Use:     "delete <Snapshot ID>",
@@ -166,17 +219,173 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command {
 		},
 	}
 
+	// Prune
+	prune := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old snapshots",
+		Long: `Delete snapshots by age and count to enforce a retention policy.
+
+Snapshots are matched on the start of their description with --label-prefix.
+The newest --keep-last matching snapshots are always kept and of the rest only
+those older than --older-than are deleted.  At least one of --keep-last and
+--older-than is required.  Use --dry-run to list the snapshots which would be
+deleted without deleting them.`,
+		Example: `
+	# Keep the 5 newest nightly snapshots and delete the others older than 30 days
+	vultr-cli snapshot prune --label-prefix nightly- --keep-last 5 --older-than 30d
+
+	# Show what would be deleted
+	vultr-cli snapshot prune --label-prefix nightly- --keep-last 5 --dry-run
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keep, errKe := cmd.Flags().GetInt("keep-last")
+			if errKe != nil {
+				return fmt.Errorf("error parsing flag 'keep-last' for prune : %v", errKe)
+			}
+
+			olderThan, errOl := cmd.Flags().GetString("older-than")
+			if errOl != nil {
+				return fmt.Errorf("error parsing flag 'older-than' for prune : %v", errOl)
+			}
+
+			prefix, errPr := cmd.Flags().GetString("label-prefix")
+			if errPr != nil {
+				return fmt.Errorf("error parsing flag 'label-prefix' for prune : %v", errPr)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing flag 'dry-run' for prune : %v", errDr)
+			}
+
+			if keep < 0 {
+				return errors.New("keep-last cannot be negative")
+			}
+
+			var age time.Duration
+			if olderThan != "" {
+				var err error
+				if age, err = utils.ParseAge(olderThan); err != nil {
+					return err
+				}
+			}
+
+			results, err := o.prune(prefix, keep, age, dryRun)
+			if err != nil {
+				return fmt.Errorf("error pruning snapshots : %v", err)
+			}
+
+			data := &SnapshotPrunePrinter{Results: results}
+			o.Base.Printer.Display(data, nil)
+
+			if failed := data.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d snapshots failed to delete", failed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	prune.Flags().Int("keep-last", 0, "number of the newest matching snapshots to always keep")
+	prune.Flags().String("older-than", "", "only delete snapshots older than this age, such as 30d, 2w or 12h")
+	prune.MarkFlagsOneRequired("keep-last", "older-than")
+	prune.Flags().String("label-prefix", "", "(optional) only prune snapshots whose description starts with this")
+	prune.Flags().Bool("dry-run", false, "(optional) list the snapshots which would be deleted without deleting them")
+
+	// Copy
+	cp := &cobra.Command{
+		Use:   "copy <Snapshot ID>",
+		Short: "Copy a snapshot through an instance in another region",
+		Long: `Make an independent copy of a snapshot taken in another region.
+
+Snapshots can be deployed in any region so a copy is only needed to keep a
+separate snapshot, for example before deleting the original.  The API cannot
+copy snapshots so an instance is created from the snapshot in the region, a new
+snapshot is taken from it and the instance is deleted again.  The instance is
+billed for the time it runs and its plan must fit the snapshot.`,
+		Example: `
+	# Full example
+	vultr-cli snapshot copy 2126b7d9-5e2a-491e-8840-838aa6b5f294 --region ams
+
+	# With a larger temporary instance for a bigger snapshot
+	vultr-cli snapshot copy 2126b7d9-5e2a-491e-8840-838aa6b5f294 --region ams --plan vc2-2c-4gb
+	`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a snapshot ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			region, errRe := cmd.Flags().GetString("region")
+			if errRe != nil {
+				return fmt.Errorf("error parsing flag 'region' for copy : %v", errRe)
+			}
+
+			plan, errPl := cmd.Flags().GetString("plan")
+			if errPl != nil {
+				return fmt.Errorf("error parsing flag 'plan' for copy : %v", errPl)
+			}
+
+			desc, errDe := cmd.Flags().GetString("description")
+			if errDe != nil {
+				return fmt.Errorf("error parsing flag 'description' for copy : %v", errDe)
+			}
+
+			timeout, errTi := utils.GetWaitTimeout(cmd)
+			if errTi != nil {
+				return errTi
+			}
+
+			snapshot, err := o.copy(region, plan, desc, timeout)
+			if err != nil {
+				return fmt.Errorf("error copying snapshot : %v", err)
+			}
+
+			data := &SnapshotPrinter{Snapshot: snapshot}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	cp.Flags().StringP("region", "r", "", "ID of the region to take the copy in")
+	if err := cp.MarkFlagRequired("region"); err != nil {
+		fmt.Printf("error marking snapshot copy 'region' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	cp.Flags().String("plan", copyPlan, "(optional) plan of the temporary instance")
+	cp.Flags().StringP(
+		"description",
+		"d",
+		"",
+		"(optional) Description of the copy. Defaults to the source description with (copy) appended",
+	)
+	utils.AddWaitTimeoutFlag(
+		cp,
+		cli.WaitTimeout,
+		"(optional) how long to wait for the temporary instance to start and for the copy to complete",
+	)
+
 	cmd.AddCommand(
 		list,
 		get,
 		create,
 		createURL,
 		del,
+		prune,
+		cp,
 	)
 
+	utils.RegisterCompletion(cmd, "<Snapshot ID>", utils.CompleteSnapshots(o.Base))
+
 	return cmd
 }
 
+// copyPlan is the default plan of the instance a snapshot copy is taken from
+const copyPlan = "vc2-1c-1gb"
+
 type options struct {
 	Base   *cli.Base
 	Req    *govultr.SnapshotReq
@@ -206,3 +415,147 @@ func (o *options) createURL() (*govultr.Snapshot, error) {
 func (o *options) del() error {
 	return o.Base.Client.Snapshot.Delete(o.Base.Context, o.Base.Args[0])
 }
+
+// waitComplete polls the snapshot until it is complete, reporting the status
+// and size whenever they change
+func (o *options) waitComplete(id string, timeout time.Duration) (*govultr.Snapshot, error) {
+	var snapshot *govultr.Snapshot
+	err := o.Base.Wait("snapshot "+id, timeout, func() (string, bool, error) {
+		var err error
+		if snapshot, _, err = o.Base.Client.Snapshot.Get(o.Base.Context, id); err != nil {
+			return "", false, err
+		}
+
+		status := fmt.Sprintf(
+			"%s, size %d, compressed size %d",
+			snapshot.Status,
+			snapshot.Size,
+			snapshot.CompressedSize,
+		)
+		return status, snapshot.Status == "complete", nil
+	})
+
+	return snapshot, err
+}
+
+// listAll retrieves every snapshot
+func (o *options) listAll() ([]govultr.Snapshot, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		snapshots, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return snapshots, meta, err
+	})
+}
+
+// prune deletes the snapshots matching the prefix which are not among the
+// newest keep and are older than age, when age is set
+func (o *options) prune(prefix string, keep int, age time.Duration, dryRun bool) ([]pruneResult, error) {
+	all, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []govultr.Snapshot
+	for i := range all {
+		if strings.HasPrefix(all[i].Description, prefix) {
+			matched = append(matched, all[i])
+		}
+	}
+
+	// the dates are RFC 3339 in UTC so they sort as strings
+	slices.SortFunc(matched, func(a, b govultr.Snapshot) int {
+		return strings.Compare(b.DateCreated, a.DateCreated)
+	})
+
+	if keep > len(matched) {
+		keep = len(matched)
+	}
+
+	cutoff := time.Now().Add(-age)
+
+	var results []pruneResult
+	for _, snapshot := range matched[keep:] {
+		if age > 0 {
+			created, err := time.Parse(time.RFC3339, snapshot.DateCreated)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse the creation date of snapshot %s : %v", snapshot.ID, err)
+			}
+
+			if created.After(cutoff) {
+				continue
+			}
+		}
+
+		result := pruneResult{Snapshot: snapshot, Status: "planned"}
+		if !dryRun {
+			result.Status = "deleted"
+			if err := o.Base.Client.Snapshot.Delete(o.Base.Context, snapshot.ID); err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// copy takes a new snapshot of the snapshot in the args through a temporary
+// instance in the region.  The instance is always deleted.  timeout applies
+// to the instance starting and to the new snapshot completing
+func (o *options) copy(region, plan, desc string, timeout time.Duration) (*govultr.Snapshot, error) {
+	src, err := o.get()
+	if err != nil {
+		return nil, err
+	}
+
+	if src.Status != "complete" {
+		return nil, fmt.Errorf("snapshot is %s, only complete snapshots can be copied", src.Status)
+	}
+
+	if desc == "" {
+		desc = strings.TrimSpace(src.Description + " (copy)")
+	}
+
+	ins, _, err := o.Base.Client.Instance.Create(o.Base.Context, &govultr.InstanceCreateReq{
+		Region:     region,
+		Plan:       plan,
+		SnapshotID: src.ID,
+		Label:      "snapshot-copy",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary instance : %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "created temporary instance %s in %s\n", ins.ID, region)
+
+	// the instance is deleted even when interrupted
+	cleanupCtx, cleanupDone := o.Base.Cleanup()
+	defer func() {
+		defer cleanupDone()
+		if err := o.Base.Client.Instance.Delete(cleanupCtx, ins.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "error deleting temporary instance %s : %v\n", ins.ID, err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "deleted temporary instance %s\n", ins.ID)
+	}()
+
+	if err := cli.Poll(o.Base.Context, cli.WaitInterval, timeout, func() (bool, error) {
+		ins, _, err = o.Base.Client.Instance.Get(o.Base.Context, ins.ID)
+		if err != nil {
+			return false, err
+		}
+		return ins.Status == "active" && ins.ServerStatus == "ok", nil
+	}); err != nil {
+		return nil, fmt.Errorf("error waiting for temporary instance : %v", err)
+	}
+
+	snapshot, _, err := o.Base.Client.Snapshot.Create(o.Base.Context, &govultr.SnapshotReq{
+		InstanceID:  ins.ID,
+		Description: desc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.waitComplete(snapshot.ID, timeout)
+}
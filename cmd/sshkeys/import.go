@@ -0,0 +1,193 @@
+package sshkeys
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	githubKeysURL = "https://github.com/%s.keys"
+	importTimeout = 30 * time.Second
+	maxKeysSize   = 1 << 20
+
+	importCreated = "created"
+	importExists  = "exists"
+	importFailed  = "failed"
+)
+
+// publicKey is a single authorized_keys line
+type publicKey struct {
+	Line        string
+	Comment     string
+	Fingerprint string
+}
+
+// importResult is the outcome of importing a single public key
+type importResult struct {
+	Fingerprint string `json:"fingerprint"`
+	Name        string `json:"name"`
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// importKeys creates the keys which are not yet in the account, matching
+// existing keys by fingerprint.  Keys without a comment are named after the
+// prefix and their position.
+func (o *options) importKeys(keys []publicKey, prefix string) ([]importResult, error) {
+	existing, err := o.listAll()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ssh key list : %v", err)
+	}
+
+	known := map[string]govultr.SSHKey{}
+	for i := range existing {
+		fp, err := fingerprint(existing[i].SSHKey)
+		if err != nil {
+			continue
+		}
+		known[fp] = existing[i]
+	}
+
+	var results []importResult
+	for i := range keys {
+		if k, ok := known[keys[i].Fingerprint]; ok {
+			results = append(results, importResult{
+				Fingerprint: keys[i].Fingerprint,
+				Name:        k.Name,
+				ID:          k.ID,
+				Status:      importExists,
+			})
+			continue
+		}
+
+		name := keys[i].Comment
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", prefix, i+1)
+		}
+
+		result := importResult{Fingerprint: keys[i].Fingerprint, Name: name}
+		k, _, err := o.Base.Client.SSHKey.Create(o.Base.Context, &govultr.SSHKeyReq{Name: name, SSHKey: keys[i].Line})
+		if err != nil {
+			result.Status = importFailed
+			result.Error = err.Error()
+		} else {
+			result.ID = k.ID
+			result.Status = importCreated
+			known[keys[i].Fingerprint] = *k
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// listAll returns every ssh key in the account
+func (o *options) listAll() ([]govultr.SSHKey, error) {
+	return utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.SSHKey, *govultr.Meta, error) {
+		keys, meta, _, err := o.Base.Client.SSHKey.List(o.Base.Context, opts)
+		return keys, meta, err
+	})
+}
+
+// githubKeys fetches the public keys published for a GitHub user
+func githubKeys(ctx context.Context, user string) ([]publicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	uri := fmt.Sprintf(githubKeysURL, url.PathEscape(user))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", uri, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxKeysSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAuthorizedKeys(body)
+}
+
+// agentKeys lists the public keys held by the local ssh agent
+func agentKeys(ctx context.Context) ([]publicKey, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ssh-add", "-L")
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String() + string(out)); msg != "" {
+			return nil, fmt.Errorf("%v : %s", err, msg)
+		}
+		return nil, err
+	}
+
+	return parseAuthorizedKeys(out)
+}
+
+// parseAuthorizedKeys reads public keys in authorized_keys format, skipping
+// blank lines and comments
+func parseAuthorizedKeys(data []byte) ([]publicKey, error) {
+	var keys []publicKey
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fp, err := fingerprint(line)
+		if err != nil {
+			return nil, err
+		}
+
+		key := publicKey{Line: line, Fingerprint: fp}
+		if fields := strings.Fields(line); len(fields) > 2 {
+			key.Comment = strings.Join(fields[2:], " ")
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, scanner.Err()
+}
+
+// fingerprint returns the SHA256 fingerprint of a public key the same way
+// ssh-keygen -l prints it
+func fingerprint(key string) (string, error) {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("invalid public key %q", key)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid public key %q : %v", fields[0], err)
+	}
+
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
@@ -1,6 +1,8 @@
 package sshkeys
 
 import (
+	"fmt"
+
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 )
@@ -89,3 +91,63 @@ func (s SSHKeyPrinter) Data() [][]string {
 func (s SSHKeyPrinter) Paging() [][]string {
 	return nil
 }
+
+// SSHKeyImportPrinter ...
+type SSHKeyImportPrinter struct {
+	Results []importResult `json:"ssh_keys"`
+}
+
+// JSON ...
+func (s *SSHKeyImportPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SSHKeyImportPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SSHKeyImportPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"NAME",
+		"FINGERPRINT",
+		"STATUS",
+	}}
+}
+
+// Data ...
+func (s *SSHKeyImportPrinter) Data() [][]string {
+	data := [][]string{}
+	for i := range s.Results {
+		status := s.Results[i].Status
+		if s.Results[i].Error != "" {
+			status = fmt.Sprintf("%s : %s", status, s.Results[i].Error)
+		}
+
+		data = append(data, []string{
+			s.Results[i].ID,
+			s.Results[i].Name,
+			s.Results[i].Fingerprint,
+			status,
+		})
+	}
+	return data
+}
+
+// Paging ...
+func (s *SSHKeyImportPrinter) Paging() [][]string {
+	return nil
+}
+
+// failed returns the number of keys which could not be imported
+func (s *SSHKeyImportPrinter) failed() int {
+	n := 0
+	for i := range s.Results {
+		if s.Results[i].Status == importFailed {
+			n++
+		}
+	}
+	return n
+}
@@ -2,7 +2,6 @@
 package sshkeys
 
 import (
-	"context"
 	"errors"
 	"fmt"
 
@@ -60,6 +59,19 @@ var (
 	vultr-cli ssh u ffd31f18-5f77-454c-9065-212f942c3c35 --name="updated name" --key="ssh-rsa AAAAB3NzaC1yc...."
 	`
 
+	importLong = `Import public SSH keys from a GitHub user or the local SSH agent into your Vultr account.
+Keys which are already in the account, matched by fingerprint, are skipped.`
+	importExample = `
+	# Import the keys published on a GitHub profile
+	vultr-cli ssh-key import --github octocat
+
+	# Import the keys loaded in the local ssh-agent
+	vultr-cli ssh-key import --from-agent
+
+	# Shortened with alias commands
+	vultr-cli ssh i -g octocat
+	`
+
 	deleteLong    = `Delete a specific SSH Key off your Vultr Account`
 	deleteExample = `
 	# Full example
@@ -98,7 +110,7 @@ func NewCmdSSHKey(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
-			list, meta, err := o.list()
+			list, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving ssh key list : %v", err)
 			}
@@ -120,6 +132,7 @@ func NewCmdSSHKey(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -253,13 +266,75 @@ func NewCmdSSHKey(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	// Import
+	imp := &cobra.Command{
+		Use:     "import",
+		Short:   "Import SSH keys from GitHub or the SSH agent",
+		Aliases: []string{"i"},
+		Long:    importLong,
+		Example: importExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			github, errGh := cmd.Flags().GetString("github")
+			if errGh != nil {
+				return fmt.Errorf("error parsing flag 'github' for ssh key import : %v", errGh)
+			}
+
+			fromAgent, errFa := cmd.Flags().GetBool("from-agent")
+			if errFa != nil {
+				return fmt.Errorf("error parsing flag 'from-agent' for ssh key import : %v", errFa)
+			}
+
+			var keys []publicKey
+			var prefix string
+			var err error
+			if fromAgent {
+				prefix = "agent"
+				keys, err = agentKeys(o.Base.Context)
+			} else {
+				prefix = "github-" + github
+				keys, err = githubKeys(o.Base.Context, github)
+			}
+			if err != nil {
+				return fmt.Errorf("error retrieving public keys : %v", err)
+			}
+
+			if len(keys) == 0 {
+				return errors.New("no public keys found to import")
+			}
+
+			results, err := o.importKeys(keys, prefix)
+			if err != nil {
+				return err
+			}
+
+			data := &SSHKeyImportPrinter{Results: results}
+			o.Base.Printer.Display(data, nil)
+
+			if n := data.failed(); n > 0 {
+				return fmt.Errorf("%d of %d ssh keys failed to import", n, len(results))
+			}
+
+			return nil
+		},
+	}
+	imp.Flags().StringP("github", "g", "", "GitHub username whose public keys are imported")
+	imp.Flags().BoolP("from-agent", "a", false, "import the public keys loaded in the local ssh-agent")
+	imp.MarkFlagsOneRequired("github", "from-agent")
+	imp.MarkFlagsMutuallyExclusive("github", "from-agent")
+
 	cmd.AddCommand(
 		create,
 		get,
 		list,
 		update,
 		del,
+		imp,
 	)
+
+	complete := utils.CompleteSSHKeys(o.Base)
+	utils.RegisterCompletion(cmd, "<SSH Key ID>", complete)
+	utils.RegisterCompletion(cmd, "<sshKeyID>", complete)
+
 	return cmd
 }
 
@@ -269,24 +344,24 @@ type options struct {
 }
 
 func (o *options) create() (*govultr.SSHKey, error) {
-	key, _, err := o.Base.Client.SSHKey.Create(context.Background(), o.SSHKeyReq)
+	key, _, err := o.Base.Client.SSHKey.Create(o.Base.Context, o.SSHKeyReq)
 	return key, err
 }
 
 func (o *options) get() (*govultr.SSHKey, error) {
-	key, _, err := o.Base.Client.SSHKey.Get(context.Background(), o.Base.Args[0])
+	key, _, err := o.Base.Client.SSHKey.Get(o.Base.Context, o.Base.Args[0])
 	return key, err
 }
 
 func (o *options) list() ([]govultr.SSHKey, *govultr.Meta, error) {
-	keys, meta, _, err := o.Base.Client.SSHKey.List(context.Background(), o.Base.Options)
+	keys, meta, _, err := o.Base.Client.SSHKey.List(o.Base.Context, o.Base.Options)
 	return keys, meta, err
 }
 
 func (o *options) update() error {
-	return o.Base.Client.SSHKey.Update(context.Background(), o.Base.Args[0], o.SSHKeyReq)
+	return o.Base.Client.SSHKey.Update(o.Base.Context, o.Base.Args[0], o.SSHKeyReq)
 }
 
 func (o *options) del() error {
-	return o.Base.Client.SSHKey.Delete(context.Background(), o.Base.Args[0])
+	return o.Base.Client.SSHKey.Delete(o.Base.Context, o.Base.Args[0])
 }
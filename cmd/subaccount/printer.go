@@ -0,0 +1,112 @@
+package subaccount
+
+import (
+	"strconv"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// SubAccountsPrinter ...
+type SubAccountsPrinter struct {
+	SubAccounts []govultr.SubAccount `json:"subaccounts"`
+	Meta        *govultr.Meta        `json:"meta"`
+}
+
+// JSON ...
+func (s *SubAccountsPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SubAccountsPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SubAccountsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"EMAIL",
+		"NAME",
+		"OTHER ID",
+		"ACTIVATED",
+		"BALANCE",
+		"PENDING CHARGES",
+	}}
+}
+
+// Data ...
+func (s *SubAccountsPrinter) Data() [][]string {
+	if len(s.SubAccounts) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.SubAccounts {
+		data = append(data, []string{
+			s.SubAccounts[i].ID,
+			s.SubAccounts[i].Email,
+			s.SubAccounts[i].Name,
+			s.SubAccounts[i].OtherID,
+			strconv.FormatBool(s.SubAccounts[i].Activated),
+			strconv.Itoa(s.SubAccounts[i].Balance),
+			strconv.Itoa(s.SubAccounts[i].PendingCharges),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *SubAccountsPrinter) Paging() [][]string {
+	return printer.NewPagingFromMeta(s.Meta).Compose()
+}
+
+// ======================================
+
+// SubAccountPrinter ...
+type SubAccountPrinter struct {
+	SubAccount *govultr.SubAccount `json:"subaccount"`
+}
+
+// JSON ...
+func (s *SubAccountPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SubAccountPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SubAccountPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"EMAIL",
+		"NAME",
+		"OTHER ID",
+		"ACTIVATED",
+		"BALANCE",
+		"PENDING CHARGES",
+	}}
+}
+
+// Data ...
+func (s *SubAccountPrinter) Data() [][]string {
+	return [][]string{0: {
+		s.SubAccount.ID,
+		s.SubAccount.Email,
+		s.SubAccount.Name,
+		s.SubAccount.OtherID,
+		strconv.FormatBool(s.SubAccount.Activated),
+		strconv.Itoa(s.SubAccount.Balance),
+		strconv.Itoa(s.SubAccount.PendingCharges),
+	}}
+}
+
+// Paging ...
+func (s *SubAccountPrinter) Paging() [][]string {
+	return nil
+}
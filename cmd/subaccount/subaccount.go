@@ -0,0 +1,218 @@
+// Package subaccount provides the functionality for the CLI to manage
+// sub-accounts
+package subaccount
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+var (
+	long    = `Get commands available to sub-accounts`
+	example = `
+	# Full example
+	vultr-cli subaccount
+	`
+	listLong    = `List all sub-accounts of your Vultr account`
+	listExample = `
+	# Full example
+	vultr-cli subaccount list
+
+	# Shortened with alias commands
+	vultr-cli sa l
+	`
+	getLong    = `Get a sub-account of your Vultr account based on its ID`
+	getExample = `
+	# Full example
+	vultr-cli subaccount get 1b58bc8a-b9fb-4b8c-a3b5-bf6ed6e74d2c
+
+	# Shortened with alias commands
+	vultr-cli sa g 1b58bc8a-b9fb-4b8c-a3b5-bf6ed6e74d2c
+	`
+	createLong    = `Create a sub-account on your Vultr account`
+	createExample = `
+	# Full example
+	vultr-cli subaccount create --email="client@example.com" --name="Example Client" --other-id="client-42"
+
+	# Shortened with alias commands
+	vultr-cli sa c -e="client@example.com" -n="Example Client"
+	`
+)
+
+// NewCmdSubAccount provides the CLI command for sub-account functions
+func NewCmdSubAccount(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "subaccount",
+		Short:   "Commands to manage sub-accounts",
+		Aliases: []string{"subaccounts", "sa"},
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+	}
+
+	// List
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List all sub-accounts",
+		Aliases: []string{"l"},
+		Long:    listLong,
+		Example: listExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.Base.Options = utils.GetPaging(cmd)
+
+			subs, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
+			if err != nil {
+				return fmt.Errorf("error retrieving sub-account list : %v", err)
+			}
+
+			data := &SubAccountsPrinter{SubAccounts: subs, Meta: meta}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	list.Flags().StringP("cursor", "c", "", "(optional) Cursor for paging.")
+	list.Flags().IntP(
+		"per-page",
+		"p",
+		utils.PerPageDefault,
+		fmt.Sprintf(
+			"(optional) Number of items requested per page. Default is %d and Max is 500.",
+			utils.PerPageDefault,
+		),
+	)
+	utils.AddListAllFlag(list)
+
+	// Get
+	get := &cobra.Command{
+		Use:     "get <Sub-Account ID>",
+		Short:   "Get a sub-account",
+		Aliases: []string{"g"},
+		Long:    getLong,
+		Example: getExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a sub-account ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sub, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving sub-account : %v", err)
+			}
+
+			data := &SubAccountPrinter{SubAccount: sub}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	// Create
+	create := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a sub-account",
+		Aliases: []string{"c"},
+		Long:    createLong,
+		Example: createExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email, errEm := cmd.Flags().GetString("email")
+			if errEm != nil {
+				return fmt.Errorf("error parsing flag 'email' for sub-account create : %v", errEm)
+			}
+
+			name, errNa := cmd.Flags().GetString("name")
+			if errNa != nil {
+				return fmt.Errorf("error parsing flag 'name' for sub-account create : %v", errNa)
+			}
+
+			otherID, errOt := cmd.Flags().GetString("other-id")
+			if errOt != nil {
+				return fmt.Errorf("error parsing flag 'other-id' for sub-account create : %v", errOt)
+			}
+
+			o.CreateReq = &govultr.SubAccountReq{
+				Email:   email,
+				Name:    name,
+				OtherID: otherID,
+			}
+
+			sub, err := o.create()
+			if err != nil {
+				return fmt.Errorf("error creating sub-account : %v", err)
+			}
+
+			data := &SubAccountPrinter{SubAccount: sub}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	create.Flags().StringP("email", "e", "", "email address of the sub-account")
+	if err := create.MarkFlagRequired("email"); err != nil {
+		fmt.Printf("error marking sub-account create 'email' flag required: %v", err)
+		os.Exit(1)
+	}
+	create.Flags().StringP("name", "n", "", "(optional) name of the sub-account")
+	create.Flags().String("other-id", "", "(optional) your own identifier for the sub-account")
+
+	cmd.AddCommand(
+		list,
+		get,
+		create,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base      *cli.Base
+	CreateReq *govultr.SubAccountReq
+}
+
+func (o *options) list() ([]govultr.SubAccount, *govultr.Meta, error) {
+	subs, meta, _, err := o.Base.Client.SubAccount.List(o.Base.Context, o.Base.Options)
+	return subs, meta, err
+}
+
+// get finds the sub-account in every page of the list since the API has no
+// endpoint for a single sub-account
+func (o *options) get() (*govultr.SubAccount, error) {
+	subs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.SubAccount, *govultr.Meta, error) {
+		subs, meta, _, err := o.Base.Client.SubAccount.List(o.Base.Context, opts)
+		return subs, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subs {
+		if subs[i].ID == o.Base.Args[0] {
+			return &subs[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("sub-account %s not found", o.Base.Args[0])
+}
+
+func (o *options) create() (*govultr.SubAccount, error) {
+	sub, _, err := o.Base.Client.SubAccount.Create(o.Base.Context, o.CreateReq)
+	return sub, err
+}
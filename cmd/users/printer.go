@@ -1,6 +1,7 @@
 package users
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -97,3 +98,55 @@ func (u *UserPrinter) Data() [][]string {
 func (u *UserPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// UserPermissionsPrinter ...
+type UserPermissionsPrinter struct {
+	User *govultr.User `json:"user"`
+}
+
+// JSON ...
+func (u *UserPermissionsPrinter) JSON() []byte {
+	return printer.MarshalObject(u, "json")
+}
+
+// YAML ...
+func (u *UserPermissionsPrinter) YAML() []byte {
+	return printer.MarshalObject(u, "yaml")
+}
+
+// Columns ...
+func (u *UserPermissionsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"PERMISSION",
+		"GRANTED",
+	}}
+}
+
+// Data ...
+func (u *UserPermissionsPrinter) Data() [][]string {
+	api := u.User.APIEnabled != nil && *u.User.APIEnabled
+	data := [][]string{{"api", strconv.FormatBool(api)}}
+
+	for i := range knownACLs {
+		data = append(data, []string{
+			knownACLs[i],
+			strconv.FormatBool(slices.Contains(u.User.ACL, knownACLs[i])),
+		})
+	}
+
+	// permissions the API returns which are not known to the CLI yet
+	for i := range u.User.ACL {
+		if !slices.Contains(knownACLs, u.User.ACL[i]) {
+			data = append(data, []string{u.User.ACL[i], "true"})
+		}
+	}
+
+	return data
+}
+
+// Paging ...
+func (u *UserPermissionsPrinter) Paging() [][]string {
+	return nil
+}
@@ -2,10 +2,11 @@
 package users
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -53,6 +54,15 @@ var (
 			--api-enabled="false" --acl="manage_users,billing"
 	`
 
+	permissionsLong    = `Show which access control permissions a sub user of your Vultr account has been granted`
+	permissionsExample = `
+		# Full example
+		vultr-cli users permissions 821fae4d-2a0f-4b0e-8ffd-2fe59d67d4b2
+
+		# Shortened with alias commands
+		vultr-cli u perms 821fae4d-2a0f-4b0e-8ffd-2fe59d67d4b2
+	`
+
 	deleteLong    = `Delete a sub user from your vultr account based on it's ID.'`
 	deleteExample = `
 		# Full example
@@ -63,6 +73,28 @@ var (
 	`
 )
 
+// knownACLs are the access control permissions which can be granted to users
+var knownACLs = []string{
+	"manage_users",
+	"subscriptions_view",
+	"subscriptions",
+	"billing",
+	"support",
+	"provisioning",
+	"dns",
+	"abuse",
+	"upgrade",
+	"firewall",
+	"alerts",
+	"objstore",
+	"loadbalancer",
+}
+
+var aclUsage = fmt.Sprintf(
+	"User access control list in a comma separated list. Possible values:\n%s",
+	strings.Join(knownACLs, " "),
+)
+
 // NewCmdUser provides the user command to the CLI
 func NewCmdUser(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
@@ -92,7 +124,7 @@ func NewCmdUser(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			user, meta, err := o.list()
+			user, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving user list : %v", err)
 			}
@@ -113,6 +145,7 @@ func NewCmdUser(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -173,6 +206,10 @@ func NewCmdUser(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'acl' for user create : %v", errAc)
 			}
 
+			if err := validateACL(acl); err != nil {
+				return err
+			}
+
 			o.CreateReq = &govultr.UserReq{
 				Email:    email,
 				Name:     name,
@@ -219,9 +256,7 @@ func NewCmdUser(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		"acl",
 		"l",
 		nil,
-		`User access control list in a comma separated list. Possible values:
-manage_users subscriptions_view subscriptions billing support provisioning dns 
-abuse upgrade firewall alerts objstore loadbalancer`,
+		aclUsage,
 	)
 
 	// Update
@@ -263,6 +298,10 @@ abuse upgrade firewall alerts objstore loadbalancer`,
 				return fmt.Errorf("error parsing flag 'acl' for user create : %v", errAc)
 			}
 
+			if err := validateACL(acl); err != nil {
+				return err
+			}
+
 			o.UpdateReq = &govultr.UserReq{}
 
 			if cmd.Flags().Changed("email") {
@@ -302,9 +341,7 @@ abuse upgrade firewall alerts objstore loadbalancer`,
 		"acl",
 		"l",
 		nil,
-		`User access control list in a comma separated list. Possible values:
-manage_users subscriptions_view subscriptions billing support provisioning dns 
-abuse upgrade firewall alerts objstore loadbalancer`,
+		aclUsage,
 	)
 
 	update.MarkFlagsOneRequired(
@@ -339,12 +376,39 @@ abuse upgrade firewall alerts objstore loadbalancer`,
 		},
 	}
 
+	// Permissions
+	permissions := &cobra.Command{
+		Use:     "permissions <User ID>",
+		Short:   "Show the permissions of a user",
+		Aliases: []string{"perms", "p"},
+		Long:    permissionsLong,
+		Example: permissionsExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a user ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving user : %v", err)
+			}
+
+			data := &UserPermissionsPrinter{User: user}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
 	cmd.AddCommand(
 		create,
 		get,
 		list,
 		update,
 		del,
+		permissions,
 	)
 
 	return cmd
@@ -356,13 +420,23 @@ type options struct {
 	UpdateReq *govultr.UserReq
 }
 
+// validateACL makes sure every permission is a known ACL
+func validateACL(acl []string) error {
+	for i := range acl {
+		if !slices.Contains(knownACLs, acl[i]) {
+			return fmt.Errorf("unknown acl %q, possible values are %s", acl[i], strings.Join(knownACLs, ", "))
+		}
+	}
+	return nil
+}
+
 func (o *options) list() ([]govultr.User, *govultr.Meta, error) {
-	users, meta, _, err := o.Base.Client.User.List(context.Background(), o.Base.Options)
+	users, meta, _, err := o.Base.Client.User.List(o.Base.Context, o.Base.Options)
 	return users, meta, err
 }
 
 func (o *options) get() (*govultr.User, error) {
-	user, _, err := o.Base.Client.User.Get(context.Background(), o.Base.Args[0])
+	user, _, err := o.Base.Client.User.Get(o.Base.Context, o.Base.Args[0])
 	return user, err
 }
 
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+)
+
+// OpenURL opens the URL in the default browser of the desktop
+func OpenURL(ctx context.Context, url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "open", url)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.CommandContext(ctx, "xdg-open", url)
+	}
+
+	return cmd.Start()
+}
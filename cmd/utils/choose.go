@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errNoAnswer is returned by the choices when stdin is closed
+var errNoAnswer = errors.New("no answer given, stdin was closed")
+
+// Choice is an option offered by Choose, its value is what the answer
+// stands for and the label what is shown
+type Choice struct {
+	Value string
+	Label string
+}
+
+// Choose lists the choices on stderr and reads the one picked from stdin,
+// either by its number or value.  Any other answer filters the list down
+// to the choices which match it.  An empty answer picks nothing when the
+// choice is optional.
+func Choose(question string, choices []Choice, optional bool) (string, error) {
+	shown := choices
+	for {
+		listChoices(shown)
+
+		answer, err := ask(question, optional, false)
+		if err != nil {
+			return "", err
+		}
+
+		if answer == "" {
+			if optional {
+				return "", nil
+			}
+			continue
+		}
+
+		matches := matchChoices(answer, shown, choices)
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(os.Stderr, "nothing matches %q\n", answer)
+			shown = choices
+		case 1:
+			return matches[0].Value, nil
+		default:
+			shown = matches
+		}
+	}
+}
+
+// ChooseMany lists the choices on stderr and reads the comma separated
+// numbers or values of those picked from stdin.  An empty answer picks
+// none.
+func ChooseMany(question string, choices []Choice) ([]string, error) {
+	listChoices(choices)
+
+	for {
+		answer, err := ask(question, true, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if answer == "" {
+			return nil, nil
+		}
+
+		var values []string
+		for _, part := range strings.Split(answer, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			matches := matchChoices(part, choices, choices)
+			if len(matches) != 1 {
+				fmt.Fprintf(os.Stderr, "%q must match exactly one choice\n", part)
+				values = nil
+				break
+			}
+			values = append(values, matches[0].Value)
+		}
+
+		if values != nil {
+			return values, nil
+		}
+	}
+}
+
+// ask prompts for an answer, noting whether it may be left empty
+func ask(question string, optional, many bool) (string, error) {
+	hint := "number, ID or text to search"
+	if many {
+		hint = "comma separated numbers or IDs"
+	}
+	if optional {
+		hint += ", empty for none"
+	}
+	fmt.Fprintf(os.Stderr, "%s (%s): ", question, hint)
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(os.Stderr)
+		return "", errNoAnswer
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
+// listChoices prints the numbered choices
+func listChoices(choices []Choice) {
+	width := 0
+	for i := range choices {
+		width = max(width, len(choices[i].Value))
+	}
+
+	for i := range choices {
+		fmt.Fprintf(os.Stderr, "%4d) %-*s  %s\n", i+1, width, choices[i].Value, choices[i].Label)
+	}
+}
+
+// matchChoices returns the choice picked by the answer, either its number
+// in the shown list or its value, or else the choices matching the answer
+// as a search, best first
+func matchChoices(answer string, shown, all []Choice) []Choice {
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(shown) {
+		return shown[n-1 : n]
+	}
+
+	for i := range all {
+		if strings.EqualFold(all[i].Value, answer) {
+			return all[i : i+1]
+		}
+	}
+
+	type scored struct {
+		choice Choice
+		score  int
+	}
+
+	var found []scored
+	terms := strings.Fields(answer)
+	for i := range all {
+		if score, ok := FuzzyScore(terms, all[i].Value+" "+all[i].Label); ok {
+			found = append(found, scored{choice: all[i], score: score})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	matches := make([]Choice, len(found))
+	for i := range found {
+		matches[i] = found[i].choice
+	}
+	return matches
+}
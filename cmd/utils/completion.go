@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+	"github.com/vultr/vultr-cli/v3/pkg/keyring"
+)
+
+// completionCacheTTL is how long the resources listed for shell completion
+// are reused, so repeated tab presses don't each call the API
+const completionCacheTTL = 30 * time.Second
+
+// RegisterCompletion sets the completion of the first argument of every
+// command below cmd whose usage starts with the placeholder, such as
+// "<Instance ID>"
+func RegisterCompletion(cmd *cobra.Command, placeholder string, complete cobra.CompletionFunc) {
+	for _, c := range cmd.Commands() {
+		RegisterCompletion(c, placeholder, complete)
+	}
+
+	usage := strings.TrimSpace(strings.TrimPrefix(cmd.Use, cmd.Name()))
+	if cmd.ValidArgsFunction != nil || !strings.HasPrefix(usage, placeholder) {
+		return
+	}
+
+	cmd.ValidArgsFunction = func(
+		cmd *cobra.Command,
+		args []string,
+		toComplete string,
+	) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// CompleteResources returns a completion function offering the resources
+// returned by list.  Results are cached per kind and profile for a short
+// while and any failure simply offers no completions.
+func CompleteResources(b *cli.Base, kind string, list func() ([]cobra.Completion, error)) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		cache := completionCachePath(kind)
+		if completions, ok := readCompletionCache(cache); ok {
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		if !completionAuth(b) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions, err := list()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		writeCompletionCache(cache, completions)
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionAuth sets up the API key of the selected profile or keyring
+// since completions run without the PersistentPreRun of the commands
+func completionAuth(b *cli.Base) bool {
+	if name := viper.GetString("profile"); name != "" {
+		p, err := cli.LoadProfile(name)
+		if err != nil {
+			return false
+		}
+		if p.APIKey != "" {
+			b.UseAPIKey(p.APIKey)
+		}
+	}
+
+	if !b.HasAuth && viper.GetBool("keyring") {
+		if key, err := keyring.Get(b.Context, keyring.DefaultAccount); err == nil {
+			b.UseAPIKey(key)
+		}
+	}
+
+	return b.HasAuth
+}
+
+func completionCachePath(kind string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	name := kind
+	if profile := viper.GetString("profile"); profile != "" {
+		name += "-" + profile
+	}
+
+	return filepath.Join(dir, "vultr-cli", "completion", name+".json")
+}
+
+func readCompletionCache(path string) ([]cobra.Completion, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > completionCacheTTL {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, false
+	}
+
+	var completions []cobra.Completion
+	if err := json.Unmarshal(data, &completions); err != nil {
+		return nil, false
+	}
+
+	return completions, true
+}
+
+func writeCompletionCache(path string, completions []cobra.Completion) {
+	if path == "" {
+		return
+	}
+
+	data, err := json.Marshal(completions)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// CompleteInstances completes instance IDs, described by their labels
+func CompleteInstances(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "instances", func() ([]cobra.Completion, error) {
+		instances, _, _, err := b.Client.Instance.List(b.Context, completionListOptions())
+		return toCompletions(instances, func(r *govultr.Instance) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteBareMetals completes bare metal server IDs, described by their
+// labels
+func CompleteBareMetals(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "bare-metals", func() ([]cobra.Completion, error) {
+		servers, _, _, err := b.Client.BareMetalServer.List(b.Context, completionListOptions())
+		return toCompletions(servers, func(r *govultr.BareMetalServer) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteDomains completes DNS domain names
+func CompleteDomains(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "domains", func() ([]cobra.Completion, error) {
+		domains, _, _, err := b.Client.Domain.List(b.Context, completionListOptions())
+		return toCompletions(domains, func(r *govultr.Domain) cobra.Completion {
+			return r.Domain
+		}), err
+	})
+}
+
+// CompleteSnapshots completes snapshot IDs, described by their descriptions
+func CompleteSnapshots(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "snapshots", func() ([]cobra.Completion, error) {
+		snapshots, _, _, err := b.Client.Snapshot.List(b.Context, completionListOptions())
+		return toCompletions(snapshots, func(r *govultr.Snapshot) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Description)
+		}), err
+	})
+}
+
+// CompleteBlockStorages completes block storage IDs, described by their
+// labels
+func CompleteBlockStorages(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "block-storages", func() ([]cobra.Completion, error) {
+		blocks, _, _, err := b.Client.BlockStorage.List(b.Context, completionListOptions())
+		return toCompletions(blocks, func(r *govultr.BlockStorage) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteSSHKeys completes SSH key IDs, described by their names
+func CompleteSSHKeys(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "ssh-keys", func() ([]cobra.Completion, error) {
+		keys, _, _, err := b.Client.SSHKey.List(b.Context, completionListOptions())
+		return toCompletions(keys, func(r *govultr.SSHKey) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Name)
+		}), err
+	})
+}
+
+// CompleteScripts completes startup script IDs, described by their names
+func CompleteScripts(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "scripts", func() ([]cobra.Completion, error) {
+		scripts, _, _, err := b.Client.StartupScript.List(b.Context, completionListOptions())
+		return toCompletions(scripts, func(r *govultr.StartupScript) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Name)
+		}), err
+	})
+}
+
+// CompleteKubernetesClusters completes kubernetes cluster IDs, described by
+// their labels
+func CompleteKubernetesClusters(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "kubernetes-clusters", func() ([]cobra.Completion, error) {
+		clusters, _, _, err := b.Client.Kubernetes.ListClusters(b.Context, completionListOptions())
+		return toCompletions(clusters, func(r *govultr.Cluster) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteDatabases completes managed database IDs, described by their
+// labels
+func CompleteDatabases(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "databases", func() ([]cobra.Completion, error) {
+		databases, _, _, err := b.Client.Database.List(b.Context, nil)
+		return toCompletions(databases, func(r *govultr.Database) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteLoadBalancers completes load balancer IDs, described by their
+// labels
+func CompleteLoadBalancers(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "load-balancers", func() ([]cobra.Completion, error) {
+		lbs, _, _, err := b.Client.LoadBalancer.List(b.Context, completionListOptions())
+		return toCompletions(lbs, func(r *govultr.LoadBalancer) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Label)
+		}), err
+	})
+}
+
+// CompleteFirewallGroups completes firewall group IDs, described by their
+// descriptions
+func CompleteFirewallGroups(b *cli.Base) cobra.CompletionFunc {
+	return CompleteResources(b, "firewall-groups", func() ([]cobra.Completion, error) {
+		groups, _, _, err := b.Client.FirewallGroup.List(b.Context, completionListOptions())
+		return toCompletions(groups, func(r *govultr.FirewallGroup) cobra.Completion {
+			return cobra.CompletionWithDesc(r.ID, r.Description)
+		}), err
+	})
+}
+
+// toCompletions converts the listed resources into completions
+func toCompletions[T any](items []T, complete func(*T) cobra.Completion) []cobra.Completion {
+	completions := make([]cobra.Completion, 0, len(items))
+	for i := range items {
+		completions = append(completions, complete(&items[i]))
+	}
+	return completions
+}
+
+func completionListOptions() *govultr.ListOptions {
+	return &govultr.ListOptions{PerPage: PerPageDefault}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdin is shared by the prompts so input buffered by one is not lost to
+// the next
+var stdin = bufio.NewReader(os.Stdin)
+
+// Confirm asks a yes/no question on stderr and reads the answer from stdin.
+// Anything other than y or yes, including a closed stdin, is a no.
+func Confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(os.Stderr)
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// Prompt asks for a value on stderr and reads the answer from stdin,
+// returning the default when the answer is empty
+func Prompt(question, def string) string {
+	if def != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", question)
+	}
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(os.Stderr)
+		return def
+	}
+
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def
+	}
+	return answer
+}
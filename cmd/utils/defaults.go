@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// Config keys holding the values used by create commands when the flags are
+// not given
+const (
+	DefaultRegionKey = "default_region"
+	DefaultPlanKey   = "default_plan"
+	DefaultOSKey     = "default_os"
+)
+
+const (
+	// configDefaultAnnotation holds the config key of the default of a flag
+	configDefaultAnnotation = "vultr-cli_config_default"
+	// configDefaultUnlessAnnotation holds the flags which stop the default
+	// from being used when given
+	configDefaultUnlessAnnotation = "vultr-cli_config_default_unless"
+)
+
+// SetConfigDefault lets the config key supply the value of the flag when it
+// is not given, unless one of the flags in unless is given instead
+func SetConfigDefault(cmd *cobra.Command, flag, key string, unless ...string) {
+	if err := cmd.Flags().SetAnnotation(flag, configDefaultAnnotation, []string{key}); err != nil {
+		fmt.Printf("error setting the config default of flag '%s' for %s: %v\n", flag, cmd.Name(), err)
+		os.Exit(1)
+	}
+
+	if len(unless) == 0 {
+		return
+	}
+
+	if err := cmd.Flags().SetAnnotation(flag, configDefaultUnlessAnnotation, unless); err != nil {
+		fmt.Printf("error setting the config default of flag '%s' for %s: %v\n", flag, cmd.Name(), err)
+		os.Exit(1)
+	}
+}
+
+// applyConfigDefaults sets the flags which were not given to their
+// defaults from the config file.  The region of the profile, when one is
+// used, takes the place of the default region.  The defaults count as given
+// so required flags are satisfied by them
+func applyConfigDefaults(cmd *cobra.Command, profile *cli.Profile) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		key, ok := f.Annotations[configDefaultAnnotation]
+		if !ok || f.Changed {
+			return
+		}
+
+		value := viper.GetString(key[0])
+		if key[0] == DefaultRegionKey && profile != nil && profile.Region != "" {
+			value = profile.Region
+		}
+		if value == "" {
+			return
+		}
+
+		for _, other := range f.Annotations[configDefaultUnlessAnnotation] {
+			if cmd.Flags().Changed(other) {
+				return
+			}
+		}
+
+		if err := f.Value.Set(value); err != nil {
+			printer.Error(fmt.Errorf("error setting flag '%s' from %s in the config file : %v", f.Name, key[0], err))
+		}
+		f.Changed = true
+	})
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// FirewallRuleSpec is a single firewall rule of a declarative rule set.  The
+// subnet may be given in CIDR notation in place of subnet_size and the
+// ip_type is inferred from the subnet when omitted
+type FirewallRuleSpec struct {
+	IPType     string `yaml:"ip_type"`
+	Protocol   string `yaml:"protocol"`
+	Subnet     string `yaml:"subnet"`
+	SubnetSize *int   `yaml:"subnet_size"`
+	Port       string `yaml:"port"`
+	Source     string `yaml:"source"`
+	Notes      string `yaml:"notes"`
+}
+
+// ToReq validates the rule and converts it to a rule create request
+func (r *FirewallRuleSpec) ToReq() (*govultr.FirewallRuleReq, error) {
+	req := &govultr.FirewallRuleReq{
+		IPType:   strings.ToLower(r.IPType),
+		Protocol: strings.ToLower(r.Protocol),
+		Subnet:   r.Subnet,
+		Port:     r.Port,
+		Source:   r.Source,
+		Notes:    r.Notes,
+	}
+
+	if subnet, size, ok := strings.Cut(r.Subnet, "/"); ok {
+		bits, err := strconv.Atoi(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %s", r.Subnet)
+		}
+		req.Subnet = subnet
+		req.SubnetSize = bits
+	} else if r.SubnetSize != nil {
+		req.SubnetSize = *r.SubnetSize
+	} else if r.Source == "" {
+		return nil, errors.New("subnet_size is required when the subnet is not in CIDR notation")
+	}
+
+	if req.IPType == "" {
+		req.IPType = "v4"
+		if strings.Contains(req.Subnet, ":") {
+			req.IPType = "v6"
+		}
+	}
+
+	switch {
+	case req.IPType != "v4" && req.IPType != "v6":
+		return nil, fmt.Errorf("invalid ip_type %q, must be v4 or v6", r.IPType)
+	case !slices.Contains([]string{"icmp", "tcp", "udp", "gre", "esp", "ah"}, req.Protocol):
+		return nil, fmt.Errorf("invalid protocol %q", r.Protocol)
+	case (req.Protocol == "tcp" || req.Protocol == "udp") && req.Port == "":
+		return nil, fmt.Errorf("a port is required for %s rules", req.Protocol)
+	case req.Subnet == "" && req.Source == "":
+		return nil, errors.New("a subnet or source is required")
+	}
+
+	return req, nil
+}
+
+// FirewallRuleToReq converts an existing rule to the request which would create it
+func FirewallRuleToReq(rule *govultr.FirewallRule) *govultr.FirewallRuleReq {
+	return &govultr.FirewallRuleReq{
+		IPType:     rule.IPType,
+		Protocol:   rule.Protocol,
+		Subnet:     rule.Subnet,
+		SubnetSize: rule.SubnetSize,
+		Port:       rule.Port,
+		Source:     rule.Source,
+		Notes:      rule.Notes,
+	}
+}
+
+// FirewallRuleKey identifies a rule by the fields which determine the traffic it
+// allows.  The network is ignored for rules with a source such as cloudflare
+func FirewallRuleKey(rule *govultr.FirewallRuleReq) string {
+	network := FormatFirewallNetwork(rule.Subnet, rule.SubnetSize)
+	if rule.Source != "" {
+		network = ""
+	}
+
+	return strings.Join([]string{
+		strings.ToLower(rule.IPType),
+		strings.ToLower(rule.Protocol),
+		rule.Port,
+		network,
+		rule.Source,
+	}, "|")
+}
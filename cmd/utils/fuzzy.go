@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"strings"
+)
+
+// FuzzyScore reports whether every term of the query matches the text and
+// how well.  A term matches when it is a substring of the text, scoring
+// higher at the start of a word, or when its characters appear in order.
+// Matching ignores case.
+func FuzzyScore(terms []string, text string) (int, bool) {
+	text = strings.ToLower(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.')
+	})
+
+	score := 0
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		switch {
+		case wordHasPrefix(words, term):
+			score += 3
+		case strings.Contains(text, term):
+			score += 2
+		case isSubsequence(term, text):
+			score++
+		default:
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+// wordHasPrefix reports whether any word starts with the term
+func wordHasPrefix(words []string, term string) bool {
+	for i := range words {
+		if strings.HasPrefix(words[i], term) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSubsequence reports whether the characters of the term appear in the
+// text in the same order
+func isSubsequence(term, text string) bool {
+	t := []rune(text)
+	j := 0
+	for _, r := range term {
+		for j < len(t) && t[j] != r {
+			j++
+		}
+		if j == len(t) {
+			return false
+		}
+		j++
+	}
+	return true
+}
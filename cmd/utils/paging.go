@@ -1,10 +1,17 @@
 package utils
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
 )
 
+// ListAllLimit caps the number of items retrieved with --all so a very large
+// account does not page forever
+const ListAllLimit = 10000
+
 func GetPaging(cmd *cobra.Command) *govultr.ListOptions {
 	options := &govultr.ListOptions{}
 
@@ -21,3 +28,59 @@ func GetPaging(cmd *cobra.Command) *govultr.ListOptions {
 
 	return options
 }
+
+// AddListAllFlag adds the --all flag read by ListAll to a list command
+func AddListAllFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, fmt.Sprintf("(optional) retrieve every page, up to %d items", ListAllLimit))
+}
+
+// ListAll calls list once, or when --all is set follows the next cursors,
+// updating the list options, and returns every item.  Once ListAllLimit is
+// reached the items so far are returned with the cursor of the next page.
+func ListAll[T any](
+	cmd *cobra.Command,
+	options *govultr.ListOptions,
+	list func() ([]T, *govultr.Meta, error),
+) ([]T, *govultr.Meta, error) {
+	if all, _ := cmd.Flags().GetBool("all"); !all {
+		return list()
+	}
+
+	var items []T
+	for {
+		page, meta, err := list()
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, page...)
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return items, &govultr.Meta{Total: len(items), Links: &govultr.Links{}}, nil
+		}
+
+		if len(items) >= ListAllLimit {
+			fmt.Fprintf(os.Stderr, "stopped after %d items, continue with --cursor %s\n", len(items), meta.Links.Next)
+			return items, &govultr.Meta{Total: meta.Total, Links: &govultr.Links{Next: meta.Links.Next}}, nil
+		}
+
+		options.Cursor = meta.Links.Next
+	}
+}
+
+// AllPages retrieves every page of a list
+func AllPages[T any](list func(*govultr.ListOptions) ([]T, *govultr.Meta, error)) ([]T, error) {
+	var items []T
+	opts := &govultr.ListOptions{PerPage: PerPageDefault}
+	for {
+		page, meta, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return items, nil
+		}
+		opts.Cursor = meta.Links.Next
+	}
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// HoursPerMonth is the number of hours after which hourly billing stops at
+// the monthly price
+const HoursPerMonth = 672
+
+// ErrNoPrice is returned by a price lookup when the API doesn't publish the
+// price of the resource
+var ErrNoPrice = errors.New("price not published by the API")
+
+// FormatPrice writes a monthly price along with its hourly rate
+func FormatPrice(monthly float64) string {
+	return fmt.Sprintf("$%.2f/month ($%.3f/hour)", monthly, monthly/HoursPerMonth)
+}
+
+// ConfirmPrice asks whether to go ahead with the action at the monthly
+// price returned by price.  Nothing is looked up or asked when yes is given
+// or on a dry run, since nothing is billed then
+func ConfirmPrice(yes bool, action string, price func() (float64, error)) (bool, error) {
+	if yes || viper.GetBool("dry-run") {
+		return true, nil
+	}
+
+	monthly, err := price()
+	switch {
+	case errors.Is(err, ErrNoPrice):
+		return Confirm(action + "?  Its price isn't published by the API"), nil
+	case err != nil:
+		return false, fmt.Errorf("error retrieving the price : %v", err)
+	}
+
+	return Confirm(fmt.Sprintf("%s for %s?", action, FormatPrice(monthly))), nil
+}
+
+// AddYesFlag adds the --yes flag read by the commands which confirm their
+// price
+func AddYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "(optional) go ahead without asking to confirm the price")
+}
+
+// PlanPrice returns the monthly price of an instance plan
+func PlanPrice(b *cli.Base, plan string) (float64, error) {
+	plans, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+		items, meta, _, err := b.Client.Plan.List(b.Context, "", opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range plans {
+		if plans[i].ID == plan {
+			return float64(plans[i].MonthlyCost), nil
+		}
+	}
+
+	return 0, fmt.Errorf("plan %s not found", plan)
+}
+
+// BareMetalPlanPrice returns the monthly price of a bare metal plan
+func BareMetalPlanPrice(b *cli.Base, plan string) (float64, error) {
+	plans, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, error) {
+		items, meta, _, err := b.Client.Plan.ListBareMetal(b.Context, opts)
+		return items, meta, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range plans {
+		if plans[i].ID == plan {
+			return float64(plans[i].MonthlyCost), nil
+		}
+	}
+
+	return 0, fmt.Errorf("bare metal plan %s not found", plan)
+}
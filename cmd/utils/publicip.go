@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// PublicIPv4URL returns the public IPv4 address of the caller as plain text
+	PublicIPv4URL = "https://api.ipify.org"
+	// PublicIPv6URL returns the public IPv6 address of the caller as plain text
+	PublicIPv6URL = "https://api6.ipify.org"
+
+	publicIPTimeout = 30 * time.Second
+)
+
+// PublicIP returns the address reported by the plain text lookup URL,
+// checking it is of the expected family
+func PublicIP(ctx context.Context, url string, ipv6 bool) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, publicIPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256)) //nolint:mnd
+	if err != nil {
+		return "", err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil || (ip.To4() == nil) != ipv6 {
+		return "", fmt.Errorf("%s did not return a valid address", url)
+	}
+
+	return ip.String(), nil
+}
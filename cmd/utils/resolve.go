@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// uuidPattern matches the IDs used by the API
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Resolver returns the ID of the resource known by the name
+type Resolver func(name string) (string, error)
+
+// candidate is a resource which can be picked by one of its names
+type candidate struct {
+	ID    string
+	Names []string
+}
+
+// RegisterResolver lets every command below cmd whose usage starts with the
+// placeholder take a name instead of an ID as its first argument.  Anything
+// which isn't an ID is resolved before the command runs unless --exact-id is
+// given.
+func RegisterResolver(cmd *cobra.Command, placeholder string, resolve Resolver) {
+	for _, c := range cmd.Commands() {
+		RegisterResolver(c, placeholder, resolve)
+	}
+
+	usage := strings.TrimSpace(strings.TrimPrefix(cmd.Use, cmd.Name()))
+	if cmd.RunE == nil || !strings.HasPrefix(usage, placeholder) {
+		return
+	}
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && !uuidPattern.MatchString(args[0]) && !viper.GetBool("exact-id") {
+			id, err := resolve(args[0])
+			if err != nil {
+				return err
+			}
+			// the args are shared with Base.Args
+			args[0] = id
+		}
+		return run(cmd, args)
+	}
+}
+
+// resolveName picks the only candidate known by the name, preferring exact
+// matches over those which only differ in case
+func resolveName(kind, name string, candidates []candidate) (string, error) {
+	var exact, folded []candidate
+	for i := range candidates {
+		for _, n := range candidates[i].Names {
+			if n == name {
+				exact = append(exact, candidates[i])
+				break
+			}
+			if strings.EqualFold(n, name) {
+				folded = append(folded, candidates[i])
+				break
+			}
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s found with the ID or name %q", kind, name)
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	ids := make([]string, len(matches))
+	for i := range matches {
+		ids[i] = matches[i].ID
+	}
+	return "", fmt.Errorf(
+		"%q matches %d %ss, use one of the IDs instead : %s",
+		name,
+		len(matches),
+		kind,
+		strings.Join(ids, ", "),
+	)
+}
+
+// ResolveInstances resolves instances by label or hostname
+func ResolveInstances(b *cli.Base) Resolver {
+	return func(name string) (string, error) {
+		instances, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+			items, meta, _, err := b.Client.Instance.List(b.Context, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving instances to resolve %q : %v", name, err)
+		}
+
+		candidates := make([]candidate, len(instances))
+		for i := range instances {
+			candidates[i] = candidate{ID: instances[i].ID, Names: []string{instances[i].Label, instances[i].Hostname}}
+		}
+		return resolveName("instance", name, candidates)
+	}
+}
+
+// ResolveBlockStorages resolves block storage by label
+func ResolveBlockStorages(b *cli.Base) Resolver {
+	return func(name string) (string, error) {
+		blocks, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+			items, meta, _, err := b.Client.BlockStorage.List(b.Context, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving block storage to resolve %q : %v", name, err)
+		}
+
+		candidates := make([]candidate, len(blocks))
+		for i := range blocks {
+			candidates[i] = candidate{ID: blocks[i].ID, Names: []string{blocks[i].Label}}
+		}
+		return resolveName("block storage", name, candidates)
+	}
+}
+
+// ResolveVPCs resolves VPCs by description
+func ResolveVPCs(b *cli.Base) Resolver {
+	return func(name string) (string, error) {
+		vpcs, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, error) {
+			items, meta, _, err := b.Client.VPC.List(b.Context, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving VPCs to resolve %q : %v", name, err)
+		}
+
+		candidates := make([]candidate, len(vpcs))
+		for i := range vpcs {
+			candidates[i] = candidate{ID: vpcs[i].ID, Names: []string{vpcs[i].Description}}
+		}
+		return resolveName("VPC", name, candidates)
+	}
+}
+
+// ResolveVPC2s resolves VPC 2.0 networks by description
+func ResolveVPC2s(b *cli.Base) Resolver {
+	return func(name string) (string, error) {
+		vpcs, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC2, *govultr.Meta, error) {
+			items, meta, _, err := b.Client.VPC2.List(b.Context, opts) //nolint:staticcheck
+			return items, meta, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving VPC 2.0 networks to resolve %q : %v", name, err)
+		}
+
+		candidates := make([]candidate, len(vpcs))
+		for i := range vpcs {
+			candidates[i] = candidate{ID: vpcs[i].ID, Names: []string{vpcs[i].Description}}
+		}
+		return resolveName("VPC 2.0 network", name, candidates)
+	}
+}
+
+// ResolveFirewallGroups resolves firewall groups by description
+func ResolveFirewallGroups(b *cli.Base) Resolver {
+	return func(name string) (string, error) {
+		groups, err := AllPages(func(opts *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, error) {
+			items, meta, _, err := b.Client.FirewallGroup.List(b.Context, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return "", fmt.Errorf("error retrieving firewall groups to resolve %q : %v", name, err)
+		}
+
+		candidates := make([]candidate, len(groups))
+		for i := range groups {
+			candidates[i] = candidate{ID: groups[i].ID, Names: []string{groups[i].Description}}
+		}
+		return resolveName("firewall group", name, candidates)
+	}
+}
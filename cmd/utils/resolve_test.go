@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveName(t *testing.T) {
+	candidates := []candidate{
+		{ID: "id-web", Names: []string{"web", "web.example.com"}},
+		{ID: "id-db", Names: []string{"db", "db.example.com"}},
+		{ID: "id-Web", Names: []string{"Web", ""}},
+		{ID: "id-cache-1", Names: []string{"cache", ""}},
+		{ID: "id-cache-2", Names: []string{"cache", ""}},
+	}
+
+	tests := []struct {
+		name string
+		want string
+		err  string
+	}{
+		{name: "db", want: "id-db"},
+		{name: "db.example.com", want: "id-db"},
+		{name: "DB", want: "id-db"},
+		{name: "web", want: "id-web"},
+		{name: "Web", want: "id-Web"},
+		{name: "WEB", err: `"WEB" matches 2 instances`},
+		{name: "cache", err: "id-cache-1, id-cache-2"},
+		{name: "mail", err: `no instance found with the ID or name "mail"`},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveName("instance", tt.name, candidates)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("resolveName(%q) = %q, %v, want an error containing %q", tt.name, got, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveName(%q) returned error %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
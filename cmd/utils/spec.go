@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadSpecFile reads a YAML or JSON document from the path (or stdin when the
+// path is "-") and unmarshals it into v.  JSON is a subset of YAML so a
+// single decoder handles both formats.
+func ReadSpecFile(path string, v interface{}) error {
+	var data []byte
+	var err error
+
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filepath.Clean(path))
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to read file %s : %v", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to parse file %s : %v", path, err)
+	}
+
+	return nil
+}
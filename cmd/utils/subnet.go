@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const (
+	minSubnetPrefix = 8
+	maxSubnetPrefix = 30
+	ipv4Bits        = 32
+)
+
+// privateBlocks are the RFC 1918 ranges in the order they are searched
+var privateBlocks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// FreeSubnet returns the first private IPv4 subnet of the prefix length which
+// does not overlap any of the used networks
+func FreeSubnet(used []*net.IPNet, prefix int) (*net.IPNet, error) {
+	if prefix < minSubnetPrefix || prefix > maxSubnetPrefix {
+		return nil, fmt.Errorf("prefix length must be between %d and %d, got %d", minSubnetPrefix, maxSubnetPrefix, prefix)
+	}
+
+	mask := net.CIDRMask(prefix, ipv4Bits)
+	size := uint32(1) << (ipv4Bits - prefix)
+
+	for _, block := range privateBlocks {
+		_, network, _ := net.ParseCIDR(block)
+		if ones, _ := network.Mask.Size(); ones > prefix {
+			continue
+		}
+
+		start := binary.BigEndian.Uint32(network.IP.To4())
+		for addr := start; network.Contains(uint32ToIP(addr)); addr += size {
+			candidate := &net.IPNet{IP: uint32ToIP(addr), Mask: mask}
+			if !overlapsAny(candidate, used) {
+				return candidate, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no free private /%d subnet", prefix)
+}
+
+func overlapsAny(n *net.IPNet, used []*net.IPNet) bool {
+	for _, u := range used {
+		if u.Contains(n.IP) || n.Contains(u.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func uint32ToIP(v uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, v)
+	return ip
+}
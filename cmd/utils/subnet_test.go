@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"net"
+	"testing"
+)
+
+func mustCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			t.Fatalf("invalid CIDR %q : %v", c, err)
+		}
+		networks[i] = n
+	}
+	return networks
+}
+
+func TestFreeSubnet(t *testing.T) {
+	tests := []struct {
+		name   string
+		used   []string
+		prefix int
+		want   string
+		err    bool
+	}{
+		{name: "nothing used", prefix: 24, want: "10.0.0.0/24"},
+		{name: "first taken", used: []string{"10.0.0.0/24"}, prefix: 24, want: "10.0.1.0/24"},
+		{name: "smaller network inside", used: []string{"10.0.0.128/25"}, prefix: 24, want: "10.0.1.0/24"},
+		{name: "larger network around", used: []string{"10.0.0.0/16"}, prefix: 24, want: "10.1.0.0/24"},
+		{name: "gap is used", used: []string{"10.0.0.0/24", "10.0.2.0/24"}, prefix: 24, want: "10.0.1.0/24"},
+		{name: "next block", used: []string{"10.0.0.0/8"}, prefix: 20, want: "172.16.0.0/20"},
+		{name: "only the full block fits", used: []string{"10.0.0.0/8"}, prefix: 10, err: true},
+		{name: "all used", used: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}, prefix: 24, err: true},
+		{name: "prefix too short", prefix: 7, err: true},
+		{name: "prefix too long", prefix: 31, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FreeSubnet(mustCIDRs(t, tt.used...), tt.prefix)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("FreeSubnet() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FreeSubnet() returned error %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("FreeSubnet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,3 +19,29 @@ func ParseAPITimestamp(ts string) string {
 	tsYear, tsMonth, tsDay := tsTime.Date()
 	return fmt.Sprintf("%d-%02d-%02d", tsYear, int(tsMonth), tsDay)
 }
+
+// ParseAge parses a duration which may also be given in days or weeks, such
+// as 30d or 2w, on top of the units understood by time.ParseDuration
+func ParseAge(age string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(age, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid age %q", age)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q", age)
+	}
+
+	return d, nil
+}
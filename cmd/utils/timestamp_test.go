@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age  string
+		want time.Duration
+		err  bool
+	}{
+		{age: "30d", want: 30 * 24 * time.Hour},
+		{age: "2w", want: 14 * 24 * time.Hour},
+		{age: "0d", want: 0},
+		{age: "12h", want: 12 * time.Hour},
+		{age: "1h30m", want: 90 * time.Minute},
+		{age: "-1d", err: true},
+		{age: "1.5d", err: true},
+		{age: "d", err: true},
+		{age: "30", err: true},
+		{age: "", err: true},
+		{age: "soon", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.age)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseAge(%q) = %v, want an error", tt.age, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAge(%q) returned error %v", tt.age, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package utils
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
+	"github.com/vultr/vultr-cli/v3/pkg/keyring"
 )
 
 const (
@@ -19,6 +23,81 @@ const (
 func SetOptions(b *cli.Base, cmd *cobra.Command, args []string) {
 	b.Args = args
 	b.Printer.Output = viper.GetString("output")
+	b.Printer.Query = viper.GetString("query")
+	b.Printer.Table = printer.TableOptions{
+		Columns: viper.GetStringSlice("columns"),
+		SortBy:  viper.GetString("sort-by"),
+		NoTrunc: viper.GetBool("no-trunc"),
+		NoColor: viper.GetBool("no-color"),
+		Short:   viper.GetBool("short"),
+		Wide:    viper.GetBool("wide"),
+	}
+	b.SetRetries(viper.GetInt("retries"), viper.GetDuration("retry-max-wait"))
+	b.SetCache(viper.GetDuration("cache-ttl"), viper.GetBool("no-cache"))
+	b.SetDebug(viper.GetBool("debug"), viper.GetBool("debug-body"))
+	b.SetDryRun(viper.GetBool("dry-run"))
+	b.SetWarnings(!viper.GetBool("quiet"))
+
+	if err := b.SetTransport(cli.TransportOptions{
+		Proxy:              viper.GetString("proxy"),
+		CACert:             viper.GetString("cacert"),
+		InsecureSkipVerify: viper.GetBool("insecure-skip-verify"),
+	}); err != nil {
+		printer.Error(err)
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	b.SetAudit(viper.GetBool("audit"), command, os.Args[1:], cmd.Flags())
+
+	if err := b.SetLog(viper.GetString("log-file"), viper.GetString("log-level")); err != nil {
+		printer.Error(err)
+	}
+	b.LogCommand(command, os.Args[1:], cmd.Flags())
+
+	if endpoint := viper.GetString("api-endpoint"); endpoint != "" {
+		if err := b.SetEndpoint(endpoint); err != nil {
+			printer.Error(err)
+		}
+	}
+
+	var profile *cli.Profile
+	if name := viper.GetString("profile"); name != "" {
+		p, err := cli.LoadProfile(name)
+		if err != nil {
+			printer.Error(err)
+		}
+		applyProfile(b, cmd, p)
+		profile = p
+	}
+
+	if !b.HasAuth && viper.GetBool("keyring") {
+		useKeyring(b)
+	}
+
+	applyConfigDefaults(cmd, profile)
+}
+
+// useKeyring switches to the API key stored in the OS keyring.  A failure
+// only warns since not every command needs to authenticate.
+func useKeyring(b *cli.Base) {
+	key, err := keyring.Get(b.Context, keyring.DefaultAccount)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading API key from keyring : %v\n", err)
+		return
+	}
+	b.UseAPIKey(key)
+}
+
+// applyProfile switches to the API key of the profile and uses its output
+// format unless it was given as a flag.  The region of the profile is only
+// used by the create commands, see applyConfigDefaults
+func applyProfile(b *cli.Base, cmd *cobra.Command, p *cli.Profile) {
+	if p.APIKey != "" {
+		b.UseAPIKey(p.APIKey)
+	}
+
+	if f := cmd.Flags().Lookup("output"); p.Output != "" && f != nil && !f.Changed {
+		b.Printer.Output = p.Output
+	}
 }
 
 // GetFirewallSource parses the source and if empty, returns 'anywhere'
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+// AddWaitFlags adds the --wait and --wait-timeout flags of a command whose
+// action finishes in the background.  until describes the state waited for
+func AddWaitFlags(cmd *cobra.Command, until string) {
+	cmd.Flags().Bool("wait", false, fmt.Sprintf("(optional) wait until %s, showing its progress", until))
+	AddWaitTimeoutFlag(cmd, cli.WaitTimeout, "(optional) how long to wait before giving up when --wait is given")
+}
+
+// AddWaitTimeoutFlag adds only the --wait-timeout flag, for commands which
+// always wait
+func AddWaitTimeoutFlag(cmd *cobra.Command, timeout time.Duration, usage string) {
+	cmd.Flags().Duration("wait-timeout", timeout, usage)
+}
+
+// GetWaitFlags returns whether the command should wait and for how long
+func GetWaitFlags(cmd *cobra.Command) (bool, time.Duration, error) {
+	wait, errWa := cmd.Flags().GetBool("wait")
+	if errWa != nil {
+		return false, 0, fmt.Errorf("error parsing flag 'wait' for %s : %v", cmd.CommandPath(), errWa)
+	}
+
+	timeout, err := GetWaitTimeout(cmd)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return wait, timeout, nil
+}
+
+// GetWaitTimeout returns the --wait-timeout of the command
+func GetWaitTimeout(cmd *cobra.Command) (time.Duration, error) {
+	timeout, errTi := cmd.Flags().GetDuration("wait-timeout")
+	if errTi != nil {
+		return 0, fmt.Errorf("error parsing flag 'wait-timeout' for %s : %v", cmd.CommandPath(), errTi)
+	}
+
+	return timeout, nil
+}
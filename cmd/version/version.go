@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
 )
 
@@ -34,6 +35,9 @@ func NewCmdVersion(base *cli.Base) *cobra.Command {
 		Aliases: []string{"v"},
 		Long:    long,
 		Example: example,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.SetOptions(o.Base, cmd, args)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			o.Base.Printer.Display(&VersionPrinter{Version: o.get()}, nil)
 		},
@@ -1,6 +1,7 @@
 package vpc
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/vultr/govultr/v3"
@@ -107,3 +108,124 @@ func (s *VPCPrinter) Data() [][]string {
 func (s *VPCPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// vpcMember is a server attached to a VPC
+type vpcMember struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	MainIP     string `json:"main_ip"`
+	PrivateIP  string `json:"private_ip"`
+	MACAddress string `json:"mac_address"`
+}
+
+// VPCMembersPrinter ...
+type VPCMembersPrinter struct {
+	Members []vpcMember `json:"members"`
+}
+
+// JSON ...
+func (s *VPCMembersPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *VPCMembersPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *VPCMembersPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"ID",
+		"LABEL",
+		"MAIN IP",
+		"PRIVATE IP",
+		"MAC ADDRESS",
+	}}
+}
+
+// Data ...
+func (s *VPCMembersPrinter) Data() [][]string {
+	if len(s.Members) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Members {
+		data = append(data, []string{
+			s.Members[i].Type,
+			s.Members[i].ID,
+			s.Members[i].Label,
+			s.Members[i].MainIP,
+			s.Members[i].PrivateIP,
+			s.Members[i].MACAddress,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *VPCMembersPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// VPCRoutesPrinter ...
+type VPCRoutesPrinter struct {
+	VPC     *govultr.VPC `json:"vpc"`
+	Members []vpcMember  `json:"members"`
+}
+
+// JSON ...
+func (s *VPCRoutesPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *VPCRoutesPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *VPCRoutesPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (s *VPCRoutesPrinter) Data() [][]string {
+	network := fmt.Sprintf("%s/%d", s.VPC.V4Subnet, s.VPC.V4SubnetMask)
+
+	data := [][]string{
+		{"ID", s.VPC.ID},
+		{"REGION", s.VPC.Region},
+		{"DESCRIPTION", s.VPC.Description},
+		{"NETWORK", network},
+		{" "},
+		{"ROUTES"},
+		{"DESTINATION", "NEXT HOP", "TYPE", "ID", "LABEL"},
+		{network, "link", "subnet", s.VPC.ID, s.VPC.Description},
+	}
+
+	for i := range s.Members {
+		data = append(data, []string{
+			s.Members[i].PrivateIP + "/32",
+			s.Members[i].MACAddress,
+			s.Members[i].Type,
+			s.Members[i].ID,
+			s.Members[i].Label,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *VPCRoutesPrinter) Paging() [][]string {
+	return nil
+}
@@ -53,6 +53,20 @@ var (
 	# Shortned example with aliases
 	vultr-cli vpc u fe8cfe1d-b25c-4c3c-8dfe-e5784bade8d9 -d="Example Updated VPC"
 	`
+	instancesLong = `List the instances and bare metal servers attached to a VPC with their private
+IP addresses.  Every server in the region of the VPC is checked so this can take
+a while on large accounts.`
+	instancesExample = `
+	# Full example
+	vultr-cli vpc instances 9fd4dcf5-7108-4641-9969-b2b9a8f77990
+	`
+	routesLong = `Show the network of a VPC along with the route to each attached server.  VPCs
+are a single layer 2 network so every member is reached directly on the subnet,
+by the MAC address shown as its next hop.`
+	routesExample = `
+	# Full example
+	vultr-cli vpc routes 9fd4dcf5-7108-4641-9969-b2b9a8f77990
+	`
 	deleteLong    = `Delete an existing VPC`
 	deleteExample = `
 	#Full example
@@ -90,7 +104,7 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			vpcs, meta, err := o.list()
+			vpcs, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving vpc list : %v", err)
 			}
@@ -112,6 +126,7 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -191,6 +206,7 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		fmt.Printf("error marking vpc create 'region' flag required: %v", err)
 		os.Exit(1)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
 
 	create.Flags().StringP("description", "d", "", "The description of the VPC")
 	create.Flags().StringP("subnet", "s", "", "The IPv4 VPC in CIDR notation.")
@@ -257,14 +273,79 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	// Instances
+	instances := &cobra.Command{
+		Use:     "instances <VPC ID>",
+		Aliases: []string{"members"},
+		Short:   "List the servers attached to a VPC",
+		Long:    instancesLong,
+		Example: instancesExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a VPC ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vpc, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc : %v", err)
+			}
+
+			members, err := o.members(vpc)
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc members : %v", err)
+			}
+
+			data := &VPCMembersPrinter{Members: members}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	// Routes
+	routes := &cobra.Command{
+		Use:     "routes <VPC ID>",
+		Short:   "Show the network and member routes of a VPC",
+		Long:    routesLong,
+		Example: routesExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a VPC ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vpc, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc : %v", err)
+			}
+
+			members, err := o.members(vpc)
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc members : %v", err)
+			}
+
+			data := &VPCRoutesPrinter{VPC: vpc, Members: members}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
 	cmd.AddCommand(
 		list,
 		get,
 		create,
 		update,
 		del,
+		instances,
+		routes,
 	)
 
+	utils.RegisterResolver(cmd, "<VPC ID>", utils.ResolveVPCs(o.Base))
+
 	return cmd
 }
 
@@ -296,3 +377,80 @@ func (o *options) update() error {
 func (o *options) del() error {
 	return o.Base.Client.VPC.Delete(o.Base.Context, o.Base.Args[0])
 }
+
+// members finds the instances and bare metal servers attached to the VPC by
+// checking the VPCs of every server in its region
+func (o *options) members(vpc *govultr.VPC) ([]vpcMember, error) {
+	var members []vpcMember
+
+	instances, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		opts.Region = vpc.Region
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range instances {
+		if instances[i].Region != vpc.Region {
+			continue
+		}
+
+		infos, _, _, err := o.Base.Client.Instance.ListVPCInfo(
+			o.Base.Context,
+			instances[i].ID,
+			&govultr.ListOptions{PerPage: utils.PerPageDefault},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range infos {
+			if infos[j].ID == vpc.ID {
+				members = append(members, vpcMember{
+					Type:       "instance",
+					ID:         instances[i].ID,
+					Label:      instances[i].Label,
+					MainIP:     instances[i].MainIP,
+					PrivateIP:  infos[j].IPAddress,
+					MACAddress: infos[j].MacAddress,
+				})
+			}
+		}
+	}
+
+	servers, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		servers, meta, _, err := o.Base.Client.BareMetalServer.List(o.Base.Context, opts)
+		return servers, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range servers {
+		if servers[i].Region != vpc.Region {
+			continue
+		}
+
+		infos, _, err := o.Base.Client.BareMetalServer.ListVPCInfo(o.Base.Context, servers[i].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range infos {
+			if infos[j].ID == vpc.ID {
+				members = append(members, vpcMember{
+					Type:       "bare-metal",
+					ID:         servers[i].ID,
+					Label:      servers[i].Label,
+					MainIP:     servers[i].MainIP,
+					PrivateIP:  infos[j].IPAddress,
+					MACAddress: infos[j].MacAddress,
+				})
+			}
+		}
+	}
+
+	return members, nil
+}
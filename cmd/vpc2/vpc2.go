@@ -4,6 +4,7 @@ package vpc2
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -19,13 +20,19 @@ var (
 	# Full example
 	vultr-cli vpc2
 	`
-	getLong       = ``
-	getExample    = ``
-	createLong    = `Create a new VPC 2.0 network with specified region, description, and network settings`
+	getLong    = ``
+	getExample = ``
+	createLong = `Create a new VPC 2.0 network with specified region, description, and network settings
+
+When --auto-subnet is set, the existing VPC and VPC 2.0 networks in the region are inspected and the first
+RFC 1918 range of the requested prefix length (24 if unset) which does not overlap any of them is used.`
 	createExample = `
 	# Full example
 	vultr-cli vpc2 create --region="ewr" --description="example-vpc" --ip-type="v4" --ip-block="10.99.0.0" \
 		--prefix-length="24"
+
+	# Pick a free private range automatically
+	vultr-cli vpc2 create --region="ewr" --description="example-vpc" --auto-subnet --prefix-length="24"
 	`
 	updateLong    = `Updates a VPC 2.0 network with the supplied information`
 	updateExample = `
@@ -76,7 +83,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			vpc2s, meta, err := o.list()
+			vpc2s, meta, err := utils.ListAll(cmd, o.Base.Options, o.list)
 			if err != nil {
 				return fmt.Errorf("error retrieving vpc2 list : %v", err)
 			}
@@ -99,6 +106,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(list)
 
 	// Get
 	get := &cobra.Command{
@@ -160,6 +168,25 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'prefix-length' for vpc2 create : %v", errPr)
 			}
 
+			autoSubnet, errAu := cmd.Flags().GetBool("auto-subnet")
+			if errAu != nil {
+				return fmt.Errorf("error parsing flag 'auto-subnet' for vpc2 create : %v", errAu)
+			}
+
+			if autoSubnet {
+				if prefixLen == 0 {
+					prefixLen = defaultPrefixLength
+				}
+
+				subnet, err := o.freeSubnet(region, prefixLen)
+				if err != nil {
+					return fmt.Errorf("error picking a subnet for vpc2 create : %v", err)
+				}
+
+				ipType = "v4"
+				ipBlock = subnet.IP.String()
+			}
+
 			o.CreateReq = &govultr.VPC2Req{
 				Region:       region,
 				Description:  description,
@@ -186,6 +213,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		fmt.Printf("error marking vpc create 'region' flag required: %v", err)
 		os.Exit(1)
 	}
+	utils.SetConfigDefault(create, "region", utils.DefaultRegionKey)
 
 	create.Flags().StringP("description", "d", "", "description for the new VPC2 network")
 	create.Flags().StringP("ip-type", "", "", "IP type for the new VPC2 network")
@@ -196,6 +224,12 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		0,
 		"number of bits for the netmask in CIDR notation for the new VPC2 network",
 	)
+	create.Flags().Bool(
+		"auto-subnet",
+		false,
+		"pick a private IPv4 range which does not overlap the existing VPC networks in the region",
+	)
+	create.MarkFlagsMutuallyExclusive("auto-subnet", "ip-block")
 
 	// Update
 	update := &cobra.Command{
@@ -281,7 +315,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.Base.Options = utils.GetPaging(cmd)
 
-			nodes, meta, err := o.listNodes()
+			nodes, meta, err := utils.ListAll(cmd, o.Base.Options, o.listNodes)
 			if err != nil {
 				return fmt.Errorf("error retrieving vpc2 nodes list : %v", err)
 			}
@@ -304,6 +338,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			utils.PerPageDefault,
 		),
 	)
+	utils.AddListAllFlag(nodesList)
 
 	// Nodes Attach
 	nodesAttach := &cobra.Command{
@@ -410,9 +445,14 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		nodes,
 	)
 
+	utils.RegisterResolver(cmd, "<VPC2 ID>", utils.ResolveVPC2s(o.Base))
+
 	return cmd
 }
 
+// defaultPrefixLength is used by --auto-subnet when no prefix length is given
+const defaultPrefixLength = 24
+
 type options struct {
 	Base            *cli.Base
 	CreateReq       *govultr.VPC2Req
@@ -435,6 +475,72 @@ func (o *options) create() (*govultr.VPC2, error) {
 	return vpc2, err
 }
 
+// freeSubnet returns the first private subnet of the prefix length which
+// does not overlap a VPC or VPC 2.0 network in the region
+func (o *options) freeSubnet(region string, prefixLen int) (*net.IPNet, error) {
+	used, err := o.vpc2Networks(region)
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpc2 networks : %v", err)
+	}
+
+	vpcs, err := o.vpcNetworks(region)
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpc networks : %v", err)
+	}
+
+	return utils.FreeSubnet(append(used, vpcs...), prefixLen)
+}
+
+// vpc2Networks returns the networks of all VPC 2.0 networks in the region
+func (o *options) vpc2Networks(region string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	vpc2s, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC2, *govultr.Meta, error) {
+		vpc2s, meta, _, err := o.Base.Client.VPC2.List(o.Base.Context, opts) //nolint:staticcheck
+		return vpc2s, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range vpc2s {
+		if n := parseNetwork(vpc2s[i].IPBlock, vpc2s[i].PrefixLength); n != nil && vpc2s[i].Region == region {
+			networks = append(networks, n)
+		}
+	}
+
+	return networks, nil
+}
+
+// vpcNetworks returns the networks of all VPCs in the region
+func (o *options) vpcNetworks(region string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	vpcs, err := utils.AllPages(func(opts *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, error) {
+		vpcs, meta, _, err := o.Base.Client.VPC.List(o.Base.Context, opts)
+		return vpcs, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range vpcs {
+		if n := parseNetwork(vpcs[i].V4Subnet, vpcs[i].V4SubnetMask); n != nil && vpcs[i].Region == region {
+			networks = append(networks, n)
+		}
+	}
+
+	return networks, nil
+}
+
+// parseNetwork returns the network of the address and prefix length or nil
+// when they are not valid
+func parseNetwork(ip string, prefix int) *net.IPNet {
+	_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", ip, prefix))
+	if err != nil {
+		return nil
+	}
+	return network
+}
+
 func (o *options) update() error {
 	return o.Base.Client.VPC2.Update(o.Base.Context, o.Base.Args[0], o.Description) //nolint:staticcheck
 }
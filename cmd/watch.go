@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultWatchInterval = "2s"
+
+	escClear     = "\x1b[H\x1b[2J"
+	escHighlight = "\x1b[7m"
+	escReset     = "\x1b[0m"
+)
+
+// watchArgs looks for --watch in the arguments.  The interval is optional
+// and may follow as the next argument, as in --watch 5s, so the flag is
+// handled before cobra parses the arguments.  The arguments are returned
+// without the flag.
+func watchArgs(args []string) ([]string, time.Duration, bool, error) {
+	var rest []string
+	var interval time.Duration
+	found := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		value, ok := strings.CutPrefix(arg, "--watch")
+		if !ok || (value != "" && !strings.HasPrefix(value, "=")) {
+			rest = append(rest, arg)
+			continue
+		}
+
+		found = true
+		value = strings.TrimPrefix(value, "=")
+		if value == "" {
+			value = defaultWatchInterval
+			if i+1 < len(args) {
+				if _, err := time.ParseDuration(args[i+1]); err == nil {
+					value = args[i+1]
+					i++
+				}
+			}
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, 0, false, fmt.Errorf("invalid --watch interval %q", value)
+		}
+		interval = d
+	}
+
+	return rest, interval, found, nil
+}
+
+// ownWatch reports whether the command defines a watch flag of its own, such
+// as load-balancer health, in which case --watch is left for the command
+func ownWatch(args []string) bool {
+	var rest []string
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg != "--watch" && !strings.HasPrefix(arg, "--watch=") {
+			rest = append(rest, arg)
+		}
+	}
+
+	cmd, _, err := rootCmd.Find(rest)
+	return err == nil && cmd != rootCmd && cmd.LocalNonPersistentFlags().Lookup("watch") != nil
+}
+
+// watchable reports whether the command only reads, so running it over and
+// over is safe
+func watchable(args []string) bool {
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil || cmd == rootCmd {
+		return false
+	}
+
+	name := cmd.Name()
+	return name == "info" || strings.HasPrefix(name, "list") || strings.HasPrefix(name, "get")
+}
+
+// watch runs the CLI with the arguments every interval, repainting the
+// screen and highlighting the lines which changed since the last run
+func watch(args []string, interval time.Duration) error {
+	if !watchable(args) {
+		return errors.New("--watch can only be used with list and get commands")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	header := fmt.Sprintf("Every %s: vultr-cli %s", interval, strings.Join(args, " "))
+
+	var previous []string
+	for {
+		out, _ := exec.CommandContext(ctx, exe, args...).CombinedOutput()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+
+		var b strings.Builder
+		b.WriteString(escClear)
+		fmt.Fprintf(&b, "%s    %s\n\n", header, time.Now().Format(time.TimeOnly))
+		for i := range lines {
+			if previous != nil && (i >= len(previous) || previous[i] != lines[i]) {
+				b.WriteString(escHighlight + lines[i] + escReset + "\n")
+				continue
+			}
+			b.WriteString(lines[i] + "\n")
+		}
+		fmt.Print(b.String())
+
+		previous = lines
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		rest     []string
+		interval time.Duration
+		found    bool
+		err      bool
+	}{
+		{
+			name: "no watch",
+			args: []string{"instance", "list"},
+			rest: []string{"instance", "list"},
+		},
+		{
+			name:     "default interval",
+			args:     []string{"instance", "list", "--watch"},
+			rest:     []string{"instance", "list"},
+			interval: 2 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "interval after the flag",
+			args:     []string{"--watch", "5s", "instance", "list"},
+			rest:     []string{"instance", "list"},
+			interval: 5 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "argument after the flag is not an interval",
+			args:     []string{"instance", "get", "--watch", "web"},
+			rest:     []string{"instance", "get", "web"},
+			interval: 2 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "interval with equals",
+			args:     []string{"instance", "list", "--watch=1m"},
+			rest:     []string{"instance", "list"},
+			interval: time.Minute,
+			found:    true,
+		},
+		{
+			name: "other flag with the same prefix",
+			args: []string{"instance", "list", "--watcher"},
+			rest: []string{"instance", "list", "--watcher"},
+		},
+		{
+			name: "after the end of the flags",
+			args: []string{"ssh", "web", "--", "--watch"},
+			rest: []string{"ssh", "web", "--", "--watch"},
+		},
+		{
+			name: "invalid interval",
+			args: []string{"instance", "list", "--watch=often"},
+			err:  true,
+		},
+		{
+			name: "interval not positive",
+			args: []string{"instance", "list", "--watch=0s"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, interval, found, err := watchArgs(tt.args)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("watchArgs(%q) succeeded, want an error", tt.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("watchArgs(%q) returned error %v", tt.args, err)
+			}
+			if !slices.Equal(rest, tt.rest) || interval != tt.interval || found != tt.found {
+				t.Errorf(
+					"watchArgs(%q) = %q, %v, %v, want %q, %v, %v",
+					tt.args, rest, interval, found, tt.rest, tt.interval, tt.found,
+				)
+			}
+		})
+	}
+}
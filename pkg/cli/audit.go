@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	auditDirPermission  = 0o700
+	auditFilePermission = 0o600
+)
+
+// AuditEntry is a single change made through the API, as stored in the
+// audit log
+type AuditEntry struct {
+	Time     time.Time   `json:"time"`
+	Command  string      `json:"command"`
+	Args     []string    `json:"args"`
+	Method   string      `json:"method"`
+	Path     string      `json:"path"`
+	Status   int         `json:"status"`
+	Response interface{} `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// AuditLogPath returns the file the audit log is written to
+func AuditLogPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".vultr-cli", "audit.jsonl"), nil
+}
+
+// auditTransport appends every request which changes a resource, along with
+// the command which made it and the response, to the audit log.  Reads are
+// never recorded
+type auditTransport struct {
+	next    http.RoundTripper
+	enabled bool
+	command string
+	args    []string // already redacted
+	mu      sync.Mutex
+}
+
+// RoundTrip ...
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled || idempotent(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	entry := &AuditEntry{
+		Time:    time.Now().UTC(),
+		Command: t.command,
+		Args:    t.args,
+		Method:  req.Method,
+		Path:    req.URL.Path,
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if errors.Is(err, ErrDryRun) {
+		// nothing was changed
+		return nil, err
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		t.record(entry)
+		return nil, err
+	}
+
+	body, errRead := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	entry.Status = res.StatusCode
+	entry.Response = redactJSON(bytes.TrimSpace(body))
+	t.record(entry)
+
+	return res, nil
+}
+
+// record appends the entry to the audit log.  The change has already been
+// made so a failure to record it only warns
+func (t *auditTransport) record(entry *AuditEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	line, err := json.Marshal(entry)
+	if err == nil {
+		err = appendAuditLine(line)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing audit log : %v\n", err)
+	}
+}
+
+func appendAuditLine(line []byte) error {
+	path, err := AuditLogPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), auditDirPermission); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditFilePermission)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// sensitive reports whether a flag or field holds a credential which must
+// not be written to the audit log, such as the auths of docker credentials
+// and the kube_config of a cluster
+func sensitive(name string) bool {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	if name == "auth" || name == "auths" || name == "token" || strings.HasSuffix(name, "_token") {
+		return true
+	}
+
+	for _, word := range []string{"password", "secret", "api_key", "kube_config"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArgs hides the values of credential flags in the arguments.  Each
+// flag is looked up in the flags of the command, so the values of shorthands
+// such as -p are hidden as well as those given to the long names
+func redactArgs(flags *pflag.FlagSet, args []string) []string {
+	out := slices.Clone(args)
+	for i := 0; i < len(out); i++ {
+		arg := out[i]
+		switch {
+		case arg == "--":
+			return out
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			f := flags.Lookup(name)
+			switch {
+			case hasValue && sensitive(name):
+				out[i] = "--" + name + "=" + redacted
+			case hasValue || (f != nil && f.NoOptDefVal != ""):
+			case i+1 < len(out) && (f != nil || sensitive(name)):
+				// the value is the next argument
+				i++
+				if sensitive(name) {
+					out[i] = redacted
+				}
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			i = redactShorthands(flags, out, i)
+		}
+	}
+	return out
+}
+
+// redactShorthands hides the value of a credential flag in a group of
+// shorthands such as -vp <value> or -p<value>, and returns the index of the
+// last argument the group used
+func redactShorthands(flags *pflag.FlagSet, args []string, i int) int {
+	arg := args[i]
+	for j := 1; j < len(arg); j++ {
+		f := flags.ShorthandLookup(arg[j : j+1])
+		if f == nil {
+			return i
+		}
+		if f.NoOptDefVal != "" {
+			continue
+		}
+
+		// the rest of the group, or the next argument, is the value
+		if j+1 < len(arg) {
+			if sensitive(f.Name) {
+				value := strings.TrimPrefix(arg[j+1:], "=")
+				args[i] = arg[:len(arg)-len(value)] + redacted
+			}
+			return i
+		}
+		if i+1 < len(args) {
+			if sensitive(f.Name) {
+				args[i+1] = redacted
+			}
+			return i + 1
+		}
+		return i
+	}
+	return i
+}
+
+// redactJSON decodes a JSON response with its credential fields hidden,
+// anything which isn't JSON is kept as a string
+func redactJSON(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	redactValue(v)
+
+	return v
+}
+
+func redactValue(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k := range val {
+			if sensitive(k) {
+				val[k] = redacted
+				continue
+			}
+			redactValue(val[k])
+		}
+	case []interface{}:
+		for i := range val {
+			redactValue(val[i])
+		}
+	}
+}
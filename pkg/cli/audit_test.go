@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestRedactArgs(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringP("password", "p", "", "")
+	flags.StringP("label", "l", "", "")
+	flags.String("root-password", "", "")
+	flags.BoolP("verbose", "v", false, "")
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "no credentials",
+			args: []string{"create", "--label", "web", "-l", "db"},
+			want: []string{"create", "--label", "web", "-l", "db"},
+		},
+		{
+			name: "long flag with the value next",
+			args: []string{"create", "--password", "hunter2", "--label", "web"},
+			want: []string{"create", "--password", redacted, "--label", "web"},
+		},
+		{
+			name: "long flag with equals",
+			args: []string{"create", "--root-password=hunter2"},
+			want: []string{"create", "--root-password=" + redacted},
+		},
+		{
+			name: "shorthand with the value next",
+			args: []string{"create", "-p", "hunter2"},
+			want: []string{"create", "-p", redacted},
+		},
+		{
+			name: "shorthand with the value attached",
+			args: []string{"create", "-phunter2"},
+			want: []string{"create", "-p" + redacted},
+		},
+		{
+			name: "shorthand with equals",
+			args: []string{"create", "-p=hunter2"},
+			want: []string{"create", "-p=" + redacted},
+		},
+		{
+			name: "grouped shorthands",
+			args: []string{"create", "-vp", "hunter2"},
+			want: []string{"create", "-vp", redacted},
+		},
+		{
+			name: "bool flag does not take the next argument",
+			args: []string{"create", "--verbose", "hunter2"},
+			want: []string{"create", "--verbose", "hunter2"},
+		},
+		{
+			name: "unknown credential flag",
+			args: []string{"create", "--api-key", "secret"},
+			want: []string{"create", "--api-key", redacted},
+		},
+		{
+			name: "value which looks like a credential flag",
+			args: []string{"create", "--label", "--password"},
+			want: []string{"create", "--label", "--password"},
+		},
+		{
+			name: "after the end of the flags",
+			args: []string{"ssh", "--", "--password", "hunter2"},
+			want: []string{"ssh", "--", "--password", "hunter2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := slices.Clone(tt.args)
+			got := redactArgs(flags, args)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("redactArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+			if !slices.Equal(args, tt.args) {
+				t.Errorf("redactArgs(%q) changed its argument to %q", tt.args, args)
+			}
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			body: ``,
+			want: `null`,
+		},
+		{
+			name: "not JSON",
+			body: `bad gateway`,
+			want: `"bad gateway"`,
+		},
+		{
+			name: "nothing to hide",
+			body: `{"instance":{"id":"abc","label":"web"}}`,
+			want: `{"instance":{"id":"abc","label":"web"}}`,
+		},
+		{
+			name: "password",
+			body: `{"instance":{"id":"abc","default_password":"hunter2"}}`,
+			want: `{"instance":{"default_password":"[REDACTED]","id":"abc"}}`,
+		},
+		{
+			name: "docker credentials",
+			body: `{"auths":{"ewr.vultrcr.com":{"auth":"dXNlcjpzZWNyZXQ="}}}`,
+			want: `{"auths":"[REDACTED]"}`,
+		},
+		{
+			name: "docker auth nested in a list",
+			body: `[{"registry":"ewr.vultrcr.com","auth":"dXNlcjpzZWNyZXQ="}]`,
+			want: `[{"auth":"[REDACTED]","registry":"ewr.vultrcr.com"}]`,
+		},
+		{
+			name: "token",
+			body: `{"access_token":"abc","expires":60}`,
+			want: `{"access_token":"[REDACTED]","expires":60}`,
+		},
+		{
+			name: "kubeconfig",
+			body: `{"kube_config":"YXBpVmVyc2lvbjogdjE="}`,
+			want: `{"kube_config":"[REDACTED]"}`,
+		},
+		{
+			name: "setting named after tokens",
+			body: `{"innodb_ft_min_token_size":3}`,
+			want: `{"innodb_ft_min_token_size":3}`,
+		},
+		{
+			name: "field which only starts like auth",
+			body: `{"author":"ops"}`,
+			want: `{"author":"ops"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(redactJSON([]byte(tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("redactJSON(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// DefaultCacheTTL is how long catalog responses are reused
+const DefaultCacheTTL = time.Hour
+
+const (
+	cacheDirPermission  = 0o700
+	cacheFilePermission = 0o600
+)
+
+// cachedPaths matches the catalogs which rarely change and are the same for
+// every account
+var cachedPaths = regexp.MustCompile(`^/v2/(regions|plans|plans-metal|os|applications|object-storage/tiers)$`)
+
+// cacheTransport keeps the responses of catalog lists on disk so commands
+// which look them up repeatedly, such as completion and validation, don't
+// wait on the API each time
+type cacheTransport struct {
+	next http.RoundTripper
+	ttl  time.Duration
+	// refresh skips reading the cache, responses are still stored
+	refresh bool
+	dir     string
+}
+
+func newCacheTransport(next http.RoundTripper) *cacheTransport {
+	t := &cacheTransport{next: next, ttl: DefaultCacheTTL}
+	if dir, err := os.UserCacheDir(); err == nil {
+		t.dir = filepath.Join(dir, "vultr-cli", "api")
+	}
+	return t
+}
+
+// RoundTrip ...
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.ttl <= 0 || t.dir == "" || req.Method != http.MethodGet || !cachedPaths.MatchString(req.URL.Path) {
+		return t.next.RoundTrip(req)
+	}
+
+	sum := sha256.Sum256([]byte(req.URL.Host + req.URL.RequestURI()))
+	path := filepath.Join(t.dir, hex.EncodeToString(sum[:]))
+
+	if !t.refresh {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < t.ttl {
+			if body, err := os.ReadFile(filepath.Clean(path)); err == nil {
+				return cachedResponse(req, body), nil
+			}
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	// a failure to cache only costs the next command a request
+	_ = writeCacheFile(path, body)
+
+	return res, nil
+}
+
+// writeCacheFile replaces the cached body through a temporary file, so
+// commands running side by side, such as those of a batch, never read a
+// partly written one
+func writeCacheFile(path string, body []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), cacheDirPermission); err != nil {
+		return err
+	}
+
+	// temporary files are created with cacheFilePermission
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// cachedResponse builds the response for a cached body
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type":   []string{"application/json"},
+			"Content-Length": []string{strconv.Itoa(len(body))},
+		},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -2,11 +2,18 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/pkg/keyring"
 	"golang.org/x/oauth2"
 )
 
@@ -24,37 +31,180 @@ type Base struct {
 	Printer *printer.Output
 	Context context.Context
 	HasAuth bool
+
+	userAgent string
+	apiKey    string
+	endpoint  string
+	audit     *auditTransport
+	cache     *cacheTransport
+	retry     *retryTransport
+	debug     *debugTransport
+	log       *logTransport
+	dryRun    *dryRunTransport
+	apiErrors *errorTransport
+	warnings  *warnTransport
+	cleanups  sync.RWMutex
+}
+
+// Profile holds the settings of a named profile in the config file
+type Profile struct {
+	Name    string
+	APIKey  string `mapstructure:"api-key"`
+	Keyring bool   `mapstructure:"keyring"`
+	Region  string `mapstructure:"region"`
+	Output  string `mapstructure:"output"`
+}
+
+// LoadProfile returns the named profile from the profiles section of the
+// config file
+func LoadProfile(name string) (*Profile, error) {
+	sub := viper.Sub("profiles." + name)
+	if sub == nil {
+		return nil, fmt.Errorf("profile %q not found in config file %s", name, viper.ConfigFileUsed())
+	}
+
+	p := &Profile{Name: name}
+	if err := sub.Unmarshal(p); err != nil {
+		return nil, fmt.Errorf("error reading profile %q : %v", name, err)
+	}
+
+	if p.Keyring && p.APIKey == "" {
+		key, err := keyring.Get(context.Background(), name)
+		if err != nil {
+			return nil, fmt.Errorf("error reading API key of profile %q from keyring : %v", name, err)
+		}
+		p.APIKey = key
+	}
+
+	return p, nil
 }
 
 // NewCLIBase creates new base struct
 func NewCLIBase(apiKey, userAgent, output string) *Base {
 	base := new(Base)
+	base.dryRun = newDryRunTransport(http.DefaultTransport)
+	base.log = newLogTransport(base.dryRun)
+	base.debug = newDebugTransport(base.log)
+	base.retry = &retryTransport{
+		next:    base.debug,
+		retries: DefaultRetries,
+		maxWait: DefaultRetryMaxWait,
+	}
+	base.cache = newCacheTransport(base.retry)
+	base.audit = &auditTransport{next: base.cache}
+	base.warnings = newWarnTransport(base.audit)
+	base.apiErrors = &errorTransport{next: base.warnings}
 	base.configurePrinter()
 	base.configureClient(apiKey, userAgent)
 	base.configureContext()
 	return base
 }
 
+// SetRetries changes how often and how long failed requests are retried
+func (b *Base) SetRetries(retries int, maxWait time.Duration) {
+	b.retry.retries = max(retries, 0)
+	b.retry.maxWait = maxWait
+}
+
+// SetCache changes how long catalog responses are reused, a ttl of 0 turns
+// the cache off.  With refresh the cache is not read but is still updated
+func (b *Base) SetCache(ttl time.Duration, refresh bool) {
+	b.cache.ttl = ttl
+	b.cache.refresh = refresh
+}
+
+// SetAudit records the changes made by the command and its arguments in
+// the audit log.  The flags of the command are used to find the credentials
+// among the arguments, which are hidden
+func (b *Base) SetAudit(enabled bool, command string, args []string, flags *pflag.FlagSet) {
+	b.audit.enabled = enabled
+	b.audit.command = command
+	b.audit.args = redactArgs(flags, args)
+}
+
+// SetDebug logs the requests and responses to stderr, including the headers
+// and payloads when body is set
+func (b *Base) SetDebug(enabled, body bool) {
+	b.debug.enabled = enabled || body
+	b.debug.body = body
+}
+
+// SetDryRun prints the requests which would change a resource instead of
+// sending them
+func (b *Base) SetDryRun(enabled bool) {
+	b.dryRun.enabled = enabled
+}
+
+// UseAPIKey replaces the client with one authenticated by the API key
+func (b *Base) UseAPIKey(apiKey string) {
+	b.newClient(apiKey)
+}
+
 func (b *Base) configureClient(apiKey, userAgent string) {
-	var token string
-	b.HasAuth = false
+	b.userAgent = userAgent
 
-	token = viper.GetString("api-key")
+	token := viper.GetString("api-key")
 	if token == "" {
 		token = apiKey
 	}
 
-	if token == "" {
-		b.Client = govultr.NewClient(nil)
-	} else {
+	b.newClient(token)
+}
+
+// SetEndpoint sends the requests to another API server, such as a mock
+// server or a staging environment, instead of api.vultr.com
+func (b *Base) SetEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid API endpoint %q : %v", endpoint, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid API endpoint %q : must be an http or https URL", endpoint)
+	}
+
+	// the client requests absolute paths such as /v2/instances, which would
+	// silently drop a path of the endpoint
+	if strings.Trim(u.Path, "/") != "" {
+		return fmt.Errorf("invalid API endpoint %q : must not have a path, requests are made to /v2 on it", endpoint)
+	}
+
+	b.endpoint = u.Scheme + "://" + u.Host
+	return b.Client.SetBaseURL(b.endpoint)
+}
+
+// Endpoint returns the API server the requests are sent to
+func (b *Base) Endpoint() string {
+	return b.Client.BaseURL.String()
+}
+
+// APIKey returns the API key the client authenticates with
+func (b *Base) APIKey() string {
+	return b.apiKey
+}
+
+func (b *Base) newClient(token string) {
+	b.HasAuth = false
+	b.apiKey = token
+	b.debug.secret = token
+
+	httpClient := &http.Client{Transport: b.apiErrors}
+	if token != "" {
 		config := &oauth2.Config{}
 		ts := config.TokenSource(context.Background(), &oauth2.Token{AccessToken: token})
-		b.Client = govultr.NewClient(oauth2.NewClient(context.Background(), ts))
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		httpClient = oauth2.NewClient(ctx, ts)
 		b.HasAuth = true
 	}
 
+	// retries are handled by the transport
+	b.Client = govultr.NewClient(httpClient)
+	b.Client.SetRetryLimit(0)
 	b.Client.SetRateLimit(1 * time.Second)
-	b.Client.SetUserAgent(userAgent)
+	b.Client.SetUserAgent(b.userAgent)
+	if b.endpoint != "" {
+		_ = b.Client.SetBaseURL(b.endpoint)
+	}
 }
 
 func (b *Base) configurePrinter() {
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// debugTransport logs every request sent to the API, including retries, to
+// stderr with the API key redacted
+type debugTransport struct {
+	next    http.RoundTripper
+	enabled bool
+	body    bool
+	secret  string
+	out     io.Writer
+}
+
+func newDebugTransport(next http.RoundTripper) *debugTransport {
+	return &debugTransport{next: next, out: os.Stderr}
+}
+
+// RoundTrip ...
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled {
+		return t.next.RoundTrip(req)
+	}
+
+	fmt.Fprintf(t.out, "DEBUG --> %s %s\n", req.Method, req.URL)
+	if t.body {
+		t.logHeaders(req.Header)
+		if err := t.logRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(t.out, "DEBUG <-- %s %s error after %s : %v\n", req.Method, req.URL, elapsed, err)
+		return nil, err
+	}
+
+	fmt.Fprintf(t.out, "DEBUG <-- %s %s %s (%s)", res.Status, req.Method, req.URL, elapsed)
+	if id := res.Header.Get("X-Request-Id"); id != "" {
+		fmt.Fprintf(t.out, " request-id=%s", id)
+	}
+	fmt.Fprintln(t.out)
+
+	if t.body {
+		t.logHeaders(res.Header)
+		if err := t.logResponseBody(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// logRequestBody prints the payload and puts it back for the next transport
+func (t *debugTransport) logRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.logBody(body)
+	return nil
+}
+
+// logResponseBody prints the payload and puts it back for the client
+func (t *debugTransport) logResponseBody(res *http.Response) error {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.logBody(body)
+	return nil
+}
+
+func (t *debugTransport) logBody(body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	fmt.Fprintf(t.out, "%s\n", strings.TrimRight(t.redact(string(body)), "\n"))
+}
+
+func (t *debugTransport) logHeaders(header http.Header) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		value := strings.Join(header[k], ", ")
+		if k == "Authorization" {
+			value = redacted
+		}
+		fmt.Fprintf(t.out, "  %s: %s\n", k, t.redact(value))
+	}
+}
+
+// redact hides the API key wherever it shows up
+func (t *debugTransport) redact(s string) string {
+	if t.secret == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, t.secret, redacted)
+}
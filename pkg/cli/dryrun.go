@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// ErrDryRun is returned for the requests which would change a resource on a
+// dry run, in place of sending them
+var ErrDryRun = errors.New("dry run, the change was not sent")
+
+// dryRunTransport prints the requests which would change a resource instead
+// of sending them, failing them with ErrDryRun.  Reads are still sent since
+// commands rely on them to look up resources.
+type dryRunTransport struct {
+	next    http.RoundTripper
+	enabled bool
+	out     io.Writer
+	changes []string
+	mu      sync.Mutex
+}
+
+func newDryRunTransport(next http.RoundTripper) *dryRunTransport {
+	return &dryRunTransport{next: next, out: os.Stdout}
+}
+
+// RoundTrip ...
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled || idempotent(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.changes = append(t.changes, req.Method+" "+req.URL.String())
+	fmt.Fprintf(t.out, "DRY RUN: %s %s\n", req.Method, req.URL)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.TrimSpace(body)
+
+		var indented bytes.Buffer
+		if json.Indent(&indented, body, "", "    ") != nil {
+			indented.Reset()
+			indented.Write(body)
+		}
+		if indented.Len() > 0 {
+			fmt.Fprintf(t.out, "%s\n", indented.String())
+		}
+	}
+
+	return nil, ErrDryRun
+}
+
+// DryRunChanges returns the requests which were not sent on a dry run, as
+// the method and URL of each
+func (b *Base) DryRunChanges() []string {
+	b.dryRun.mu.Lock()
+	defer b.dryRun.mu.Unlock()
+	return append([]string(nil), b.dryRun.changes...)
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	tr := &dryRunTransport{next: http.DefaultTransport, enabled: true, out: &out}
+
+	tests := []struct {
+		method string
+		body   string
+		err    error
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPost, body: `{"label":"web"}`, err: ErrDryRun},
+		{method: http.MethodDelete, err: ErrDryRun},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, server.URL+"/v2/instances", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := tr.RoundTrip(req)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s returned error %v, want %v", tt.method, err, tt.err)
+		}
+		if res != nil {
+			_ = res.Body.Close()
+		}
+	}
+
+	if want := []string{http.MethodGet}; !slices.Equal(sent, want) {
+		t.Errorf("sent %q, want %q", sent, want)
+	}
+
+	want := []string{
+		http.MethodPost + " " + server.URL + "/v2/instances",
+		http.MethodDelete + " " + server.URL + "/v2/instances",
+	}
+	if !slices.Equal(tr.changes, want) {
+		t.Errorf("changes %q, want %q", tr.changes, want)
+	}
+
+	if !strings.Contains(out.String(), `"label": "web"`) {
+		t.Errorf("body of the change not printed:\n%s", out.String())
+	}
+}
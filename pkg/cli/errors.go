@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// APIError is a failed request made to the API
+type APIError struct {
+	// Status is the HTTP status, 0 when no response was received
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// apiErrorKey is the context key of the apiErrorRecorder of WithAPIError
+type apiErrorKey struct{}
+
+// apiErrorRecorder holds the failure of the last request made with a
+// context from WithAPIError
+type apiErrorRecorder struct {
+	err *APIError
+}
+
+// WithAPIError returns a context whose requests report their failure to the
+// returned function instead of LastAPIError, for callers which handle some
+// failures themselves such as a resource which is already gone.  The
+// function returns the failure of the last request made with the context, or
+// nil when it succeeded
+func WithAPIError(ctx context.Context) (context.Context, func() *APIError) {
+	rec := &apiErrorRecorder{}
+	return context.WithValue(ctx, apiErrorKey{}, rec), func() *APIError {
+		return rec.err
+	}
+}
+
+// errorTransport keeps the last failure so the CLI can tell why a command
+// failed, since govultr only returns the error as text.  Requests may run
+// at the same time so a success doesn't clear the failure of another one
+type errorTransport struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	last *APIError
+}
+
+// RoundTrip ...
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.record(req, &APIError{Message: err.Error()})
+		return nil, err
+	}
+
+	if res.StatusCode < http.StatusBadRequest {
+		t.record(req, nil)
+		return res, nil
+	}
+
+	body, errRead := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	failure := &APIError{Status: res.StatusCode, Message: string(bytes.TrimSpace(body))}
+
+	// the API reports errors as {"error": "...", "status": 404}
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		failure.Message = apiErr.Error
+	}
+	t.record(req, failure)
+
+	return res, nil
+}
+
+// record keeps the outcome of the request, nil when it succeeded, for the
+// caller which asked for it with WithAPIError or otherwise as the last
+// failure
+func (t *errorTransport) record(req *http.Request, failure *APIError) {
+	if rec, ok := req.Context().Value(apiErrorKey{}).(*apiErrorRecorder); ok {
+		rec.err = failure
+		return
+	}
+
+	if failure == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.last = failure
+}
+
+// LastAPIError returns the last failed request made to the API, or nil when
+// none failed.  Failures of requests made with a context from WithAPIError
+// aren't included
+func (b *Base) LastAPIError() *APIError {
+	b.apiErrors.mu.Lock()
+	defer b.apiErrors.mu.Unlock()
+	return b.apiErrors.last
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	// ExitInterrupted is the exit code of a command stopped by an interrupt,
+	// following the shell convention of 128 plus SIGINT
+	ExitInterrupted = 130
+
+	// interruptGrace is how long a command has to stop after an interrupt
+	// before the CLI exits anyway, such as when it waits on a prompt
+	interruptGrace = 5 * time.Second
+)
+
+// CancelOnInterrupt cancels the context of the commands on SIGINT or SIGTERM
+// so requests, waits and polling stop cleanly.  The CLI doesn't exit while a
+// cleanup is running, see Cleanup.  A second interrupt exits right away.
+func (b *Base) CancelOnInterrupt() {
+	ctx, cancel := context.WithCancel(b.Context)
+	b.Context = ctx
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		cancel()
+		fmt.Fprintln(os.Stderr, "interrupted, stopping")
+
+		select {
+		case <-signals:
+			os.Exit(ExitInterrupted)
+		case <-time.After(interruptGrace):
+		}
+
+		cleaned := make(chan struct{})
+		go func() {
+			b.cleanups.Lock()
+			close(cleaned)
+		}()
+
+		select {
+		case <-signals:
+		case <-cleaned:
+		}
+		os.Exit(ExitInterrupted)
+	}()
+}
+
+// Cleanup returns the context to undo the work of the command with, such as
+// deleting temporary resources, which an interrupt doesn't cancel.  Until
+// done is called an interrupt doesn't make the CLI exit, so it should be
+// called as soon as there is something to clean up.
+func (b *Base) Cleanup() (ctx context.Context, done func()) {
+	b.cleanups.RLock()
+	return context.WithoutCancel(b.Context), b.cleanups.RUnlock
+}
+
+// Interrupted reports whether the command was stopped by an interrupt
+func (b *Base) Interrupted() bool {
+	return b.Context.Err() != nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultLogLevel is the lowest level written to the log file
+const DefaultLogLevel = "info"
+
+const logFilePermission = 0o600
+
+// logTransport writes every request sent to the API, including retries, to
+// the structured log.  Failed requests are logged as warnings.
+type logTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func newLogTransport(next http.RoundTripper) *logTransport {
+	return &logTransport{next: next, logger: slog.New(slog.DiscardHandler)}
+}
+
+// RoundTrip ...
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+	}
+
+	if err != nil {
+		t.logger.Warn("request failed", append(attrs, slog.String("error", err.Error()))...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+	if id := res.Header.Get("X-Request-Id"); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		t.logger.Warn("request failed", attrs...)
+	} else {
+		t.logger.Debug("request", attrs...)
+	}
+
+	return res, nil
+}
+
+// SetLog writes JSON logs of the command and its API requests to the file,
+// or to stderr when the file is -.  Records below the level, one of debug,
+// info, warn or error, are dropped.  An empty file turns logging off.
+func (b *Base) SetLog(file, level string) error {
+	if file == "" {
+		b.log.logger = slog.New(slog.DiscardHandler)
+		return nil
+	}
+
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %q, must be one of debug, info, warn or error", level)
+	}
+
+	var out io.Writer = os.Stderr
+	if file != "-" {
+		// the file is left open until the CLI exits
+		f, err := os.OpenFile(filepath.Clean(file), os.O_APPEND|os.O_CREATE|os.O_WRONLY, logFilePermission)
+		if err != nil {
+			return fmt.Errorf("error opening log file : %v", err)
+		}
+		out = f
+	}
+
+	b.log.logger = slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: lvl})).With(
+		slog.Int("pid", os.Getpid()),
+		slog.String("user_agent", b.userAgent),
+	)
+
+	return nil
+}
+
+// Logger returns the structured logger of the CLI, which discards the
+// records unless a log file is set
+func (b *Base) Logger() *slog.Logger {
+	return b.log.logger
+}
+
+// LogCommand logs the start of the command with its arguments, the values
+// of credential flags, found through the flags of the command, hidden
+func (b *Base) LogCommand(command string, args []string, flags *pflag.FlagSet) {
+	b.log.logger.Info("command started", slog.String("command", command), slog.Any("args", redactArgs(flags, args)))
+}
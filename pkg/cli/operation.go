@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+const operationIDBytes = 6
+
+// operationID matches the IDs made by NewOperation, so an ID given by the
+// user can't point outside the operations directory
+var operationID = regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+// Operation is the progress of a long running command, kept in a state file
+// so the command can be resumed with the steps it completed skipped after it
+// was interrupted or failed
+type Operation struct {
+	ID      string          `json:"id"`
+	Command string          `json:"command"`
+	Created time.Time       `json:"created"`
+	Updated time.Time       `json:"updated"`
+	Input   json.RawMessage `json:"input"`
+	Done    []string        `json:"done"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// operationsDir returns the directory of the state files
+func operationsDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the cache directory : %v", err)
+	}
+	return filepath.Join(dir, "vultr-cli", "operations"), nil
+}
+
+// NewOperation starts the state file of the command.  The input holds what
+// the command needs to run again, such as its list of steps.
+func NewOperation(command string, input interface{}) (*Operation, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("error saving operation : %v", err)
+	}
+
+	id := make([]byte, operationIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("error saving operation : %v", err)
+	}
+
+	dir, err := operationsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	op := &Operation{
+		ID:      hex.EncodeToString(id),
+		Command: command,
+		Created: now,
+		Updated: now,
+		Input:   data,
+		Done:    []string{},
+	}
+	op.path = filepath.Join(dir, op.ID+".json")
+
+	if err := op.save(); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// LoadOperation reads the state file of the operation to resume it
+func LoadOperation(command, id string) (*Operation, error) {
+	if !operationID.MatchString(id) {
+		return nil, fmt.Errorf("invalid operation ID %q", id)
+	}
+
+	dir, err := operationsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, id+".json")
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("operation %s not found, it may have already completed", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading operation %s : %v", id, err)
+	}
+
+	op := &Operation{path: path}
+	if err := json.Unmarshal(data, op); err != nil {
+		return nil, fmt.Errorf("error reading operation %s : %v", id, err)
+	}
+
+	if op.Command != command {
+		return nil, fmt.Errorf("operation %s was started by %s, not %s", id, op.Command, command)
+	}
+
+	return op, nil
+}
+
+// Decode reads the input the operation was started with into v
+func (op *Operation) Decode(v interface{}) error {
+	if err := json.Unmarshal(op.Input, v); err != nil {
+		return fmt.Errorf("error reading operation %s : %v", op.ID, err)
+	}
+	return nil
+}
+
+// IsDone reports whether the step was completed by an earlier run
+func (op *Operation) IsDone(step string) bool {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return slices.Contains(op.Done, step)
+}
+
+// MarkDone records the step as completed.  The state file is saved right
+// away so an interrupt loses no progress.
+func (op *Operation) MarkDone(step string) error {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if !slices.Contains(op.Done, step) {
+		op.Done = append(op.Done, step)
+	}
+	op.Updated = time.Now().UTC()
+
+	return op.save()
+}
+
+// Remove deletes the state file once every step is completed
+func (op *Operation) Remove() error {
+	if err := os.Remove(op.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing operation %s : %v", op.ID, err)
+	}
+	return nil
+}
+
+// save writes the state file through a temporary file, so an interrupted
+// write doesn't lose the earlier progress
+func (op *Operation) save() error {
+	data, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error saving operation %s : %v", op.ID, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(op.path), cacheDirPermission); err != nil {
+		return fmt.Errorf("error saving operation %s : %v", op.ID, err)
+	}
+
+	tmp := op.path + ".tmp"
+	if err := os.WriteFile(tmp, data, cacheFilePermission); err != nil {
+		return fmt.Errorf("error saving operation %s : %v", op.ID, err)
+	}
+
+	if err := os.Rename(tmp, op.path); err != nil {
+		return fmt.Errorf("error saving operation %s : %v", op.ID, err)
+	}
+
+	return nil
+}
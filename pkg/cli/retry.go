@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetries is the number of times a failed request is retried
+	DefaultRetries = 3
+	// DefaultRetryMaxWait caps the wait between retries
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryMinWait = time.Second
+	// retryMaxShift caps the exponent of the backoff well above any useful
+	// wait, so the shift can't overflow
+	retryMaxShift = 20
+)
+
+// retryTransport retries requests which were rate limited, honoring the
+// Retry-After header, and idempotent requests which failed on a transient
+// network or server error
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	maxWait time.Duration
+}
+
+// RoundTrip ...
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// keep the body so it can be sent again
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		res, err := t.next.RoundTrip(req)
+		if attempt >= t.retries || !t.shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether the request can be sent again.  A rate
+// limited request was not processed so any method is retried while other
+// failures are only retried for methods which are safe to repeat.
+func (t *retryTransport) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return idempotent(req.Method)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented:
+		return idempotent(req.Method)
+	}
+
+	return false
+}
+
+// backoff returns the wait before the next attempt, taken from the
+// Retry-After header when given or growing exponentially with some jitter
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, t.maxWait)
+		}
+	}
+
+	wait := t.maxWait
+	if attempt < retryMaxShift {
+		wait = min(retryMinWait<<attempt, t.maxWait)
+	}
+	if jitter := wait / 2; jitter > 0 {
+		wait += rand.N(jitter) //nolint:gosec
+	}
+	return min(wait, t.maxWait)
+}
+
+// retryAfter parses the Retry-After header given either in seconds or as an
+// HTTP date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// idempotent reports whether the request only reads.  OPTIONS isn't one of
+// them since the API creates container registry credentials with it
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
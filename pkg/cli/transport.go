@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// TransportOptions changes how the CLI connects to the API
+type TransportOptions struct {
+	// Proxy is the URL of an HTTP, HTTPS or SOCKS5 proxy.  When empty the
+	// HTTPS_PROXY and NO_PROXY variables are used.
+	Proxy string
+	// CACert is a PEM file of certificates trusted in addition to the
+	// system ones, such as the CA of a TLS inspecting proxy
+	CACert string
+	// InsecureSkipVerify turns off the verification of the API certificate
+	InsecureSkipVerify bool
+}
+
+// SetTransport changes the proxy and the certificates the requests to the
+// API are made with
+func (b *Base) SetTransport(o TransportOptions) error {
+	t, err := newTransport(o)
+	if err != nil {
+		return err
+	}
+
+	b.dryRun.next = t
+	return nil
+}
+
+// Transport returns the transport the requests to the API are sent with,
+// for requests to other services which should go through the same proxy
+func (b *Base) Transport() http.RoundTripper {
+	return b.dryRun.next
+}
+
+func newTransport(o TransportOptions) (http.RoundTripper, error) {
+	if o.Proxy == "" && o.CACert == "" && !o.InsecureSkipVerify {
+		return http.DefaultTransport, nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+
+	if o.Proxy != "" {
+		proxy, err := parseProxy(o.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if o.CACert != "" || o.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: o.InsecureSkipVerify, //nolint:gosec
+		}
+	}
+
+	if o.CACert != "" {
+		pool, err := certPool(o.CACert)
+		if err != nil {
+			return nil, err
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+
+	return t, nil
+}
+
+// parseProxy parses the proxy URL, which needs a scheme since a SOCKS5
+// proxy can't be told apart from an HTTP one otherwise
+func parseProxy(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q : %v", proxy, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q : scheme must be http, https, socks5 or socks5h", proxy)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q : missing host", proxy)
+	}
+
+	return u, nil
+}
+
+// certPool returns the system certificates with the ones in the PEM file
+// added
+func certPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate : %v", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("error reading CA certificate : no PEM certificate found in %s", file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// WaitInterval is the default delay between polls when waiting on a resource
+	WaitInterval = 10 * time.Second
+	// WaitTimeout is the default maximum time to wait on a resource
+	WaitTimeout = 30 * time.Minute
+)
+
+// WaitFunc reports the status of the resource being waited on and whether it
+// has reached the state wanted
+type WaitFunc func() (status string, done bool, err error)
+
+// Poll calls check every interval until it reports done, returns an error or
+// the timeout elapses
+func Poll(ctx context.Context, interval, timeout time.Duration, check func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("timed out after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Wait polls the resource until check reports it done or the timeout
+// elapses.  The status is written to stderr along with the time waited so
+// far whenever it changes, so every command follows its resources the same
+// way.  A timeout of 0 uses WaitTimeout.
+func (b *Base) Wait(resource string, timeout time.Duration, check WaitFunc) error {
+	if timeout <= 0 {
+		timeout = WaitTimeout
+	}
+
+	start := time.Now()
+	last := ""
+	err := Poll(b.Context, WaitInterval, timeout, func() (bool, error) {
+		status, done, err := check()
+		if err != nil {
+			return false, err
+		}
+
+		if status != last {
+			fmt.Fprintf(os.Stderr, "waiting for %s : %s (%s)\n", resource, status, time.Since(start).Round(time.Second))
+			last = status
+		}
+
+		return done, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for %s : %v", resource, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersion is the newest version of the API this CLI was built against.
+// Responses reporting a newer version mean a newer CLI likely supports it.
+const APIVersion = "2.0"
+
+const (
+	// apiVersionHeader is the response header reporting the API version
+	apiVersionHeader = "X-Api-Version"
+	// warningCode is the code of the Warning header for a persistent warning
+	warningCode = "299"
+)
+
+// warnTransport warns on stderr when the API reports an endpoint as
+// deprecated, with the Deprecation, Sunset or Warning headers, or reports a
+// newer version than the CLI knows.  Each warning is printed once.
+type warnTransport struct {
+	next    http.RoundTripper
+	enabled bool
+	out     io.Writer
+
+	mu     sync.Mutex
+	warned map[string]bool
+}
+
+func newWarnTransport(next http.RoundTripper) *warnTransport {
+	return &warnTransport{
+		next:    next,
+		enabled: true,
+		out:     os.Stderr,
+		warned:  make(map[string]bool),
+	}
+}
+
+// RoundTrip ...
+func (t *warnTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil || !t.enabled {
+		return res, err
+	}
+
+	endpoint := req.Method + " " + req.URL.Path
+	if msg := deprecation(res.Header); msg != "" {
+		t.warn(endpoint, fmt.Sprintf("%s is deprecated by the API%s", endpoint, msg))
+	}
+
+	for _, w := range res.Header.Values("Warning") {
+		if code, text, ok := strings.Cut(w, " "); ok && code == warningCode {
+			t.warn(w, warningText(text))
+		}
+	}
+
+	if v := res.Header.Get(apiVersionHeader); v != "" && NewerVersion(v, APIVersion) {
+		t.warn(apiVersionHeader, fmt.Sprintf(
+			"the API is at version %s, newer than the %s this vultr-cli supports, "+
+				"check for a newer vultr-cli with: vultr-cli update --check",
+			v,
+			APIVersion,
+		))
+	}
+
+	return res, nil
+}
+
+// warn prints the message the first time the key is seen
+func (t *warnTransport) warn(key, msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.warned[key] {
+		return
+	}
+	t.warned[key] = true
+
+	fmt.Fprintf(t.out, "warning: %s\n", msg)
+}
+
+// deprecation returns the detail of the deprecation of the endpoint, which
+// is empty when it isn't deprecated.  The Deprecation header is true or a
+// date and the Sunset header is the date the endpoint is removed.
+func deprecation(h http.Header) string {
+	dep := h.Get("Deprecation")
+	sunset := h.Get("Sunset")
+	if dep == "" && sunset == "" {
+		return ""
+	}
+
+	var detail string
+	if date := headerDate(dep); date != "" {
+		detail += " since " + date
+	}
+
+	if date := headerDate(sunset); date != "" {
+		detail += " and will be removed on " + date
+	} else if sunset != "" {
+		detail += " and will be removed"
+	}
+
+	if link := deprecationLink(h); link != "" {
+		detail += ", see " + link
+	}
+
+	return detail
+}
+
+// headerDate formats the date of a Deprecation or Sunset header, either an
+// HTTP date or a @ prefixed Unix time
+func headerDate(v string) string {
+	if ts, ok := strings.CutPrefix(v, "@"); ok {
+		if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC().Format(time.DateOnly)
+		}
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return t.UTC().Format(time.DateOnly)
+	}
+
+	return ""
+}
+
+// deprecationLink returns the URL of the Link header with the deprecation
+// or sunset relation
+func deprecationLink(h http.Header) string {
+	for _, v := range h.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			if strings.Contains(params, `rel="deprecation"`) || strings.Contains(params, `rel="sunset"`) {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// warningText returns the quoted text of a Warning header value following
+// its code, which is "299 <agent> "<text>" [<date>]"
+func warningText(v string) string {
+	if _, after, ok := strings.Cut(v, `"`); ok {
+		if text, _, ok := strings.Cut(after, `"`); ok {
+			return text
+		}
+	}
+	return strings.TrimSpace(v)
+}
+
+// NewerVersion reports whether version a is newer than version b.  Versions
+// are MAJOR.MINOR.PATCH with an optional v prefix, anything after a - is
+// ignored and missing parts are 0.
+func NewerVersion(a, b string) bool {
+	parse := func(v string) [3]int {
+		var parts [3]int
+		v, _, _ = strings.Cut(strings.TrimPrefix(strings.TrimSpace(v), "v"), "-")
+		for i, p := range strings.SplitN(v, ".", len(parts)) {
+			parts[i], _ = strconv.Atoi(p)
+		}
+		return parts
+	}
+
+	x, y := parse(a), parse(b)
+	for i := range x {
+		if x[i] != y[i] {
+			return x[i] > y[i]
+		}
+	}
+
+	return false
+}
+
+// SetWarnings turns the API deprecation and version warnings on or off
+func (b *Base) SetWarnings(enabled bool) {
+	b.warnings.enabled = enabled
+}
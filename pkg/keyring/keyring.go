@@ -0,0 +1,38 @@
+// Package keyring stores API keys in the credential store of the operating
+// system: the Keychain on macOS, the Credential Manager on Windows and the
+// Secret Service (libsecret) elsewhere.
+package keyring
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// Service is the name the secrets are stored under
+	Service = "vultr-cli"
+	// DefaultAccount holds the API key used without a profile
+	DefaultAccount = "default"
+)
+
+var (
+	// ErrNotFound is returned when no secret is stored for the account
+	ErrNotFound = errors.New("secret not found in keyring")
+	// ErrUnsupported is returned when the credential store can not be used
+	ErrUnsupported = errors.New("keyring is not supported on this system")
+)
+
+// Get returns the secret stored for the account
+func Get(ctx context.Context, account string) (string, error) {
+	return get(ctx, account)
+}
+
+// Set stores the secret for the account, replacing any existing one
+func Set(ctx context.Context, account, secret string) error {
+	return set(ctx, account, secret)
+}
+
+// Delete removes the secret stored for the account
+func Delete(ctx context.Context, account string) error {
+	return remove(ctx, account)
+}
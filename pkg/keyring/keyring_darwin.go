@@ -0,0 +1,62 @@
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// errItemNotFound is the exit status of the security tool when no item
+// matches
+const errItemNotFound = 44
+
+func get(ctx context.Context, account string) (string, error) {
+	out, err := exec.CommandContext(ctx, "security", "find-generic-password", "-s", Service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", keychainError(err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// set passes the secret hex encoded on stdin so it never shows up in the
+// process list
+func set(ctx context.Context, account, secret string) error {
+	command := fmt.Sprintf(
+		"add-generic-password -U -s %q -a %q -X %s\n",
+		Service,
+		account,
+		hex.EncodeToString([]byte(secret)),
+	)
+
+	cmd := exec.CommandContext(ctx, "security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v : %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func remove(ctx context.Context, account string) error {
+	cmd := exec.CommandContext(ctx, "security", "delete-generic-password", "-s", Service, "-a", account)
+	if err := cmd.Run(); err != nil {
+		return keychainError(err)
+	}
+	return nil
+}
+
+func keychainError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == errItemNotFound {
+		return ErrNotFound
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrUnsupported
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+//go:build !darwin && !windows
+
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// the secret-tool command of libsecret talks to the Secret Service provided
+// by GNOME Keyring, KWallet and KeePassXC
+
+func get(ctx context.Context, account string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "secret-tool", "lookup", "service", Service, "account", account)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// lookup exits with 1 and no output when nothing matches
+		if stderr.Len() == 0 && len(out) == 0 && !errors.Is(err, exec.ErrNotFound) {
+			return "", ErrNotFound
+		}
+		return "", secretToolError(err, &stderr)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func set(ctx context.Context, account, secret string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(
+		ctx,
+		"secret-tool", "store",
+		"--label", fmt.Sprintf("%s (%s)", Service, account),
+		"service", Service,
+		"account", account,
+	)
+	cmd.Stdin = strings.NewReader(secret)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, &stderr)
+	}
+	return nil
+}
+
+func remove(ctx context.Context, account string) error {
+	if _, err := get(ctx, account); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "secret-tool", "clear", "service", Service, "account", account)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, &stderr)
+	}
+	return nil
+}
+
+func secretToolError(err error, stderr *bytes.Buffer) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%w : secret-tool from libsecret is not installed", ErrUnsupported)
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%v : %s", err, msg)
+	}
+	return err
+}
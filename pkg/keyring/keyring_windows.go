@@ -0,0 +1,120 @@
+package keyring
+
+import (
+	"context"
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+var (
+	advapi32       = windows.NewLazySystemDLL("advapi32.dll")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors the CREDENTIALW structure of the Credential Manager
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// target is the name of the generic credential holding the secret
+func target(account string) (*uint16, error) {
+	return windows.UTF16PtrFromString(Service + ":" + account)
+}
+
+func get(_ context.Context, account string) (string, error) {
+	name, err := target(account)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, errCall := procCredRead.Call(
+		uintptr(unsafe.Pointer(name)),
+		credTypeGeneric,
+		0,
+		uintptr(unsafe.Pointer(&cred)),
+	)
+	if ret == 0 {
+		return "", credError(errCall)
+	}
+
+	secret := string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize))
+	_, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	return secret, nil
+}
+
+func set(_ context.Context, account, secret string) error {
+	name, err := target(account)
+	if err != nil {
+		return err
+	}
+
+	user, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         name,
+		UserName:           user,
+		CredentialBlobSize: uint32(len(blob)), //nolint:gosec
+		Persist:            credPersistLocalMachine,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ret, _, errCall := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return credError(errCall)
+	}
+	return nil
+}
+
+func remove(_ context.Context, account string) error {
+	name, err := target(account)
+	if err != nil {
+		return err
+	}
+
+	ret, _, errCall := procCredDelete.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0)
+	if ret == 0 {
+		return credError(errCall)
+	}
+	return nil
+}
+
+func credError(err error) error {
+	if errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return ErrNotFound
+	}
+	var dllErr *windows.DLLError
+	if errors.As(err, &dllErr) {
+		return ErrUnsupported
+	}
+	return err
+}